@@ -6,6 +6,7 @@ import (
 	"net/http"
 	"os"
 	"os/signal"
+	"sync"
 	"syscall"
 	"time"
 
@@ -14,24 +15,49 @@ import (
 	"github.com/AndikaPrasetia/wash-shoe/internal/config"
 	"github.com/AndikaPrasetia/wash-shoe/internal/delivery/handler"
 	"github.com/AndikaPrasetia/wash-shoe/internal/middleware"
+	"github.com/AndikaPrasetia/wash-shoe/internal/notification"
+	"github.com/AndikaPrasetia/wash-shoe/internal/payment"
 	"github.com/AndikaPrasetia/wash-shoe/internal/redis"
 	"github.com/AndikaPrasetia/wash-shoe/internal/repository"
+	"github.com/AndikaPrasetia/wash-shoe/internal/sqlc/blog"
+	"github.com/AndikaPrasetia/wash-shoe/internal/sqlc/catalog"
+	"github.com/AndikaPrasetia/wash-shoe/internal/sqlc/photo"
+	"github.com/AndikaPrasetia/wash-shoe/internal/sqlc/promo"
+	"github.com/AndikaPrasetia/wash-shoe/internal/sqlc/referral"
+	"github.com/AndikaPrasetia/wash-shoe/internal/sqlc/review"
 	"github.com/AndikaPrasetia/wash-shoe/internal/sqlc/user"
+	"github.com/AndikaPrasetia/wash-shoe/internal/storage"
 	"github.com/AndikaPrasetia/wash-shoe/internal/usecase"
 	utils "github.com/AndikaPrasetia/wash-shoe/internal/utils/services"
 	"github.com/gin-gonic/gin"
 )
 
 type Server struct {
-	engine    *gin.Engine
-	server    *http.Server
-	jwtSvc    utils.JwtService
-	dbPool    *pgxpool.Pool
-	querier   user.Querier
-	authUC    usecase.AuthUserUsecase
-	host      string
-	port      string
-	redisCli  *redis.RedisClient
+	engine      *gin.Engine
+	server      *http.Server
+	jwtSvc      utils.JwtService
+	dbPool      *pgxpool.Pool
+	querier     user.Querier
+	authUC      usecase.AuthUserUsecase
+	orderUC     usecase.OrderUsecase
+	serviceUC   usecase.ServiceTypeUsecase
+	addressUC   usecase.AddressUsecase
+	paymentUC   usecase.PaymentUsecase
+	promoUC     usecase.PromoUsecase
+	photoUC     usecase.PhotoUsecase
+	photoLimit  int64
+	reviewUC    usecase.ReviewUsecase
+	complaintUC usecase.ComplaintUsecase
+	referralUC  usecase.ReferralUsecase
+	walletUC    usecase.WalletUsecase
+	notifyUC    usecase.NotificationUsecase
+	eventUC     usecase.OrderEventUsecase
+	blogUC      usecase.BlogUsecase
+	accountUC   usecase.AccountUsecase
+	revokeUC    usecase.TokenRevocationUsecase
+	host        string
+	port        string
+	redisCli    *redis.RedisClient
 }
 
 func NewServer() *Server {
@@ -64,18 +90,95 @@ func NewServer() *Server {
 
 	authRepo := repository.NewAuthUserRepo(queries)
 	userRepo := repository.NewUserRepo(queries)
-	authUC := usecase.NewAuthUserUsecase(authRepo, userRepo, redisCli)
+	referralUC := usecase.NewReferralUsecase(
+		repository.NewReferralRepo(referral.New(dbPool)), cfg.ReferralConfig)
+	jwtSvc := utils.NewJwtService(cfg.TokenConfig)
+	revocationUC := usecase.NewTokenRevocationUsecase(redisCli, cfg.TokenConfig)
+	authUC := usecase.NewAuthUserUsecase(authRepo, userRepo, referralUC, jwtSvc, revocationUC,
+		repository.NewRefreshTokenRepo(dbPool), redisCli, cfg.TokenConfig)
+
+	addressRepo := repository.NewAddressRepo(dbPool)
+	promoUC := usecase.NewPromoUsecase(repository.NewPromoRepo(promo.New(dbPool)))
+	orderRepo := repository.NewOrderRepo(dbPool)
+	eventUC := usecase.NewOrderEventUsecase(orderRepo, redisCli)
+	orderUC := usecase.NewOrderUsecase(orderRepo, addressRepo, promoUC, eventUC, cfg.OrderConfig)
+	addressUC := usecase.NewAddressUsecase(addressRepo)
+	serviceRepo := repository.NewServiceTypeRepo(catalog.New(dbPool))
+	serviceUC := usecase.NewServiceTypeUsecase(serviceRepo)
+
+	gateways := []payment.PaymentGateway{payment.NewCODGateway()}
+	for _, method := range cfg.PaymentConfig.FakeMethods {
+		gateways = append(gateways, payment.NewFakeGateway(method, payment.StatusSuccess))
+	}
+	walletRepo := repository.NewWalletRepo(dbPool)
+	walletUC := usecase.NewWalletUsecase(walletRepo)
+	paymentUC := usecase.NewPaymentUsecase(
+		repository.NewPaymentRepo(dbPool), walletRepo, orderUC, eventUC, payment.NewRegistry(gateways...),
+		cfg.PaymentConfig.WebhookSecrets)
+
+	blobs, err := storage.NewLocalStore(cfg.StorageConfig.Dir)
+	if err != nil {
+		panic(fmt.Errorf("failed to init storage: %v", err))
+	}
+	photoUC := usecase.NewPhotoUsecase(
+		repository.NewPhotoRepo(photo.New(dbPool)), orderUC, blobs, cfg.StorageConfig.MaxPhotoSize)
+
+	reviewUC := usecase.NewReviewUsecase(
+		repository.NewReviewRepo(review.New(dbPool)), serviceRepo, orderUC, redisCli)
+
+	complaintUC := usecase.NewComplaintUsecase(repository.NewComplaintRepo(dbPool), orderUC)
+
+	notifiers := []notification.Notifier{notification.NewInAppNotifier()}
+	if cfg.NotificationConfig.SMTPHost != "" {
+		notifiers = append(notifiers, notification.NewEmailNotifier(notification.SMTPConfig{
+			Host:     cfg.NotificationConfig.SMTPHost,
+			Port:     cfg.NotificationConfig.SMTPPort,
+			Username: cfg.NotificationConfig.SMTPUsername,
+			Password: cfg.NotificationConfig.SMTPPassword,
+			From:     cfg.NotificationConfig.SMTPFrom,
+		}))
+	}
+	if cfg.NotificationConfig.WhatsAppURL != "" {
+		notifiers = append(notifiers, notification.NewWhatsAppNotifier(notification.NewHTTPWhatsAppProvider(
+			cfg.NotificationConfig.WhatsAppURL, cfg.NotificationConfig.WhatsAppToken)))
+	}
+	notifyUC := usecase.NewNotificationUsecase(
+		repository.NewNotificationRepo(dbPool), notification.NewRegistry(notifiers...), redisCli,
+		cfg.NotificationConfig)
+
+	// the client IP recorded with sessions is taken from X-Forwarded-For
+	// only when the request comes through one of the trusted proxies
+	engine := gin.Default()
+	if err := engine.SetTrustedProxies(cfg.APIConfig.TrustedProxies); err != nil {
+		panic(fmt.Errorf("invalid TRUSTED_PROXIES: %v", err))
+	}
 
 	// misalnya lanjutkan setup Server
 	s := &Server{
-		engine:   gin.Default(),
-		jwtSvc:   utils.NewJwtService(cfg.TokenConfig),
-		querier:  queries,
-		authUC:   authUC,
-		host:     cfg.APIConfig.APIHost,
-		port:     cfg.APIConfig.APIPort,
-		dbPool:   dbPool,
-		redisCli: redisCli,
+		engine:      engine,
+		jwtSvc:      jwtSvc,
+		querier:     queries,
+		authUC:      authUC,
+		orderUC:     orderUC,
+		serviceUC:   serviceUC,
+		addressUC:   addressUC,
+		paymentUC:   paymentUC,
+		promoUC:     promoUC,
+		photoUC:     photoUC,
+		photoLimit:  cfg.StorageConfig.MaxPhotoSize,
+		reviewUC:    reviewUC,
+		complaintUC: complaintUC,
+		referralUC:  referralUC,
+		walletUC:    walletUC,
+		notifyUC:    notifyUC,
+		eventUC:     eventUC,
+		blogUC:      usecase.NewBlogUsecase(repository.NewBlogRepo(blog.New(dbPool))),
+		accountUC:   usecase.NewAccountUsecase(repository.NewAccountRepo(dbPool), orderRepo, revocationUC, redisCli),
+		revokeUC:    revocationUC,
+		host:        cfg.APIConfig.APIHost,
+		port:        cfg.APIConfig.APIPort,
+		dbPool:      dbPool,
+		redisCli:    redisCli,
 	}
 	return s
 }
@@ -83,7 +186,25 @@ func NewServer() *Server {
 func (s *Server) initRoute() {
 	// Buat handler
 	authHandler := handler.NewAuthHandler(s.authUC)
-	userHandler := handler.NewUserHandler(usecase.NewUserUsecase(repository.NewUserRepo(s.querier)))
+	userHandler := handler.NewUserHandler(usecase.NewUserUsecase(repository.NewUserRepo(s.querier), s.redisCli))
+	orderHandler := handler.NewOrderHandler(s.orderUC)
+	orderEventHandler := handler.NewOrderEventHandler(s.orderUC, s.eventUC)
+	s.server.RegisterOnShutdown(orderEventHandler.Close)
+	serviceHandler := handler.NewServiceTypeHandler(s.serviceUC)
+	addressHandler := handler.NewAddressHandler(s.addressUC)
+	paymentHandler := handler.NewPaymentHandler(s.paymentUC)
+	promoHandler := handler.NewPromoHandler(s.promoUC, s.orderUC)
+	photoHandler := handler.NewPhotoHandler(s.photoUC, s.photoLimit)
+	reviewHandler := handler.NewReviewHandler(s.reviewUC)
+	complaintHandler := handler.NewComplaintHandler(s.complaintUC)
+	referralHandler := handler.NewReferralHandler(s.referralUC)
+	walletHandler := handler.NewWalletHandler(s.walletUC)
+	notificationHandler := handler.NewNotificationHandler(s.notifyUC)
+	blogHandler := handler.NewBlogHandler(s.blogUC)
+	accountHandler := handler.NewAccountHandler(s.accountUC)
+	jwksHandler := handler.NewJWKSHandler(s.jwtSvc)
+
+	s.engine.GET("/.well-known/jwks.json", jwksHandler.Get)
 
 	// Grup publik (tanpa middleware)
 	publicGroup := s.engine.Group("/api/v1")
@@ -92,29 +213,180 @@ func (s *Server) initRoute() {
 		publicGroup.POST("/auth/login", authHandler.Login)
 		// Add refresh token endpoint
 		publicGroup.POST("/auth/refresh", authHandler.RefreshToken)
+
+		publicGroup.GET("/services", serviceHandler.List)
+		publicGroup.GET("/services/:id", serviceHandler.GetByID)
+		publicGroup.GET("/services/:id/ratings", reviewHandler.ServiceTypeRating)
+
+		publicGroup.GET("/blog/posts", blogHandler.ListPublished)
+		publicGroup.GET("/blog/posts/:slug", blogHandler.GetPublished)
+
+		publicGroup.POST("/webhooks/payments/:provider", paymentHandler.Webhook)
 	}
 
 	// Grup proteksi (dengan middleware)
-	authMiddleware := middleware.NewAuthMiddleware(s.jwtSvc)
+	authMiddleware := middleware.NewAuthMiddleware(s.jwtSvc, s.accountUC, s.revokeUC)
 	protectedGroup := s.engine.Group("/api/v1")
 	protectedGroup.Use(authMiddleware.Middleware()) // <<< MIDDLEWARE DITERAPKAN DI SINI
 	{
 		protectedGroup.POST("/auth/logout", authHandler.Logout) // <<< ENDPOINT LOGOUT DIPINDAH KE SINI
+		protectedGroup.PUT("/auth/password", authHandler.ChangePassword)
+		protectedGroup.GET("/auth/sessions", authHandler.Sessions)
+		protectedGroup.DELETE("/auth/sessions/others", authHandler.RevokeOtherSessions)
+		protectedGroup.DELETE("/auth/sessions/:id", authHandler.RevokeSession)
 		protectedGroup.POST("/home", handler.NewHomeHandler().Home)
+		protectedGroup.GET("/me", userHandler.Me)
+		protectedGroup.PATCH("/me", userHandler.UpdateMe)
+		protectedGroup.GET("/users/:id",
+			authMiddleware.RequireRole("admin"),
+			userHandler.GetByID)
 		protectedGroup.DELETE("/users/:id",
 			authMiddleware.RequireSelfOrAdmin(),
 			userHandler.Delete)
+		protectedGroup.GET("/admin/users",
+			authMiddleware.RequireRole("admin"),
+			accountHandler.List)
+		protectedGroup.GET("/admin/users/:id",
+			authMiddleware.RequireRole("admin"),
+			accountHandler.Get)
+		protectedGroup.PUT("/admin/users/:id/role",
+			authMiddleware.RequireRole("admin"),
+			accountHandler.UpdateRole)
+		protectedGroup.POST("/admin/users/:id/suspend",
+			authMiddleware.RequireRole("admin"),
+			accountHandler.Suspend)
+		protectedGroup.POST("/admin/users/:id/unsuspend",
+			authMiddleware.RequireRole("admin"),
+			accountHandler.Unsuspend)
+
+		addressGroup := protectedGroup.Group("/users/:id/addresses", authMiddleware.RequireSelfOrAdmin())
+		{
+			addressGroup.GET("", addressHandler.List)
+			addressGroup.POST("", addressHandler.Create)
+			addressGroup.GET("/:addressId", addressHandler.GetByID)
+			addressGroup.PUT("/:addressId", addressHandler.Update)
+			addressGroup.DELETE("/:addressId", addressHandler.Delete)
+			addressGroup.PUT("/:addressId/primary", addressHandler.SetPrimary)
+		}
+
+		protectedGroup.POST("/orders", orderHandler.Create)
+		protectedGroup.GET("/orders", orderHandler.List)
+		protectedGroup.GET("/orders/:id", orderHandler.GetByID)
+		protectedGroup.POST("/orders/:id/cancel", orderHandler.Cancel)
+		protectedGroup.GET("/orders/:id/history", orderHandler.History)
+		protectedGroup.GET("/orders/:id/events", orderEventHandler.Stream)
+		protectedGroup.POST("/orders/:id/payments", paymentHandler.Create)
+		protectedGroup.GET("/orders/:id/refunds", paymentHandler.ListRefunds)
+		protectedGroup.POST("/orders/:id/photos", photoHandler.Upload)
+		protectedGroup.GET("/orders/:id/photos", photoHandler.List)
+		protectedGroup.GET("/orders/:id/photos/:photoId", photoHandler.Get)
+		protectedGroup.POST("/orders/:id/review", reviewHandler.Create)
+		protectedGroup.POST("/orders/:id/complaints", complaintHandler.Create)
+		protectedGroup.POST("/orders/:id/refunds",
+			authMiddleware.RequireRole("admin"),
+			paymentHandler.Refund)
+		protectedGroup.PATCH("/orders/:id/status",
+			authMiddleware.RequireRole("admin"),
+			orderHandler.UpdateStatus)
+		protectedGroup.GET("/admin/orders/at-risk",
+			authMiddleware.RequireRole("admin"),
+			orderHandler.AtRisk)
+
+		protectedGroup.GET("/referrals", referralHandler.Summary)
+
+		protectedGroup.GET("/wallet", walletHandler.Balance)
+		protectedGroup.GET("/wallet/statement", walletHandler.Statement)
+		protectedGroup.POST("/admin/users/:id/wallet/adjustments",
+			authMiddleware.RequireRole("admin"),
+			walletHandler.Adjust)
+
+		protectedGroup.GET("/notifications", notificationHandler.List)
+		protectedGroup.GET("/notifications/unread-count", notificationHandler.UnreadCount)
+		protectedGroup.POST("/notifications/read-all", notificationHandler.MarkAllRead)
+		protectedGroup.POST("/notifications/:id/read", notificationHandler.MarkRead)
+
+		protectedGroup.GET("/complaints", complaintHandler.List)
+		protectedGroup.GET("/complaints/:id", complaintHandler.GetByID)
+		protectedGroup.GET("/admin/complaints",
+			authMiddleware.RequireRole("admin"),
+			complaintHandler.ListAll)
+		protectedGroup.POST("/admin/complaints/:id/notes",
+			authMiddleware.RequireRole("admin"),
+			complaintHandler.AddNote)
+		protectedGroup.POST("/admin/complaints/:id/resolve",
+			authMiddleware.RequireRole("admin"),
+			complaintHandler.Resolve)
+		protectedGroup.POST("/admin/complaints/:id/close",
+			authMiddleware.RequireRole("admin"),
+			complaintHandler.Close)
+
+		protectedGroup.POST("/promos/validate", promoHandler.Validate)
+		protectedGroup.GET("/promos",
+			authMiddleware.RequireRole("admin"),
+			promoHandler.List)
+		protectedGroup.GET("/promos/:code",
+			authMiddleware.RequireRole("admin"),
+			promoHandler.GetByCode)
+		protectedGroup.POST("/promos",
+			authMiddleware.RequireRole("admin"),
+			promoHandler.Create)
+		protectedGroup.PUT("/promos/:code",
+			authMiddleware.RequireRole("admin"),
+			promoHandler.Update)
+		protectedGroup.DELETE("/promos/:code",
+			authMiddleware.RequireRole("admin"),
+			promoHandler.Delete)
+
+		protectedGroup.POST("/services",
+			authMiddleware.RequireRole("admin"),
+			serviceHandler.Create)
+		protectedGroup.PUT("/services/:id",
+			authMiddleware.RequireRole("admin"),
+			serviceHandler.Update)
+		protectedGroup.DELETE("/services/:id",
+			authMiddleware.RequireRole("admin"),
+			serviceHandler.Archive)
+
+		protectedGroup.GET("/admin/blog/posts",
+			authMiddleware.RequireRole("admin"),
+			blogHandler.List)
+		protectedGroup.POST("/admin/blog/posts",
+			authMiddleware.RequireRole("admin"),
+			blogHandler.Create)
+		protectedGroup.GET("/admin/blog/posts/:id",
+			authMiddleware.RequireRole("admin"),
+			blogHandler.GetByID)
+		protectedGroup.PUT("/admin/blog/posts/:id",
+			authMiddleware.RequireRole("admin"),
+			blogHandler.Update)
+		protectedGroup.POST("/admin/blog/posts/:id/publish",
+			authMiddleware.RequireRole("admin"),
+			blogHandler.Publish)
+		protectedGroup.POST("/admin/blog/posts/:id/unpublish",
+			authMiddleware.RequireRole("admin"),
+			blogHandler.Unpublish)
 	}
 }
 
 func (s *Server) Run() {
-	s.initRoute()
-
 	addr := fmt.Sprintf("%s:%s", s.host, s.port)
 	s.server = &http.Server{
 		Addr:    addr,
 		Handler: s.engine,
 	}
+	s.initRoute()
+
+	// Deliver queued notifications and relay order events in the background
+	// until shutdown
+	bgCtx, stopBackground := context.WithCancel(context.Background())
+	var background sync.WaitGroup
+	for _, run := range []func(context.Context){s.notifyUC.Run, s.eventUC.Run} {
+		background.Add(1)
+		go func() {
+			defer background.Done()
+			run(bgCtx)
+		}()
+	}
 
 	quit := make(chan os.Signal, 1)
 	signal.Notify(quit, os.Interrupt, syscall.SIGTERM)
@@ -127,16 +399,20 @@ func (s *Server) Run() {
 	}()
 
 	<-quit
-	s.dbPool.Close()
-	s.redisCli.Close()
 	fmt.Println("\nShutting down server...")
 
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 	defer cancel()
 
+	// requests still running need the event fan-out, database and Redis,
+	// so those are only stopped once the server is done
 	if err := s.server.Shutdown(ctx); err != nil {
 		fmt.Printf("Server forced to shutdown: %v", err)
 	}
+	stopBackground()
+	background.Wait()
+	s.dbPool.Close()
+	s.redisCli.Close()
 
 	fmt.Println("Server gracefully stopped 󱠡 ")
 }
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"os"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/golang-jwt/jwt/v5"
@@ -25,12 +26,25 @@ type APIConfig struct {
 	APIPort  string
 	Domain   string
 	IsSecure bool
+	// TrustedProxies are the IPs and CIDRs of the reverse proxies whose
+	// X-Forwarded-For is believed. Without any the client IP is the
+	// address of the connection.
+	TrustedProxies []string
 }
 
 type TokenConfig struct {
-	AppName              string
-	JwtSecretKey         []byte
-	JwtSigningMethod     *jwt.SigningMethodHMAC
+	// AppName is the issuer of the tokens and Audience the API they are
+	// meant for.
+	AppName          string
+	Audience         string
+	JwtSecretKey     []byte
+	JwtSigningMethod jwt.SigningMethod
+	// SigningKey, when set, signs new tokens in place of JwtSecretKey.
+	// PreviousKeys are rotated out keys, still accepted for verification
+	// until PreviousKeysUntil.
+	SigningKey           *SigningKey
+	PreviousKeys         []SigningKey
+	PreviousKeysUntil    time.Time
 	AccessTokenLifeTime  time.Duration
 	RefreshTokenLifeTime time.Duration
 }
@@ -41,11 +55,67 @@ type RedisConfig struct {
 	DB       int
 }
 
+type OrderConfig struct {
+	ExpressFee float64
+	// ExpressDurationPercent is the share of the regular turnaround promised
+	// to express orders.
+	ExpressDurationPercent int
+	// DefaultDurationHours is used for services without
+	// estimated_duration_hours.
+	DefaultDurationHours int
+}
+
+type ReferralConfig struct {
+	// Cashback is credited to the referrer once the referred user's first
+	// order is completed. The amount is fixed when the referral is made.
+	Cashback float64
+}
+
+type StorageConfig struct {
+	// Dir is the root of the local blob store.
+	Dir          string
+	MaxPhotoSize int64
+}
+
+type NotificationConfig struct {
+	// SMTPHost is the mail server used for email notifications; email is
+	// disabled when it is empty.
+	SMTPHost     string
+	SMTPPort     string
+	SMTPUsername string
+	SMTPPassword string
+	SMTPFrom     string
+	// WhatsAppURL is the provider endpoint WhatsApp messages are posted to;
+	// WhatsApp is disabled when it is empty.
+	WhatsAppURL   string
+	WhatsAppToken string
+	// PollInterval is how often the dispatcher looks for due notifications.
+	PollInterval time.Duration
+	// RetryBackoff is the wait after the first failed attempt. It doubles
+	// with every further attempt, up to MaxAttempts attempts in total.
+	RetryBackoff time.Duration
+	MaxAttempts  int
+}
+
+type PaymentConfig struct {
+	// FakeMethods lists payment methods served by the local fake gateway,
+	// for development until the real provider integration exists.
+	FakeMethods []string
+	// WebhookSecrets holds the HMAC secret per payment method, read from
+	// PAYMENT_WEBHOOK_SECRET_<METHOD>. Providers without one are rejected.
+	WebhookSecrets map[string][]byte
+}
+
 type Config struct {
 	DBConfig
 	APIConfig
 	TokenConfig
 	RedisConfig
+	OrderConfig
+	PaymentConfig
+	StorageConfig
+	ReferralConfig
+	NotificationConfig
 }
 
 func NewConfig() (*Config, error) {
@@ -71,21 +141,31 @@ func (c *Config) readConfig() error {
 	isSecure := os.Getenv("ENV") == "production"
 
 	c.APIConfig = APIConfig{
-		APIHost:  os.Getenv("API_HOST"),
-		APIPort:  os.Getenv("API_PORT"),
-		Domain:   os.Getenv("DOMAIN"),
-		IsSecure: isSecure,
+		APIHost:        os.Getenv("API_HOST"),
+		APIPort:        os.Getenv("API_PORT"),
+		Domain:         os.Getenv("DOMAIN"),
+		IsSecure:       isSecure,
+		TrustedProxies: splitList(os.Getenv("TRUSTED_PROXIES")),
 	}
 
-	accessExp, _ := strconv.Atoi(os.Getenv("ACCESS_TOKEN_EXP"))
-	refreshExp, _ := strconv.Atoi(os.Getenv("REFRESH_TOKEN_EXP"))
-
+	appName := os.Getenv("APP_NAME")
+	if appName == "" {
+		appName = "wash-shoe"
+	}
+	audience := os.Getenv("JWT_AUDIENCE")
+	if audience == "" {
+		audience = appName
+	}
 	c.TokenConfig = TokenConfig{
-		AppName:              os.Getenv("APP_NAME"),
+		AppName:              appName,
+		Audience:             audience,
 		JwtSecretKey:         []byte(os.Getenv("JWT_SECRET")),
 		JwtSigningMethod:     jwt.SigningMethodHS256,
-		AccessTokenLifeTime:  time.Duration(accessExp) * time.Minute,
-		RefreshTokenLifeTime: time.Duration(refreshExp) * time.Minute,
+		AccessTokenLifeTime:  time.Duration(envInt("ACCESS_TOKEN_EXP", 15)) * time.Minute,
+		RefreshTokenLifeTime: time.Duration(envInt("REFRESH_TOKEN_EXP", 10080)) * time.Minute,
+	}
+	if err := c.TokenConfig.readSigningKeys(); err != nil {
+		return err
 	}
 
 	redisDB, _ := strconv.Atoi(os.Getenv("REDIS_DB"))
@@ -95,12 +175,65 @@ func (c *Config) readConfig() error {
 		DB:       redisDB,
 	}
 
+	expressFee, err := strconv.ParseFloat(os.Getenv("EXPRESS_FEE"), 64)
+	if err != nil {
+		expressFee = 20000
+	}
+	c.OrderConfig = OrderConfig{
+		ExpressFee:             expressFee,
+		ExpressDurationPercent: envInt("EXPRESS_DURATION_PERCENT", 50),
+		DefaultDurationHours:   envInt("DEFAULT_DURATION_HOURS", 72),
+	}
+
+	c.PaymentConfig = PaymentConfig{
+		FakeMethods:    splitList(os.Getenv("PAYMENT_FAKE_METHODS")),
+		WebhookSecrets: make(map[string][]byte),
+	}
+	for _, method := range []string{"DANA", "OVO", "Bank_Transfer", "QRIS"} {
+		if secret := os.Getenv("PAYMENT_WEBHOOK_SECRET_" + strings.ToUpper(method)); secret != "" {
+			c.PaymentConfig.WebhookSecrets[method] = []byte(secret)
+		}
+	}
+
+	cashback, err := strconv.ParseFloat(os.Getenv("REFERRAL_CASHBACK"), 64)
+	if err != nil || cashback < 0 {
+		cashback = 10000
+	}
+	c.ReferralConfig = ReferralConfig{Cashback: cashback}
+
+	smtpFrom := os.Getenv("SMTP_FROM")
+	if smtpFrom == "" {
+		smtpFrom = "no-reply@localhost"
+	}
+	c.NotificationConfig = NotificationConfig{
+		SMTPHost:      os.Getenv("SMTP_HOST"),
+		SMTPPort:      strconv.Itoa(envInt("SMTP_PORT", 25)),
+		SMTPUsername:  os.Getenv("SMTP_USERNAME"),
+		SMTPPassword:  os.Getenv("SMTP_PASSWORD"),
+		SMTPFrom:      smtpFrom,
+		WhatsAppURL:   os.Getenv("WHATSAPP_API_URL"),
+		WhatsAppToken: os.Getenv("WHATSAPP_API_TOKEN"),
+		PollInterval:  time.Duration(envInt("NOTIFICATION_POLL_SECONDS", 5)) * time.Second,
+		RetryBackoff:  time.Duration(envInt("NOTIFICATION_RETRY_SECONDS", 30)) * time.Second,
+		MaxAttempts:   envInt("NOTIFICATION_MAX_ATTEMPTS", 5),
+	}
+
+	storageDir := os.Getenv("STORAGE_DIR")
+	if storageDir == "" {
+		storageDir = "uploads"
+	}
+	c.StorageConfig = StorageConfig{
+		Dir:          storageDir,
+		MaxPhotoSize: int64(envInt("PHOTO_MAX_SIZE_MB", 5)) << 20,
+	}
+
 	if c.DBConfig.Host == "" ||
 		c.DBConfig.Port == "" ||
 		c.DBConfig.Username == "" ||
 		c.DBConfig.Password == "" ||
 		c.APIConfig.APIHost == "" ||
-		c.APIConfig.APIPort == "" {
+		c.APIConfig.APIPort == "" ||
+		(len(c.TokenConfig.JwtSecretKey) == 0 && c.TokenConfig.SigningKey == nil) {
 		return fmt.Errorf("there's an empty payload")
 	}
 
@@ -114,3 +247,23 @@ func (c *Config) GetDomain() string {
 func (c *Config) IsSecure() bool {
 	return c.APIConfig.IsSecure
 }
+
+// envInt reads a positive integer env value, falling back to def.
+func envInt(name string, def int) int {
+	v, err := strconv.Atoi(os.Getenv(name))
+	if err != nil || v <= 0 {
+		return def
+	}
+	return v
+}
+
+// splitList parses a comma separated env value, skipping empty items.
+func splitList(v string) []string {
+	var items []string
+	for _, item := range strings.Split(v, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
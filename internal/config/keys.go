@@ -0,0 +1,105 @@
+package config
+
+import (
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// minRSAKeyBits is the smallest RSA modulus accepted for RS256.
+const minRSAKeyBits = 2048
+
+// SigningKey is an asymmetric JWT key, published in the JWKS under ID.
+// Private is nil for previous keys loaded from a public key file.
+type SigningKey struct {
+	ID      string
+	Method  jwt.SigningMethod
+	Private crypto.PrivateKey
+	Public  crypto.PublicKey
+}
+
+// readSigningKeys loads the key new tokens are signed with from
+// JWT_SIGNING_KEY_FILE and the previous keys, still accepted until
+// JWT_PREVIOUS_KEYS_UNTIL, from JWT_PREVIOUS_KEYS ("kid=path,kid=path").
+// Without JWT_SIGNING_KEY_FILE tokens are signed with JWT_SECRET instead.
+func (c *TokenConfig) readSigningKeys() error {
+	path := os.Getenv("JWT_SIGNING_KEY_FILE")
+	if path == "" {
+		return nil
+	}
+	kid := os.Getenv("JWT_SIGNING_KEY_ID")
+	if kid == "" {
+		return fmt.Errorf("JWT_SIGNING_KEY_ID is required with JWT_SIGNING_KEY_FILE")
+	}
+	key, err := loadSigningKey(kid, path, false)
+	if err != nil {
+		return err
+	}
+	c.SigningKey = &key
+
+	previous := splitList(os.Getenv("JWT_PREVIOUS_KEYS"))
+	if len(previous) == 0 {
+		return nil
+	}
+	until, err := time.Parse(time.RFC3339, os.Getenv("JWT_PREVIOUS_KEYS_UNTIL"))
+	if err != nil {
+		return fmt.Errorf("JWT_PREVIOUS_KEYS_UNTIL must be an RFC 3339 time: %w", err)
+	}
+	c.PreviousKeysUntil = until
+	seen := map[string]bool{kid: true}
+	for _, item := range previous {
+		kid, path, ok := strings.Cut(item, "=")
+		if !ok || kid == "" || path == "" {
+			return fmt.Errorf("JWT_PREVIOUS_KEYS: %q is not kid=path", item)
+		}
+		if seen[kid] {
+			return fmt.Errorf("JWT_PREVIOUS_KEYS: duplicate kid %q", kid)
+		}
+		seen[kid] = true
+		key, err := loadSigningKey(kid, path, true)
+		if err != nil {
+			return err
+		}
+		c.PreviousKeys = append(c.PreviousKeys, key)
+	}
+	return nil
+}
+
+// loadSigningKey reads an RSA (RS256) or Ed25519 (EdDSA) key from a PEM
+// file. Public keys are only accepted when allowPublic is set.
+func loadSigningKey(kid, path string, allowPublic bool) (SigningKey, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return SigningKey{}, fmt.Errorf("read JWT key %s: %w", kid, err)
+	}
+
+	key := SigningKey{ID: kid}
+	if rsaKey, err := jwt.ParseRSAPrivateKeyFromPEM(data); err == nil {
+		key.Method, key.Private, key.Public = jwt.SigningMethodRS256, rsaKey, &rsaKey.PublicKey
+	} else if edKey, err := jwt.ParseEdPrivateKeyFromPEM(data); err == nil {
+		private, ok := edKey.(ed25519.PrivateKey)
+		if !ok {
+			return SigningKey{}, fmt.Errorf("JWT key %s: unsupported private key", kid)
+		}
+		key.Method, key.Private, key.Public = jwt.SigningMethodEdDSA, private, private.Public()
+	} else if !allowPublic {
+		return SigningKey{}, fmt.Errorf("JWT key %s: want an RSA or Ed25519 private key in PEM form", kid)
+	} else if rsaPub, err := jwt.ParseRSAPublicKeyFromPEM(data); err == nil {
+		key.Method, key.Public = jwt.SigningMethodRS256, rsaPub
+	} else if edPub, err := jwt.ParseEdPublicKeyFromPEM(data); err == nil {
+		key.Method, key.Public = jwt.SigningMethodEdDSA, edPub
+	} else {
+		return SigningKey{}, fmt.Errorf("JWT key %s: want an RSA or Ed25519 key in PEM form", kid)
+	}
+
+	if pub, ok := key.Public.(*rsa.PublicKey); ok && pub.N.BitLen() < minRSAKeyBits {
+		return SigningKey{}, fmt.Errorf("JWT key %s: RSA keys must have at least %d bits", kid, minRSAKeyBits)
+	}
+	return key, nil
+}
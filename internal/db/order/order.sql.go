@@ -1,123 +0,0 @@
-// Code generated by sqlc. DO NOT EDIT.
-// versions:
-//   sqlc v1.29.0
-// source: order.sql
-
-package order
-
-import (
-	"context"
-
-	"github.com/jackc/pgx/v5/pgtype"
-)
-
-const createOrder = `-- name: CreateOrder :one
-INSERT INTO "orders" (user_id, service_type, status)
-VALUES ($1, $2, $3)
-RETURNING id, user_id, service_type, status, created_at
-`
-
-type CreateOrderParams struct {
-	UserID      pgtype.UUID `json:"user_id"`
-	ServiceType pgtype.Text `json:"service_type"`
-	Status      pgtype.Text `json:"status"`
-}
-
-func (q *Queries) CreateOrder(ctx context.Context, arg CreateOrderParams) (Order, error) {
-	row := q.db.QueryRow(ctx, createOrder, arg.UserID, arg.ServiceType, arg.Status)
-	var i Order
-	err := row.Scan(
-		&i.ID,
-		&i.UserID,
-		&i.ServiceType,
-		&i.Status,
-		&i.CreatedAt,
-	)
-	return i, err
-}
-
-const deleteOrder = `-- name: DeleteOrder :exec
-DELETE FROM "orders" WHERE id = $1
-`
-
-func (q *Queries) DeleteOrder(ctx context.Context, id int32) error {
-	_, err := q.db.Exec(ctx, deleteOrder, id)
-	return err
-}
-
-const getOrderByID = `-- name: GetOrderByID :one
-SELECT id, user_id, service_type, status, created_at
-FROM "orders"
-WHERE id = $1
-`
-
-func (q *Queries) GetOrderByID(ctx context.Context, id int32) (Order, error) {
-	row := q.db.QueryRow(ctx, getOrderByID, id)
-	var i Order
-	err := row.Scan(
-		&i.ID,
-		&i.UserID,
-		&i.ServiceType,
-		&i.Status,
-		&i.CreatedAt,
-	)
-	return i, err
-}
-
-const listOrdersByUser = `-- name: ListOrdersByUser :many
-SELECT id, user_id, service_type, status, created_at
-FROM "orders"
-WHERE user_id = $1
-ORDER BY created_at DESC
-`
-
-func (q *Queries) ListOrdersByUser(ctx context.Context, userID pgtype.UUID) ([]Order, error) {
-	rows, err := q.db.Query(ctx, listOrdersByUser, userID)
-	if err != nil {
-		return nil, err
-	}
-	defer rows.Close()
-	var items []Order
-	for rows.Next() {
-		var i Order
-		if err := rows.Scan(
-			&i.ID,
-			&i.UserID,
-			&i.ServiceType,
-			&i.Status,
-			&i.CreatedAt,
-		); err != nil {
-			return nil, err
-		}
-		items = append(items, i)
-	}
-	if err := rows.Err(); err != nil {
-		return nil, err
-	}
-	return items, nil
-}
-
-const updateOrderStatus = `-- name: UpdateOrderStatus :one
-UPDATE "orders"
-SET status = $2
-WHERE id = $1
-RETURNING id, user_id, service_type, status, created_at
-`
-
-type UpdateOrderStatusParams struct {
-	ID     int32       `json:"id"`
-	Status pgtype.Text `json:"status"`
-}
-
-func (q *Queries) UpdateOrderStatus(ctx context.Context, arg UpdateOrderStatusParams) (Order, error) {
-	row := q.db.QueryRow(ctx, updateOrderStatus, arg.ID, arg.Status)
-	var i Order
-	err := row.Scan(
-		&i.ID,
-		&i.UserID,
-		&i.ServiceType,
-		&i.Status,
-		&i.CreatedAt,
-	)
-	return i, err
-}
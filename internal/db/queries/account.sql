@@ -0,0 +1,56 @@
+-- Accounts
+-- Search matches email or full name; every filter is skipped when NULL.
+-- name: ListAccounts :many
+SELECT
+  pu.id, au.email, pu.full_name, pu.phone_number, pu.provider, pu.role,
+  pu.created_at, au.last_sign_in_at, au.suspended_at
+FROM public.users pu
+JOIN auth.users au ON au.id = pu.id
+WHERE (sqlc.narg(search)::text IS NULL
+       OR au.email ILIKE '%' || sqlc.narg(search) || '%'
+       OR pu.full_name ILIKE '%' || sqlc.narg(search) || '%')
+  AND (sqlc.narg(role)::text IS NULL OR pu.role = sqlc.narg(role))
+  AND (sqlc.narg(provider)::text IS NULL OR pu.provider = sqlc.narg(provider))
+  AND (sqlc.narg(suspended)::boolean IS NULL OR (au.suspended_at IS NOT NULL) = sqlc.narg(suspended))
+ORDER BY pu.created_at DESC, pu.id
+LIMIT sqlc.arg(row_limit) OFFSET sqlc.arg(row_offset);
+
+-- name: CountAccounts :one
+SELECT COUNT(*)
+FROM public.users pu
+JOIN auth.users au ON au.id = pu.id
+WHERE (sqlc.narg(search)::text IS NULL
+       OR au.email ILIKE '%' || sqlc.narg(search) || '%'
+       OR pu.full_name ILIKE '%' || sqlc.narg(search) || '%')
+  AND (sqlc.narg(role)::text IS NULL OR pu.role = sqlc.narg(role))
+  AND (sqlc.narg(provider)::text IS NULL OR pu.provider = sqlc.narg(provider))
+  AND (sqlc.narg(suspended)::boolean IS NULL OR (au.suspended_at IS NOT NULL) = sqlc.narg(suspended));
+
+-- name: GetAccount :one
+SELECT
+  pu.id, au.email, pu.full_name, pu.phone_number, pu.provider, pu.role,
+  pu.created_at, au.last_sign_in_at, au.suspended_at
+FROM public.users pu
+JOIN auth.users au ON au.id = pu.id
+WHERE pu.id = $1;
+
+-- Suspension
+-- name: GetAccountSuspendedAt :one
+SELECT suspended_at FROM auth.users WHERE id = $1;
+
+-- name: SuspendAccount :execrows
+UPDATE auth.users SET suspended_at = NOW(), updated_at = NOW()
+WHERE id = $1 AND suspended_at IS NULL;
+
+-- name: UnsuspendAccount :execrows
+UPDATE auth.users SET suspended_at = NULL, updated_at = NOW()
+WHERE id = $1 AND suspended_at IS NOT NULL;
+
+-- Audit
+-- Entries the user made themselves as well as those about them.
+-- name: ListAccountAuditLogs :many
+SELECT * FROM auth.audit_log
+WHERE actor_id = sqlc.arg(user_id)::uuid
+   OR details->>'user_id' = sqlc.arg(user_id)::uuid::text
+ORDER BY created_at DESC, id DESC
+LIMIT sqlc.arg(row_limit);
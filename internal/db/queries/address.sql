@@ -0,0 +1,47 @@
+-- Addresses
+-- name: CreateAddress :one
+INSERT INTO public.addresses (
+  user_id, street, city, province, postal_code, notes, is_primary
+) VALUES ($1, $2, $3, $4, $5, $6, false)
+RETURNING *;
+
+-- name: ListAddressesByUser :many
+SELECT * FROM public.addresses
+WHERE user_id = $1
+ORDER BY is_primary DESC, created_at;
+
+-- name: GetAddressByID :one
+SELECT * FROM public.addresses
+WHERE id = $1 AND user_id = $2
+LIMIT 1;
+
+-- name: UpdateAddress :one
+UPDATE public.addresses
+SET street      = $3,
+    city        = $4,
+    province    = $5,
+    postal_code = $6,
+    notes       = $7
+WHERE id = $1 AND user_id = $2
+RETURNING *;
+
+-- Flip the flag on all of the user's addresses in one statement so there is
+-- never more than one primary address.
+-- name: SetPrimaryAddress :execrows
+UPDATE public.addresses
+SET is_primary = (id = sqlc.arg(id))
+WHERE user_id = sqlc.arg(user_id)
+  AND EXISTS (
+    SELECT 1 FROM public.addresses a
+    WHERE a.id = sqlc.arg(id) AND a.user_id = sqlc.arg(user_id)
+  );
+
+-- Refuse to delete while an open order still ships to this address.
+-- name: DeleteUnusedAddress :execrows
+DELETE FROM public.addresses a
+WHERE a.id = $1 AND a.user_id = $2
+  AND NOT EXISTS (
+    SELECT 1 FROM public.orders o
+    WHERE o.address_id = a.id
+      AND o.status NOT IN ('completed', 'delivered', 'cancelled')
+  );
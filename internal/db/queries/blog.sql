@@ -0,0 +1,59 @@
+-- Authoring
+-- name: CreateBlogPost :one
+INSERT INTO public.blog_posts (title, slug, excerpt, content, author_id)
+VALUES ($1, $2, $3, $4, $5)
+RETURNING *;
+
+-- name: GetBlogPostByID :one
+SELECT * FROM public.blog_posts WHERE id = $1;
+
+-- name: UpdateBlogPost :one
+UPDATE public.blog_posts
+SET title = $2, slug = $3, excerpt = $4, content = $5, updated_at = NOW()
+WHERE id = $1
+RETURNING *;
+
+-- A published_at in the future schedules the post.
+-- name: PublishBlogPost :one
+UPDATE public.blog_posts
+SET is_published = true, published_at = $2, updated_at = NOW()
+WHERE id = $1
+RETURNING *;
+
+-- name: UnpublishBlogPost :one
+UPDATE public.blog_posts
+SET is_published = false, published_at = NULL, updated_at = NOW()
+WHERE id = $1
+RETURNING *;
+
+-- Every post, optionally only drafts, scheduled or published ones.
+-- name: ListBlogPosts :many
+SELECT * FROM public.blog_posts
+WHERE sqlc.narg(status)::text IS NULL
+   OR (sqlc.narg(status)::text = 'draft' AND is_published IS NOT TRUE)
+   OR (sqlc.narg(status)::text = 'scheduled' AND is_published AND published_at > NOW())
+   OR (sqlc.narg(status)::text = 'published' AND is_published AND published_at <= NOW())
+ORDER BY COALESCE(published_at, updated_at) DESC, id DESC
+LIMIT sqlc.arg(row_limit) OFFSET sqlc.arg(row_offset);
+
+-- name: CountBlogPosts :one
+SELECT COUNT(*) FROM public.blog_posts
+WHERE sqlc.narg(status)::text IS NULL
+   OR (sqlc.narg(status)::text = 'draft' AND is_published IS NOT TRUE)
+   OR (sqlc.narg(status)::text = 'scheduled' AND is_published AND published_at > NOW())
+   OR (sqlc.narg(status)::text = 'published' AND is_published AND published_at <= NOW());
+
+-- Public
+-- name: ListPublishedBlogPosts :many
+SELECT * FROM public.blog_posts
+WHERE is_published AND published_at <= NOW()
+ORDER BY published_at DESC, id DESC
+LIMIT sqlc.arg(row_limit) OFFSET sqlc.arg(row_offset);
+
+-- name: CountPublishedBlogPosts :one
+SELECT COUNT(*) FROM public.blog_posts
+WHERE is_published AND published_at <= NOW();
+
+-- name: GetPublishedBlogPostBySlug :one
+SELECT * FROM public.blog_posts
+WHERE slug = $1 AND is_published AND published_at <= NOW();
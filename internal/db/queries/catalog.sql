@@ -0,0 +1,34 @@
+-- Service Types
+-- name: ListServiceTypes :many
+SELECT * FROM public.service_types
+WHERE archived_at IS NULL
+  AND (NOT sqlc.arg(eco_friendly_only)::boolean OR is_eco_friendly)
+ORDER BY
+  CASE WHEN sqlc.arg(sort)::text = 'price_asc' THEN base_price END ASC,
+  CASE WHEN sqlc.arg(sort)::text = 'price_desc' THEN base_price END DESC,
+  id;
+
+-- name: GetServiceTypeByID :one
+SELECT * FROM public.service_types WHERE id = $1 LIMIT 1;
+
+-- name: CreateServiceType :one
+INSERT INTO public.service_types (
+  name, description, base_price, estimated_duration_hours, is_eco_friendly
+) VALUES ($1, $2, $3, $4, $5)
+RETURNING *;
+
+-- name: UpdateServiceType :one
+UPDATE public.service_types
+SET name                     = $2,
+    description              = $3,
+    base_price               = $4,
+    estimated_duration_hours = $5,
+    is_eco_friendly          = $6
+WHERE id = $1
+RETURNING *;
+
+-- Archive instead of delete: order_services keep pointing at the row
+-- name: ArchiveServiceType :execrows
+UPDATE public.service_types
+SET archived_at = NOW()
+WHERE id = $1 AND archived_at IS NULL;
@@ -0,0 +1,53 @@
+-- Complaints
+-- name: CreateComplaint :one
+INSERT INTO public.complaints (user_id, order_id, description)
+VALUES ($1, $2, $3)
+RETURNING *;
+
+-- name: GetComplaintByID :one
+SELECT * FROM public.complaints WHERE id = $1 LIMIT 1;
+
+-- name: GetComplaintForUpdate :one
+SELECT * FROM public.complaints WHERE id = $1 FOR UPDATE;
+
+-- name: ListComplaintsByUser :many
+SELECT * FROM public.complaints
+WHERE user_id = $1
+ORDER BY created_at DESC;
+
+-- Oldest first, so the admin queue shows what has been waiting longest.
+-- name: ListComplaints :many
+SELECT * FROM public.complaints
+WHERE (sqlc.narg(status)::text IS NULL OR status = sqlc.narg(status)::text)
+  AND (sqlc.narg(created_before)::timestamptz IS NULL OR created_at <= sqlc.narg(created_before)::timestamptz)
+  AND (sqlc.narg(created_after)::timestamptz IS NULL OR created_at >= sqlc.narg(created_after)::timestamptz)
+ORDER BY created_at, id;
+
+-- resolved_at is only set by resolving; closing keeps whatever it was.
+-- name: UpdateComplaintStatus :one
+UPDATE public.complaints
+SET status = sqlc.arg(status)::text,
+    resolved_at = CASE WHEN sqlc.arg(status)::text = 'resolved' THEN COALESCE(resolved_at, NOW()) ELSE resolved_at END
+WHERE id = sqlc.arg(id)
+RETURNING *;
+
+-- Complaint Notes
+-- name: CreateComplaintNote :one
+INSERT INTO public.complaint_notes (complaint_id, author_id, note)
+VALUES ($1, $2, $3)
+RETURNING *;
+
+-- name: ListComplaintNotes :many
+SELECT * FROM public.complaint_notes
+WHERE complaint_id = $1
+ORDER BY created_at, id;
+
+-- Audit
+-- name: CreateAuditLog :exec
+INSERT INTO auth.audit_log (actor_id, action, details)
+VALUES ($1, $2, $3);
+
+-- Notifications
+-- name: CreateNotification :exec
+INSERT INTO public.notifications (user_id, order_id, message, channel)
+VALUES ($1, $2, $3, $4);
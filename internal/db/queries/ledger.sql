@@ -0,0 +1,54 @@
+-- Accounts
+-- name: GetLedgerAccountIDByCode :one
+SELECT id FROM public.ledger_accounts WHERE code = $1;
+
+-- Creates the user's wallet account on first use. The upsert also locks the
+-- row until the transaction ends, which serializes postings to one wallet.
+-- name: LockWalletAccount :one
+INSERT INTO public.ledger_accounts (user_id)
+VALUES ($1)
+ON CONFLICT (user_id) DO UPDATE SET user_id = EXCLUDED.user_id
+RETURNING id;
+
+-- name: GetLedgerAccountBalance :one
+SELECT COALESCE(SUM(amount), 0)::numeric AS balance
+FROM public.ledger_entries
+WHERE account_id = $1;
+
+-- name: GetWalletBalance :one
+SELECT COALESCE(SUM(e.amount), 0)::numeric AS balance
+FROM public.ledger_entries e
+JOIN public.ledger_accounts a ON a.id = e.account_id
+WHERE a.user_id = $1;
+
+-- Transactions
+-- name: CreateLedgerTransaction :one
+INSERT INTO public.ledger_transactions (kind, order_id, description, created_by)
+VALUES ($1, $2, $3, $4)
+RETURNING *;
+
+-- name: CreateLedgerEntry :one
+INSERT INTO public.ledger_entries (transaction_id, account_id, amount)
+VALUES ($1, $2, $3)
+RETURNING *;
+
+-- Statement
+-- Wallet entries newest first, each with the balance right after it.
+-- name: ListWalletEntries :many
+SELECT s.id, s.transaction_id, s.kind, s.order_id, s.description, s.amount, s.balance, s.created_at
+FROM (
+  SELECT e.id, e.transaction_id, t.kind, t.order_id, t.description, e.amount,
+         SUM(e.amount) OVER (ORDER BY e.id)::numeric AS balance,
+         e.created_at
+  FROM public.ledger_entries e
+  JOIN public.ledger_accounts a ON a.id = e.account_id
+  JOIN public.ledger_transactions t ON t.id = e.transaction_id
+  WHERE a.user_id = sqlc.arg(user_id)
+) s
+ORDER BY s.id DESC
+LIMIT sqlc.arg(row_limit) OFFSET sqlc.arg(row_offset);
+
+-- Audit
+-- name: CreateAuditLog :exec
+INSERT INTO auth.audit_log (actor_id, action, details)
+VALUES ($1, $2, $3);
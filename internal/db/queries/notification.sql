@@ -0,0 +1,78 @@
+-- Dispatch
+-- Claims up to batch_size due notifications for one delivery attempt.
+-- Claimed rows are pushed lease_seconds ahead, so rows of a dispatcher that
+-- dies mid-batch are retried instead of lost.
+-- name: ClaimPendingNotifications :many
+WITH claimed AS (
+  UPDATE public.notifications
+  SET attempts = attempts + 1,
+      next_attempt_at = NOW() + make_interval(secs => sqlc.arg(lease_seconds)::int)
+  WHERE id IN (
+    SELECT id FROM public.notifications
+    WHERE status = 'pending' AND next_attempt_at <= NOW()
+    ORDER BY next_attempt_at, id
+    LIMIT sqlc.arg(batch_size)::int
+    FOR UPDATE SKIP LOCKED
+  )
+  RETURNING id, user_id, order_id, message, channel, attempts
+)
+SELECT
+  c.id, c.user_id, c.order_id, c.message, c.channel, c.attempts,
+  a.email, u.phone_number
+FROM claimed c
+LEFT JOIN auth.users a ON a.id = c.user_id
+LEFT JOIN public.users u ON u.id = c.user_id
+ORDER BY c.id;
+
+-- name: MarkNotificationSent :exec
+UPDATE public.notifications
+SET status = 'sent', sent_at = NOW(), last_error = NULL
+WHERE id = $1;
+
+-- name: MarkNotificationRetry :exec
+UPDATE public.notifications
+SET next_attempt_at = sqlc.arg(next_attempt_at), last_error = sqlc.arg(last_error)
+WHERE id = sqlc.arg(id);
+
+-- name: MarkNotificationFailed :exec
+UPDATE public.notifications
+SET status = 'failed', last_error = $2
+WHERE id = $1;
+
+-- Inbox
+-- In-app notifications show up in the inbox once they have been dispatched.
+-- name: ListInboxNotifications :many
+SELECT * FROM public.notifications
+WHERE user_id = sqlc.arg(user_id)
+  AND channel = 'in_app' AND status = 'sent'
+  AND (sqlc.narg(cursor)::int IS NULL OR id < sqlc.narg(cursor)::int)
+ORDER BY id DESC
+LIMIT sqlc.arg(row_limit);
+
+-- name: GetInboxNotification :one
+SELECT * FROM public.notifications
+WHERE id = $1 AND user_id = $2
+  AND channel = 'in_app' AND status = 'sent';
+
+-- Only unread notifications change, so no row means already read or not
+-- found.
+-- name: MarkNotificationRead :one
+UPDATE public.notifications
+SET read_at = NOW()
+WHERE id = $1 AND user_id = $2
+  AND channel = 'in_app' AND status = 'sent'
+  AND read_at IS NULL
+RETURNING *;
+
+-- name: MarkAllNotificationsRead :execrows
+UPDATE public.notifications
+SET read_at = NOW()
+WHERE user_id = $1
+  AND channel = 'in_app' AND status = 'sent'
+  AND read_at IS NULL;
+
+-- name: CountUnreadNotifications :one
+SELECT COUNT(*) FROM public.notifications
+WHERE user_id = $1
+  AND channel = 'in_app' AND status = 'sent'
+  AND read_at IS NULL;
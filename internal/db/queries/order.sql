@@ -1,24 +1,83 @@
--- -- name: CreateOrder :one
--- INSERT INTO "order" (user_id, service_type, status)
--- VALUES ($1, $2, $3)
--- RETURNING id, user_id, service_type, status, created_at;
--- 
--- -- name: GetOrderByID :one
--- SELECT id, user_id, service_type, status, created_at
--- FROM "order"
--- WHERE id = $1;
--- 
--- -- name: ListOrdersByUser :many
--- SELECT id, user_id, service_type, status, created_at
--- FROM "order"
--- WHERE user_id = $1
--- ORDER BY created_at DESC;
--- 
--- -- name: UpdateOrderStatus :one
--- UPDATE "order"
--- SET status = $2
--- WHERE id = $1
--- RETURNING id, user_id, service_type, status, created_at;
--- 
--- -- name: DeleteOrder :exec
--- DELETE FROM "order" WHERE id = $1;
+-- Orders
+-- name: CreateOrderWithServices :one
+SELECT create_order_with_services(
+  sqlc.arg(user_id)::uuid,
+  sqlc.narg(address_id)::int,
+  sqlc.arg(total_price)::numeric,
+  'pending'::order_status,
+  sqlc.arg(is_express)::boolean,
+  sqlc.arg(express_fee)::numeric,
+  sqlc.narg(promo_code)::text,
+  sqlc.arg(services)::jsonb
+)::int AS order_id;
+
+-- name: SetOrderPromisedBy :exec
+UPDATE public.orders SET promised_by = $2 WHERE id = $1;
+
+-- name: GetOrderByID :one
+SELECT * FROM public.orders WHERE id = $1 LIMIT 1;
+
+-- name: ListOrdersByUser :many
+SELECT * FROM public.orders
+WHERE user_id = $1
+ORDER BY created_at DESC;
+
+-- Order Services
+-- name: ListOrderServices :many
+SELECT
+  os.id, os.order_id, os.service_type_id, os.quantity, os.price,
+  st.name AS service_name
+FROM public.order_services os
+JOIN public.service_types st ON st.id = os.service_type_id
+WHERE os.order_id = $1
+ORDER BY os.id;
+
+-- Service Types
+-- name: ListServiceTypesByIDs :many
+SELECT * FROM public.service_types
+WHERE id = ANY(sqlc.arg(ids)::int[])
+  AND archived_at IS NULL;
+
+-- Order Status
+-- name: GetOrderStatusForUpdate :one
+SELECT status, promo_code FROM public.orders WHERE id = $1 FOR UPDATE;
+
+-- name: MarkOrderCompleted :exec
+UPDATE public.orders SET completed_at = NOW() WHERE id = $1;
+
+-- Give back the promo usage taken by a cancelled order.
+-- name: ReleasePromoUsage :exec
+UPDATE public.promos
+SET used_count = GREATEST(COALESCE(used_count, 0) - 1, 0)
+WHERE code = $1;
+
+-- name: UpdateOrderStatus :exec
+SELECT update_order_status(
+  sqlc.arg(order_id)::int,
+  sqlc.arg(status)::order_status,
+  sqlc.narg(updated_by)::uuid
+);
+
+-- name: ListOrderStatusHistory :many
+SELECT * FROM public.order_status_history
+WHERE order_id = $1
+ORDER BY updated_at, id;
+
+-- SLA
+-- name: ListOpenOrdersWithDeadline :many
+SELECT * FROM public.orders
+WHERE status IN ('pending', 'processing', 'cleaning', 'ready_for_delivery')
+  AND promised_by IS NOT NULL
+ORDER BY promised_by;
+
+-- Referrals
+-- The referral of the order's customer completes with their first completed
+-- order; later orders find it completed already and get no rows.
+-- name: CompleteReferralForOrder :one
+UPDATE public.referrals r
+SET is_completed = true, completed_at = NOW()
+FROM public.orders o
+WHERE o.id = $1
+  AND r.referred_id = o.user_id
+  AND r.is_completed IS NOT TRUE
+RETURNING r.referrer_id, r.cashback_amount;
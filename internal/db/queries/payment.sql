@@ -0,0 +1,77 @@
+-- Payments
+-- name: GetPaymentByOrderID :one
+SELECT * FROM public.payments WHERE order_id = $1 LIMIT 1;
+
+-- name: DeleteFailedPayment :exec
+DELETE FROM public.payments WHERE order_id = $1 AND status = 'failed';
+
+-- A payment is stored as pending before the gateway is called, so every
+-- charge has a row to be reconciled against.
+-- name: ReservePayment :one
+INSERT INTO public.payments (order_id, method, amount, wallet_amount, status)
+VALUES ($1, $2, $3, $4, 'pending')
+RETURNING *;
+
+-- name: SetPaymentTransactionID :exec
+UPDATE public.payments SET transaction_id = $2 WHERE id = $1;
+
+-- Orders
+-- name: GetOrderStatusForUpdate :one
+SELECT status FROM public.orders WHERE id = $1 FOR UPDATE;
+
+-- name: GetOrderUserID :one
+SELECT user_id FROM public.orders WHERE id = $1;
+
+-- Webhooks
+-- Transaction IDs are only unique per provider, so the method is part of
+-- the lookup. The row is locked afterwards, after its order.
+-- name: GetPaymentByTransactionID :one
+SELECT * FROM public.payments
+WHERE transaction_id = $1 AND method = $2
+LIMIT 1;
+
+-- name: UpdatePaymentStatus :exec
+UPDATE public.payments
+SET status = sqlc.arg(status)::payment_status,
+    paid_at = CASE WHEN sqlc.arg(status)::payment_status = 'success' THEN NOW() ELSE paid_at END
+WHERE id = sqlc.arg(id);
+
+-- name: UpdateOrderStatus :exec
+SELECT update_order_status(
+  sqlc.arg(order_id)::int,
+  sqlc.arg(status)::order_status,
+  sqlc.narg(updated_by)::uuid
+);
+
+-- Refunds
+-- name: GetPaymentByOrderIDForUpdate :one
+SELECT * FROM public.payments WHERE order_id = $1 LIMIT 1 FOR UPDATE;
+
+-- name: SumRefundsByPayment :one
+SELECT COALESCE(SUM(amount), 0)::numeric AS refunded
+FROM public.refunds
+WHERE payment_id = $1;
+
+-- name: CreateRefund :one
+INSERT INTO public.refunds (payment_id, amount, reason, refunded_by)
+VALUES ($1, $2, $3, $4)
+RETURNING *;
+
+-- name: ListRefundsByOrder :many
+SELECT r.* FROM public.refunds r
+JOIN public.payments p ON p.id = r.payment_id
+WHERE p.order_id = $1
+ORDER BY r.created_at, r.id;
+
+-- name: GetOrderForRefund :one
+SELECT status, promo_code FROM public.orders WHERE id = $1 FOR UPDATE;
+
+-- Give back the promo usage taken by a refunded order.
+-- name: ReleasePromoUsage :exec
+UPDATE public.promos
+SET used_count = GREATEST(COALESCE(used_count, 0) - 1, 0)
+WHERE code = $1;
+
+-- name: CreateAuditLog :exec
+INSERT INTO auth.audit_log (actor_id, action, details)
+VALUES ($1, $2, $3);
@@ -0,0 +1,15 @@
+-- Photo Evidences
+-- name: CreatePhotoEvidence :one
+INSERT INTO public.photo_evidences (order_id, photo_url, type, uploaded_by)
+VALUES ($1, $2, $3, $4)
+RETURNING *;
+
+-- name: ListPhotoEvidencesByOrder :many
+SELECT * FROM public.photo_evidences
+WHERE order_id = $1
+ORDER BY uploaded_at, id;
+
+-- name: GetPhotoEvidence :one
+SELECT * FROM public.photo_evidences
+WHERE id = $1 AND order_id = $2
+LIMIT 1;
@@ -0,0 +1,24 @@
+-- Promos
+-- name: ListPromos :many
+SELECT * FROM public.promos ORDER BY created_at DESC;
+
+-- name: GetPromoByCode :one
+SELECT * FROM public.promos WHERE code = $1 LIMIT 1;
+
+-- name: CreatePromo :one
+INSERT INTO public.promos (
+  code, discount_type, discount_value, max_usage, valid_until
+) VALUES ($1, $2, $3, $4, $5)
+RETURNING *;
+
+-- name: UpdatePromo :one
+UPDATE public.promos
+SET discount_type = $2,
+    discount_value = $3,
+    max_usage = $4,
+    valid_until = $5
+WHERE code = $1
+RETURNING *;
+
+-- name: DeletePromo :execrows
+DELETE FROM public.promos WHERE code = $1;
@@ -0,0 +1,23 @@
+-- Referral Codes
+-- name: GetReferralCodeByUser :one
+SELECT code FROM public.referral_codes WHERE user_id = $1;
+
+-- Returns the code the user already has when there is one.
+-- name: CreateReferralCode :one
+INSERT INTO public.referral_codes (user_id, code)
+VALUES ($1, $2)
+ON CONFLICT (user_id) DO UPDATE SET code = public.referral_codes.code
+RETURNING code;
+
+-- name: GetReferrerByCode :one
+SELECT user_id FROM public.referral_codes WHERE code = $1;
+
+-- Referrals
+-- name: CreateReferral :exec
+INSERT INTO public.referrals (referrer_id, referred_email, referred_id, cashback_amount)
+VALUES ($1, $2, $3, $4);
+
+-- name: ListReferralsByReferrer :many
+SELECT * FROM public.referrals
+WHERE referrer_id = $1
+ORDER BY created_at DESC;
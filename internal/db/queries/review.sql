@@ -0,0 +1,28 @@
+-- Reviews
+-- name: CreateReview :one
+INSERT INTO public.reviews (user_id, order_id, rating, comment)
+VALUES ($1, $2, $3, $4)
+RETURNING *;
+
+-- Ratings per service type. A review counts for every service of its order.
+-- name: ListServiceTypeRatingCounts :many
+SELECT r.rating, COUNT(*)::int AS count
+FROM public.reviews r
+WHERE EXISTS (
+  SELECT 1 FROM public.order_services os
+  WHERE os.order_id = r.order_id AND os.service_type_id = $1
+)
+GROUP BY r.rating
+ORDER BY r.rating;
+
+-- name: ListRecentServiceTypeComments :many
+SELECT r.id, r.rating, r.comment, r.created_at, u.full_name AS reviewer_name
+FROM public.reviews r
+JOIN public.users u ON u.id = r.user_id
+WHERE r.comment IS NOT NULL AND r.comment <> ''
+  AND EXISTS (
+    SELECT 1 FROM public.order_services os
+    WHERE os.order_id = r.order_id AND os.service_type_id = sqlc.arg(service_type_id)
+  )
+ORDER BY r.created_at DESC, r.id DESC
+LIMIT sqlc.arg(row_limit);
@@ -7,6 +7,12 @@ RETURNING *;
 -- name: GetAuthUserByEmail :one
 SELECT * FROM auth.users WHERE email = $1 LIMIT 1;
 
+-- name: GetAuthUserByID :one
+SELECT * FROM auth.users WHERE id = $1 LIMIT 1;
+
+-- name: UpdateAuthUserPassword :exec
+UPDATE auth.users SET password_hash = $2, updated_at = NOW() WHERE id = $1;
+
 -- name: UpdateAuthUserLastLogin :exec
 UPDATE auth.users SET last_sign_in_at = NOW() WHERE id = $1;
 
@@ -38,13 +44,59 @@ DELETE FROM auth.users WHERE id = $1;
 
 -- Refresh Tokens
 -- name: CreateRefreshToken :one
-INSERT INTO auth.refresh_tokens (user_id, token_hash, expires_at)
-VALUES ($1, $2, $3)
+INSERT INTO auth.refresh_tokens (user_id, token_hash, expires_at, family_id)
+VALUES ($1, $2, $3, $4)
 RETURNING *;
 
 -- name: RevokeRefreshToken :exec
 UPDATE auth.refresh_tokens SET revoked = true WHERE id = $1;
 
+-- name: GetRefreshTokenByHashForUpdate :one
+SELECT * FROM auth.refresh_tokens WHERE token_hash = $1 FOR UPDATE;
+
+-- name: GetRefreshTokenByIDForUpdate :one
+SELECT * FROM auth.refresh_tokens WHERE id = $1 FOR UPDATE;
+
+-- name: MarkRefreshTokenRotated :exec
+UPDATE auth.refresh_tokens SET rotated_at = NOW(), replaced_by = $2 WHERE id = $1;
+
+-- name: RevokeRefreshTokenFamily :exec
+UPDATE auth.refresh_tokens SET revoked = true WHERE family_id = $1;
+
+-- name: RevokeUserRefreshTokenFamily :execrows
+UPDATE auth.refresh_tokens SET revoked = true
+WHERE user_id = $1 AND family_id = $2 AND revoked = false;
+
+-- name: RevokeOtherRefreshTokenFamilies :many
+UPDATE auth.refresh_tokens SET revoked = true
+WHERE user_id = $1 AND family_id <> $2 AND revoked = false
+RETURNING family_id;
+
+-- Sessions
+-- name: CreateSession :one
+INSERT INTO auth.sessions (id, user_id, device_name, user_agent, ip_address)
+VALUES ($1, $2, $3, $4, $5)
+RETURNING *;
+
+-- name: TouchSession :exec
+UPDATE auth.sessions
+SET last_used_at = NOW(), user_agent = $2, ip_address = $3
+WHERE id = $1;
+
+-- A session is active while its family still holds a usable refresh token.
+-- name: ListActiveSessions :many
+SELECT s.id, s.user_id, s.device_name, s.user_agent, s.ip_address, s.created_at, s.last_used_at
+FROM auth.sessions s
+WHERE s.user_id = $1
+  AND EXISTS (
+    SELECT 1 FROM auth.refresh_tokens rt
+    WHERE rt.family_id = s.id
+      AND rt.revoked = false
+      AND rt.rotated_at IS NULL
+      AND rt.expires_at > NOW()
+  )
+ORDER BY s.last_used_at DESC;
+
 -- Audit Log
 -- name: CreateAuditLog :one
 INSERT INTO auth.audit_log (actor_id, action, details)
@@ -58,6 +110,16 @@ SELECT * FROM auth.audit_log WHERE actor_id = $1 ORDER BY created_at DESC;
 -- name: GetUserByID :one
 SELECT * FROM public.users WHERE id = $1 LIMIT 1;
 
+-- Get Profile by ID, with the login email
+-- name: GetUserProfileByID :one
+SELECT
+  pu.id, pu.full_name, pu.phone_number, pu.provider, pu.provider_id, pu.role,
+  pu.created_at, pu.updated_at, au.email
+FROM public.users pu
+JOIN auth.users au ON pu.id = au.id
+WHERE pu.id = $1
+LIMIT 1;
+
 -- Update User Role (admin only)
 -- name: UpdateUserRole :exec
 UPDATE public.users SET role = $1 WHERE id = $2;
@@ -0,0 +1,135 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/AndikaPrasetia/wash-shoe/internal/dto"
+	"github.com/AndikaPrasetia/wash-shoe/internal/usecase"
+	"github.com/gin-gonic/gin"
+)
+
+// AccountHandler serves the admin user console.
+type AccountHandler struct {
+	accountUC usecase.AccountUsecase
+}
+
+func NewAccountHandler(accountUC usecase.AccountUsecase) *AccountHandler {
+	return &AccountHandler{accountUC: accountUC}
+}
+
+func (h *AccountHandler) List(c *gin.Context) {
+	var query dto.ListUsersQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	page, err := h.accountUC.List(c.Request.Context(), query)
+	if err != nil {
+		writeAccountError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, page)
+}
+
+func (h *AccountHandler) Get(c *gin.Context) {
+	userID, ok := paramUserID(c)
+	if !ok {
+		return
+	}
+
+	account, err := h.accountUC.Get(c.Request.Context(), userID)
+	if err != nil {
+		writeAccountError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, account)
+}
+
+func (h *AccountHandler) UpdateRole(c *gin.Context) {
+	authUser, ok := currentUser(c)
+	if !ok {
+		return
+	}
+	userID, ok := paramUserID(c)
+	if !ok {
+		return
+	}
+
+	var req dto.UpdateUserRoleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	account, err := h.accountUC.UpdateRole(c.Request.Context(), authUser, userID, req)
+	if err != nil {
+		writeAccountError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, account)
+}
+
+func (h *AccountHandler) Suspend(c *gin.Context) {
+	authUser, ok := currentUser(c)
+	if !ok {
+		return
+	}
+	userID, ok := paramUserID(c)
+	if !ok {
+		return
+	}
+
+	// the body is optional
+	var req dto.SuspendUserRequest
+	if c.Request.ContentLength != 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+	}
+
+	account, err := h.accountUC.Suspend(c.Request.Context(), authUser, userID, req)
+	if err != nil {
+		writeAccountError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, account)
+}
+
+func (h *AccountHandler) Unsuspend(c *gin.Context) {
+	authUser, ok := currentUser(c)
+	if !ok {
+		return
+	}
+	userID, ok := paramUserID(c)
+	if !ok {
+		return
+	}
+
+	account, err := h.accountUC.Unsuspend(c.Request.Context(), authUser, userID)
+	if err != nil {
+		writeAccountError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, account)
+}
+
+func writeAccountError(c *gin.Context, err error) {
+	status := http.StatusInternalServerError
+
+	switch {
+	case errors.Is(err, usecase.ErrUserNotFound):
+		status = http.StatusNotFound
+	case errors.Is(err, usecase.ErrChangeOwnAccount):
+		status = http.StatusConflict
+	}
+
+	c.JSON(status, gin.H{"error": err.Error()})
+}
@@ -0,0 +1,160 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/AndikaPrasetia/wash-shoe/internal/dto"
+	"github.com/AndikaPrasetia/wash-shoe/internal/usecase"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// AddressHandler serves /users/:id/addresses. Access is checked by
+// AuthMiddleware.RequireSelfOrAdmin on the :id param.
+type AddressHandler struct {
+	addressUC usecase.AddressUsecase
+}
+
+func NewAddressHandler(addressUC usecase.AddressUsecase) *AddressHandler {
+	return &AddressHandler{addressUC: addressUC}
+}
+
+func (h *AddressHandler) List(c *gin.Context) {
+	userID, ok := paramUserID(c)
+	if !ok {
+		return
+	}
+
+	addresses, err := h.addressUC.List(c.Request.Context(), userID)
+	if err != nil {
+		writeAddressError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"addresses": addresses})
+}
+
+func (h *AddressHandler) GetByID(c *gin.Context) {
+	userID, ok := paramUserID(c)
+	if !ok {
+		return
+	}
+	id, ok := paramInt32(c, "addressId")
+	if !ok {
+		return
+	}
+
+	address, err := h.addressUC.GetByID(c.Request.Context(), userID, id)
+	if err != nil {
+		writeAddressError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, address)
+}
+
+func (h *AddressHandler) Create(c *gin.Context) {
+	userID, ok := paramUserID(c)
+	if !ok {
+		return
+	}
+
+	var req dto.AddressRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	address, err := h.addressUC.Create(c.Request.Context(), userID, req)
+	if err != nil {
+		writeAddressError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, address)
+}
+
+func (h *AddressHandler) Update(c *gin.Context) {
+	userID, ok := paramUserID(c)
+	if !ok {
+		return
+	}
+	id, ok := paramInt32(c, "addressId")
+	if !ok {
+		return
+	}
+
+	var req dto.AddressRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	address, err := h.addressUC.Update(c.Request.Context(), userID, id, req)
+	if err != nil {
+		writeAddressError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, address)
+}
+
+func (h *AddressHandler) SetPrimary(c *gin.Context) {
+	userID, ok := paramUserID(c)
+	if !ok {
+		return
+	}
+	id, ok := paramInt32(c, "addressId")
+	if !ok {
+		return
+	}
+
+	if err := h.addressUC.SetPrimary(c.Request.Context(), userID, id); err != nil {
+		writeAddressError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "primary address updated"})
+}
+
+func (h *AddressHandler) Delete(c *gin.Context) {
+	userID, ok := paramUserID(c)
+	if !ok {
+		return
+	}
+	id, ok := paramInt32(c, "addressId")
+	if !ok {
+		return
+	}
+
+	if err := h.addressUC.Delete(c.Request.Context(), userID, id); err != nil {
+		writeAddressError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "address deleted successfully"})
+}
+
+// paramUserID validates the :id path param as a user UUID.
+func paramUserID(c *gin.Context) (string, bool) {
+	userID := c.Param("id")
+	if _, err := uuid.Parse(userID); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid user ID format"})
+		return "", false
+	}
+	return userID, true
+}
+
+func writeAddressError(c *gin.Context, err error) {
+	status := http.StatusInternalServerError
+
+	switch {
+	case errors.Is(err, usecase.ErrAddressNotFound):
+		status = http.StatusNotFound
+	case errors.Is(err, usecase.ErrAddressInUse):
+		status = http.StatusConflict
+	}
+
+	c.JSON(status, gin.H{"error": err.Error()})
+}
@@ -8,8 +8,10 @@ import (
 	"github.com/AndikaPrasetia/wash-shoe/internal/dto"
 	"github.com/AndikaPrasetia/wash-shoe/internal/model"
 	"github.com/AndikaPrasetia/wash-shoe/internal/usecase"
+	modelUtils "github.com/AndikaPrasetia/wash-shoe/internal/utils/model-utils"
 	utils "github.com/AndikaPrasetia/wash-shoe/internal/utils/services"
 	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
 )
 
 type authHandler struct {
@@ -47,7 +49,7 @@ func (h *authHandler) Signup(c *gin.Context) {
 		return
 	}
 
-	user, accessToken, refreshToken, err := h.authUC.Signup(c, req)
+	user, accessToken, refreshToken, err := h.authUC.Signup(c, req, clientDevice(c, req.DeviceName))
 	if err != nil {
 		status := http.StatusInternalServerError
 		errType := "server_error"
@@ -59,6 +61,9 @@ func (h *authHandler) Signup(c *gin.Context) {
 		case errors.Is(err, usecase.ErrInvalidCredentials):
 			status = http.StatusBadRequest
 			errType = "invalid_credential"
+		case errors.Is(err, usecase.ErrInvalidReferralCode):
+			status = http.StatusBadRequest
+			errType = "invalid_referral_code"
 		}
 
 		c.JSON(status, gin.H{
@@ -85,13 +90,17 @@ func (h *authHandler) Login(c *gin.Context) {
 		return
 	}
 
-	accessToken, refreshToken, err := h.authUC.Login(c.Request.Context(), req)
+	accessToken, refreshToken, err := h.authUC.Login(c.Request.Context(), req, clientDevice(c, req.DeviceName))
 	if err != nil {
 		if errors.Is(err, usecase.ErrUserNotFound) ||
 			errors.Is(err, usecase.ErrInvalidCredentials) {
 			c.JSON(http.StatusUnauthorized, gin.H{"error": "invalid credentials"})
 			return
 		}
+		if errors.Is(err, usecase.ErrAccountSuspended) {
+			c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+			return
+		}
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
@@ -118,8 +127,14 @@ func (h *authHandler) Logout(c *gin.Context) {
 		return
 	}
 
-	// Panggil usecase hanya dengan userID
-	err := h.authUC.Logout(c.Request.Context(), authUser.ID)
+	// Access token yang dipakai request ini ikut di-revoke
+	token, ok := c.MustGet("token").(modelUtils.JwtPayloadClaim)
+	if !ok || token.Subject != authUser.ID {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Invalid token data"})
+		return
+	}
+
+	err := h.authUC.Logout(c.Request.Context(), token)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
@@ -140,12 +155,16 @@ func (h *authHandler) RefreshToken(c *gin.Context) {
 		return
 	}
 
-	accessToken, refreshToken, err := h.authUC.RefreshToken(c.Request.Context(), req.RefreshToken)
+	accessToken, refreshToken, err := h.authUC.RefreshToken(c.Request.Context(), req.RefreshToken, clientDevice(c, ""))
 	if err != nil {
 		if errors.Is(err, usecase.ErrInvalidToken) || errors.Is(err, usecase.ErrTokenRevoked) {
 			c.JSON(http.StatusUnauthorized, gin.H{"error": "invalid or revoked refresh token"})
 			return
 		}
+		if errors.Is(err, usecase.ErrTokenReused) {
+			c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+			return
+		}
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
@@ -157,3 +176,101 @@ func (h *authHandler) RefreshToken(c *gin.Context) {
 
 	c.JSON(http.StatusOK, response)
 }
+
+// ChangePassword requires the current password. Every token of the user is
+// revoked afterwards, including the one used for this request.
+func (h *authHandler) ChangePassword(c *gin.Context) {
+	authUser, ok := currentUser(c)
+	if !ok {
+		return
+	}
+
+	var req dto.ChangePasswordRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if err := utils.ValidatePassword(req.NewPassword, 8, 64); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	err := h.authUC.ChangePassword(c.Request.Context(), authUser.ID, req)
+	if err != nil {
+		switch {
+		case errors.Is(err, usecase.ErrWrongPassword):
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		case errors.Is(err, usecase.ErrUserNotFound):
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Password changed, please log in again"})
+}
+
+// Sessions lists the devices the user is logged in on.
+func (h *authHandler) Sessions(c *gin.Context) {
+	token, ok := currentToken(c)
+	if !ok {
+		return
+	}
+
+	sessions, err := h.authUC.ListSessions(c.Request.Context(), token)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, sessions)
+}
+
+// RevokeSession logs one device out. Revoking the current session works
+// like Logout.
+func (h *authHandler) RevokeSession(c *gin.Context) {
+	token, ok := currentToken(c)
+	if !ok {
+		return
+	}
+	id := c.Param("id")
+	if _, err := uuid.Parse(id); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid id"})
+		return
+	}
+
+	err := h.authUC.RevokeSession(c.Request.Context(), token, id)
+	if err != nil {
+		switch {
+		case errors.Is(err, usecase.ErrSessionNotFound):
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		}
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// RevokeOtherSessions logs out every device but the one making the request.
+func (h *authHandler) RevokeOtherSessions(c *gin.Context) {
+	token, ok := currentToken(c)
+	if !ok {
+		return
+	}
+
+	revoked, err := h.authUC.RevokeOtherSessions(c.Request.Context(), token)
+	if err != nil {
+		switch {
+		case errors.Is(err, usecase.ErrInvalidToken):
+			c.JSON(http.StatusBadRequest, gin.H{"error": "token is not bound to a session, please log in again"})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.RevokeSessionsResponse{Revoked: revoked})
+}
@@ -0,0 +1,173 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/AndikaPrasetia/wash-shoe/internal/dto"
+	"github.com/AndikaPrasetia/wash-shoe/internal/usecase"
+	"github.com/gin-gonic/gin"
+)
+
+type BlogHandler struct {
+	blogUC usecase.BlogUsecase
+}
+
+func NewBlogHandler(blogUC usecase.BlogUsecase) *BlogHandler {
+	return &BlogHandler{blogUC: blogUC}
+}
+
+// ListPublished is the public blog index.
+func (h *BlogHandler) ListPublished(c *gin.Context) {
+	var query dto.BlogPostsQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	page, err := h.blogUC.ListPublished(c.Request.Context(), query)
+	if err != nil {
+		writeBlogError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, page)
+}
+
+// GetPublished serves a live post by its slug.
+func (h *BlogHandler) GetPublished(c *gin.Context) {
+	post, err := h.blogUC.GetPublished(c.Request.Context(), c.Param("slug"))
+	if err != nil {
+		writeBlogError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, post)
+}
+
+func (h *BlogHandler) List(c *gin.Context) {
+	var query dto.ListBlogPostsQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	page, err := h.blogUC.List(c.Request.Context(), query)
+	if err != nil {
+		writeBlogError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, page)
+}
+
+func (h *BlogHandler) GetByID(c *gin.Context) {
+	id, ok := paramInt32(c, "id")
+	if !ok {
+		return
+	}
+
+	post, err := h.blogUC.GetByID(c.Request.Context(), id)
+	if err != nil {
+		writeBlogError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, post)
+}
+
+func (h *BlogHandler) Create(c *gin.Context) {
+	authUser, ok := currentUser(c)
+	if !ok {
+		return
+	}
+
+	var req dto.BlogPostRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	post, err := h.blogUC.Create(c.Request.Context(), authUser, req)
+	if err != nil {
+		writeBlogError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, post)
+}
+
+func (h *BlogHandler) Update(c *gin.Context) {
+	id, ok := paramInt32(c, "id")
+	if !ok {
+		return
+	}
+
+	var req dto.BlogPostRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	post, err := h.blogUC.Update(c.Request.Context(), id, req)
+	if err != nil {
+		writeBlogError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, post)
+}
+
+func (h *BlogHandler) Publish(c *gin.Context) {
+	id, ok := paramInt32(c, "id")
+	if !ok {
+		return
+	}
+
+	// the body is optional
+	var req dto.PublishBlogPostRequest
+	if c.Request.ContentLength != 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+	}
+
+	post, err := h.blogUC.Publish(c.Request.Context(), id, req)
+	if err != nil {
+		writeBlogError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, post)
+}
+
+func (h *BlogHandler) Unpublish(c *gin.Context) {
+	id, ok := paramInt32(c, "id")
+	if !ok {
+		return
+	}
+
+	post, err := h.blogUC.Unpublish(c.Request.Context(), id)
+	if err != nil {
+		writeBlogError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, post)
+}
+
+func writeBlogError(c *gin.Context, err error) {
+	status := http.StatusInternalServerError
+
+	switch {
+	case errors.Is(err, usecase.ErrBlogPostNotFound):
+		status = http.StatusNotFound
+	case errors.Is(err, usecase.ErrBlogSlugTaken):
+		status = http.StatusConflict
+	case errors.Is(err, usecase.ErrInvalidBlogSlug):
+		status = http.StatusBadRequest
+	}
+
+	c.JSON(status, gin.H{"error": err.Error()})
+}
@@ -0,0 +1,176 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"github.com/AndikaPrasetia/wash-shoe/internal/dto"
+	"github.com/AndikaPrasetia/wash-shoe/internal/model"
+	"github.com/AndikaPrasetia/wash-shoe/internal/usecase"
+	"github.com/gin-gonic/gin"
+)
+
+type ComplaintHandler struct {
+	complaintUC usecase.ComplaintUsecase
+}
+
+func NewComplaintHandler(complaintUC usecase.ComplaintUsecase) *ComplaintHandler {
+	return &ComplaintHandler{complaintUC: complaintUC}
+}
+
+func (h *ComplaintHandler) Create(c *gin.Context) {
+	authUser, ok := currentUser(c)
+	if !ok {
+		return
+	}
+	id, ok := paramInt32(c, "id")
+	if !ok {
+		return
+	}
+
+	var req dto.CreateComplaintRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	complaint, err := h.complaintUC.Create(c.Request.Context(), authUser, id, req)
+	if err != nil {
+		writeComplaintError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, complaint)
+}
+
+// List returns the complaints filed by the current user.
+func (h *ComplaintHandler) List(c *gin.Context) {
+	authUser, ok := currentUser(c)
+	if !ok {
+		return
+	}
+
+	complaints, err := h.complaintUC.List(c.Request.Context(), authUser)
+	if err != nil {
+		writeComplaintError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"complaints": complaints})
+}
+
+func (h *ComplaintHandler) GetByID(c *gin.Context) {
+	authUser, ok := currentUser(c)
+	if !ok {
+		return
+	}
+	id, ok := paramInt32(c, "id")
+	if !ok {
+		return
+	}
+
+	complaint, err := h.complaintUC.GetByID(c.Request.Context(), authUser, id)
+	if err != nil {
+		writeComplaintError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, complaint)
+}
+
+// ListAll is admin only: ?status=open|resolved|closed&min_age_hours=&max_age_hours=
+func (h *ComplaintHandler) ListAll(c *gin.Context) {
+	var query dto.ListComplaintsQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	complaints, err := h.complaintUC.ListAll(c.Request.Context(), query)
+	if err != nil {
+		writeComplaintError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"complaints": complaints})
+}
+
+func (h *ComplaintHandler) AddNote(c *gin.Context) {
+	authUser, ok := currentUser(c)
+	if !ok {
+		return
+	}
+	id, ok := paramInt32(c, "id")
+	if !ok {
+		return
+	}
+
+	var req dto.ComplaintNoteRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	note, err := h.complaintUC.AddNote(c.Request.Context(), authUser, id, req)
+	if err != nil {
+		writeComplaintError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, note)
+}
+
+func (h *ComplaintHandler) Resolve(c *gin.Context) {
+	h.changeStatus(c, h.complaintUC.Resolve)
+}
+
+func (h *ComplaintHandler) Close(c *gin.Context) {
+	h.changeStatus(c, h.complaintUC.Close)
+}
+
+// changeStatus binds the optional resolution note and applies change.
+func (h *ComplaintHandler) changeStatus(c *gin.Context, change func(ctx context.Context, actor model.User, id int32, req dto.ComplaintStatusRequest) (model.Complaint, error)) {
+	authUser, ok := currentUser(c)
+	if !ok {
+		return
+	}
+	id, ok := paramInt32(c, "id")
+	if !ok {
+		return
+	}
+
+	// the body is optional
+	var req dto.ComplaintStatusRequest
+	if c.Request.ContentLength != 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+	}
+
+	complaint, err := change(c.Request.Context(), authUser, id, req)
+	if err != nil {
+		writeComplaintError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, complaint)
+}
+
+// writeComplaintError maps complaint usecase errors to HTTP responses and
+// falls back to writeOrderError for errors coming from the order lookup.
+func writeComplaintError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, usecase.ErrComplaintNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case errors.Is(err, usecase.ErrComplaintForbidden):
+		c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+	case errors.Is(err, usecase.ErrComplaintClosed),
+		errors.Is(err, usecase.ErrComplaintNotOpen),
+		errors.Is(err, usecase.ErrComplaintStatusConflict):
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	default:
+		writeOrderError(c, err)
+	}
+}
@@ -0,0 +1,62 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/AndikaPrasetia/wash-shoe/internal/model"
+	modelUtils "github.com/AndikaPrasetia/wash-shoe/internal/utils/model-utils"
+	"github.com/gin-gonic/gin"
+)
+
+// currentUser returns the user stored in context by the auth middleware.
+// It writes the error response itself when the user is missing.
+func currentUser(c *gin.Context) (model.User, bool) {
+	user, exists := c.Get("user")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "user not found in context"})
+		return model.User{}, false
+	}
+
+	authUser, ok := user.(model.User)
+	if !ok {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "invalid user type"})
+		return model.User{}, false
+	}
+	return authUser, true
+}
+
+// currentToken returns the access token claims stored in context by the
+// auth middleware, checked against the current user.
+func currentToken(c *gin.Context) (modelUtils.JwtPayloadClaim, bool) {
+	authUser, ok := currentUser(c)
+	if !ok {
+		return modelUtils.JwtPayloadClaim{}, false
+	}
+	token, ok := c.MustGet("token").(modelUtils.JwtPayloadClaim)
+	if !ok || token.Subject != authUser.ID {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "invalid token data"})
+		return modelUtils.JwtPayloadClaim{}, false
+	}
+	return token, true
+}
+
+// clientDevice describes the device a request comes from. name is what the
+// client calls itself, the rest is taken from the request.
+func clientDevice(c *gin.Context, name string) model.Device {
+	return model.Device{
+		Name:      name,
+		UserAgent: c.Request.UserAgent(),
+		IPAddress: c.ClientIP(),
+	}
+}
+
+// paramInt32 parses a numeric path param such as an order ID.
+func paramInt32(c *gin.Context, name string) (int32, bool) {
+	v, err := strconv.ParseInt(c.Param(name), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid " + name})
+		return 0, false
+	}
+	return int32(v), true
+}
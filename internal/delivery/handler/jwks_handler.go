@@ -0,0 +1,24 @@
+package handler
+
+import (
+	"net/http"
+
+	utils "github.com/AndikaPrasetia/wash-shoe/internal/utils/services"
+	"github.com/gin-gonic/gin"
+)
+
+type JWKSHandler struct {
+	jwtSvc utils.JwtService
+}
+
+func NewJWKSHandler(jwtSvc utils.JwtService) *JWKSHandler {
+	return &JWKSHandler{jwtSvc: jwtSvc}
+}
+
+// Get serves the public keys other services verify our tokens with. It may
+// be cached for a few minutes; verifiers should refetch it when they meet an
+// unknown kid.
+func (h *JWKSHandler) Get(c *gin.Context) {
+	c.Header("Cache-Control", "public, max-age=300")
+	c.JSON(http.StatusOK, h.jwtSvc.JWKS())
+}
@@ -0,0 +1,99 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/AndikaPrasetia/wash-shoe/internal/dto"
+	"github.com/AndikaPrasetia/wash-shoe/internal/usecase"
+	"github.com/gin-gonic/gin"
+)
+
+type NotificationHandler struct {
+	notificationUC usecase.NotificationUsecase
+}
+
+func NewNotificationHandler(notificationUC usecase.NotificationUsecase) *NotificationHandler {
+	return &NotificationHandler{notificationUC: notificationUC}
+}
+
+// List returns the current user's in-app notifications: ?cursor=123&limit=20
+func (h *NotificationHandler) List(c *gin.Context) {
+	authUser, ok := currentUser(c)
+	if !ok {
+		return
+	}
+
+	var query dto.ListNotificationsQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	page, err := h.notificationUC.Inbox(c.Request.Context(), authUser.ID, query)
+	if err != nil {
+		writeNotificationError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, page)
+}
+
+func (h *NotificationHandler) UnreadCount(c *gin.Context) {
+	authUser, ok := currentUser(c)
+	if !ok {
+		return
+	}
+
+	n, err := h.notificationUC.UnreadCount(c.Request.Context(), authUser.ID)
+	if err != nil {
+		writeNotificationError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"unread": n})
+}
+
+func (h *NotificationHandler) MarkRead(c *gin.Context) {
+	authUser, ok := currentUser(c)
+	if !ok {
+		return
+	}
+	id, ok := paramInt32(c, "id")
+	if !ok {
+		return
+	}
+
+	n, err := h.notificationUC.MarkRead(c.Request.Context(), authUser.ID, id)
+	if err != nil {
+		writeNotificationError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, n)
+}
+
+func (h *NotificationHandler) MarkAllRead(c *gin.Context) {
+	authUser, ok := currentUser(c)
+	if !ok {
+		return
+	}
+
+	n, err := h.notificationUC.MarkAllRead(c.Request.Context(), authUser.ID)
+	if err != nil {
+		writeNotificationError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"marked_read": n})
+}
+
+func writeNotificationError(c *gin.Context, err error) {
+	status := http.StatusInternalServerError
+
+	if errors.Is(err, usecase.ErrNotificationNotFound) {
+		status = http.StatusNotFound
+	}
+
+	c.JSON(status, gin.H{"error": err.Error()})
+}
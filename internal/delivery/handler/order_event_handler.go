@@ -0,0 +1,80 @@
+package handler
+
+import (
+	"io"
+	"sync"
+	"time"
+
+	"github.com/AndikaPrasetia/wash-shoe/internal/usecase"
+	"github.com/gin-gonic/gin"
+)
+
+// orderEventHeartbeat keeps idle streams from being cut by proxies.
+const orderEventHeartbeat = 15 * time.Second
+
+type OrderEventHandler struct {
+	orderUC   usecase.OrderUsecase
+	eventUC   usecase.OrderEventUsecase
+	closing   chan struct{}
+	closeOnce sync.Once
+}
+
+func NewOrderEventHandler(orderUC usecase.OrderUsecase, eventUC usecase.OrderEventUsecase) *OrderEventHandler {
+	return &OrderEventHandler{orderUC: orderUC, eventUC: eventUC, closing: make(chan struct{})}
+}
+
+// Close ends every open stream. http.Server.Shutdown waits for streams to
+// finish without interrupting them, so Close is registered with
+// RegisterOnShutdown.
+func (h *OrderEventHandler) Close() {
+	h.closeOnce.Do(func() { close(h.closing) })
+}
+
+// Stream sends the order's status changes as Server-Sent Events, starting
+// with its current status. The stream ends once the order reaches a final
+// status, the client goes away or the server shuts down.
+func (h *OrderEventHandler) Stream(c *gin.Context) {
+	authUser, ok := currentUser(c)
+	if !ok {
+		return
+	}
+	id, ok := paramInt32(c, "id")
+	if !ok {
+		return
+	}
+
+	ctx := c.Request.Context()
+	if _, err := h.orderUC.GetByID(ctx, authUser, id); err != nil {
+		writeOrderError(c, err)
+		return
+	}
+	events, unsubscribe, err := h.eventUC.Subscribe(ctx, id)
+	if err != nil {
+		writeOrderError(c, err)
+		return
+	}
+	defer unsubscribe()
+
+	c.Header("Content-Type", "text/event-stream")
+	c.Header("Cache-Control", "no-cache")
+	c.Header("Connection", "keep-alive")
+	c.Header("X-Accel-Buffering", "no")
+
+	heartbeat := time.NewTicker(orderEventHeartbeat)
+	defer heartbeat.Stop()
+
+	c.Stream(func(w io.Writer) bool {
+		select {
+		case <-ctx.Done():
+			return false
+		case <-h.closing:
+			return false
+		case ev := <-events:
+			c.SSEvent("status", ev)
+			return !ev.Final
+		case <-heartbeat.C:
+			_, err := io.WriteString(w, ": ping\n\n")
+			return err == nil
+		}
+	})
+}
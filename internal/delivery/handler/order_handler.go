@@ -0,0 +1,174 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/AndikaPrasetia/wash-shoe/internal/dto"
+	"github.com/AndikaPrasetia/wash-shoe/internal/usecase"
+	"github.com/gin-gonic/gin"
+)
+
+type OrderHandler struct {
+	orderUC usecase.OrderUsecase
+}
+
+func NewOrderHandler(orderUC usecase.OrderUsecase) *OrderHandler {
+	return &OrderHandler{orderUC: orderUC}
+}
+
+func (h *OrderHandler) Create(c *gin.Context) {
+	authUser, ok := currentUser(c)
+	if !ok {
+		return
+	}
+
+	var req dto.CreateOrderRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	order, err := h.orderUC.Create(c.Request.Context(), authUser.ID, req)
+	if err != nil {
+		writeOrderError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, order)
+}
+
+func (h *OrderHandler) List(c *gin.Context) {
+	authUser, ok := currentUser(c)
+	if !ok {
+		return
+	}
+
+	orders, err := h.orderUC.List(c.Request.Context(), authUser.ID)
+	if err != nil {
+		writeOrderError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"orders": orders})
+}
+
+func (h *OrderHandler) GetByID(c *gin.Context) {
+	authUser, ok := currentUser(c)
+	if !ok {
+		return
+	}
+	id, ok := paramInt32(c, "id")
+	if !ok {
+		return
+	}
+
+	order, err := h.orderUC.GetByID(c.Request.Context(), authUser, id)
+	if err != nil {
+		writeOrderError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, order)
+}
+
+func (h *OrderHandler) Cancel(c *gin.Context) {
+	authUser, ok := currentUser(c)
+	if !ok {
+		return
+	}
+	id, ok := paramInt32(c, "id")
+	if !ok {
+		return
+	}
+
+	order, err := h.orderUC.Cancel(c.Request.Context(), authUser, id)
+	if err != nil {
+		writeOrderError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, order)
+}
+
+func (h *OrderHandler) UpdateStatus(c *gin.Context) {
+	authUser, ok := currentUser(c)
+	if !ok {
+		return
+	}
+	id, ok := paramInt32(c, "id")
+	if !ok {
+		return
+	}
+
+	var req dto.UpdateOrderStatusRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	order, err := h.orderUC.UpdateStatus(c.Request.Context(), authUser, id, req.Status)
+	if err != nil {
+		writeOrderError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, order)
+}
+
+func (h *OrderHandler) History(c *gin.Context) {
+	authUser, ok := currentUser(c)
+	if !ok {
+		return
+	}
+	id, ok := paramInt32(c, "id")
+	if !ok {
+		return
+	}
+
+	history, err := h.orderUC.History(c.Request.Context(), authUser, id)
+	if err != nil {
+		writeOrderError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"history": history})
+}
+
+// AtRisk lists open orders that may miss their promised_by deadline.
+func (h *OrderHandler) AtRisk(c *gin.Context) {
+	orders, err := h.orderUC.AtRisk(c.Request.Context())
+	if err != nil {
+		writeOrderError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"orders": orders})
+}
+
+// writeOrderError maps order usecase errors to HTTP responses.
+func writeOrderError(c *gin.Context, err error) {
+	status := http.StatusInternalServerError
+	var transitionErr *usecase.InvalidTransitionError
+
+	switch {
+	case errors.Is(err, usecase.ErrOrderNotFound):
+		status = http.StatusNotFound
+	case errors.Is(err, usecase.ErrOrderForbidden):
+		status = http.StatusForbidden
+	case errors.Is(err, usecase.ErrServiceTypeNotFound),
+		errors.Is(err, usecase.ErrPriceMismatch),
+		errors.Is(err, usecase.ErrInvalidOrderStatus),
+		errors.Is(err, usecase.ErrAddressNotFound),
+		errors.Is(err, usecase.ErrPromoNotFound),
+		errors.Is(err, usecase.ErrPromoExpired),
+		errors.Is(err, usecase.ErrPromoUsedUp):
+		status = http.StatusBadRequest
+	case errors.As(err, &transitionErr),
+		errors.Is(err, usecase.ErrOrderNotCancellable),
+		errors.Is(err, usecase.ErrOrderStatusConflict):
+		status = http.StatusConflict
+	}
+
+	c.JSON(status, gin.H{"error": err.Error()})
+}
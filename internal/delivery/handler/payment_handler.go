@@ -0,0 +1,138 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/AndikaPrasetia/wash-shoe/internal/dto"
+	"github.com/AndikaPrasetia/wash-shoe/internal/payment"
+	"github.com/AndikaPrasetia/wash-shoe/internal/usecase"
+	"github.com/gin-gonic/gin"
+)
+
+type PaymentHandler struct {
+	paymentUC usecase.PaymentUsecase
+}
+
+func NewPaymentHandler(paymentUC usecase.PaymentUsecase) *PaymentHandler {
+	return &PaymentHandler{paymentUC: paymentUC}
+}
+
+func (h *PaymentHandler) Create(c *gin.Context) {
+	authUser, ok := currentUser(c)
+	if !ok {
+		return
+	}
+	id, ok := paramInt32(c, "id")
+	if !ok {
+		return
+	}
+
+	var req dto.CreatePaymentRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	payment, order, err := h.paymentUC.Pay(c.Request.Context(), authUser, id, req)
+	if err != nil {
+		writePaymentError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{"payment": payment, "order": order})
+}
+
+func (h *PaymentHandler) Refund(c *gin.Context) {
+	authUser, ok := currentUser(c)
+	if !ok {
+		return
+	}
+	id, ok := paramInt32(c, "id")
+	if !ok {
+		return
+	}
+
+	var req dto.RefundRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	refund, order, err := h.paymentUC.Refund(c.Request.Context(), authUser, id, req)
+	if err != nil {
+		writePaymentError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{"refund": refund, "order": order})
+}
+
+func (h *PaymentHandler) ListRefunds(c *gin.Context) {
+	authUser, ok := currentUser(c)
+	if !ok {
+		return
+	}
+	id, ok := paramInt32(c, "id")
+	if !ok {
+		return
+	}
+
+	refunds, err := h.paymentUC.ListRefunds(c.Request.Context(), authUser, id)
+	if err != nil {
+		writePaymentError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"refunds": refunds})
+}
+
+// Webhook receives asynchronous payment results from providers. It is
+// public; providers authenticate with the signature header instead.
+func (h *PaymentHandler) Webhook(c *gin.Context) {
+	body, err := c.GetRawData()
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "failed to read request body"})
+		return
+	}
+
+	applied, err := h.paymentUC.HandleWebhook(c.Request.Context(), c.Param("provider"), body,
+		c.GetHeader(payment.SignatureHeader))
+	if err != nil {
+		writePaymentError(c, err)
+		return
+	}
+
+	if !applied {
+		c.JSON(http.StatusOK, gin.H{"message": "event already processed"})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"message": "payment updated"})
+}
+
+// writePaymentError maps payment usecase errors to HTTP responses and falls
+// back to writeOrderError for errors coming from the order lookup.
+func writePaymentError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, usecase.ErrPaymentMethodUnavailable),
+		errors.Is(err, usecase.ErrInvalidWebhookEvent),
+		errors.Is(err, usecase.ErrRefundExceedsPayment):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	case errors.Is(err, usecase.ErrInvalidWebhookSignature):
+		c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+	case errors.Is(err, usecase.ErrUnknownPaymentProvider),
+		errors.Is(err, usecase.ErrPaymentNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case errors.Is(err, usecase.ErrOrderNotPayable),
+		errors.Is(err, usecase.ErrOrderAlreadyPaid),
+		errors.Is(err, usecase.ErrPaymentNotRefundable),
+		errors.Is(err, usecase.ErrInsufficientBalance):
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	case errors.Is(err, usecase.ErrPaymentDeclined):
+		c.JSON(http.StatusPaymentRequired, gin.H{"error": err.Error()})
+	case errors.Is(err, usecase.ErrPaymentGateway):
+		c.JSON(http.StatusBadGateway, gin.H{"error": usecase.ErrPaymentGateway.Error()})
+	default:
+		writeOrderError(c, err)
+	}
+}
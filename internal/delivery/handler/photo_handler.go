@@ -0,0 +1,132 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/AndikaPrasetia/wash-shoe/internal/dto"
+	"github.com/AndikaPrasetia/wash-shoe/internal/usecase"
+	"github.com/gin-gonic/gin"
+)
+
+// photoFormOverhead is the room left in an upload request body for the
+// multipart boundaries and the other form fields.
+const photoFormOverhead = 64 << 10
+
+type PhotoHandler struct {
+	photoUC usecase.PhotoUsecase
+	maxBody int64
+}
+
+// NewPhotoHandler creates a PhotoHandler. maxSize is the largest photo
+// accepted; upload bodies are cut off just above it.
+func NewPhotoHandler(photoUC usecase.PhotoUsecase, maxSize int64) *PhotoHandler {
+	return &PhotoHandler{photoUC: photoUC, maxBody: maxSize + photoFormOverhead}
+}
+
+// Upload takes a multipart form with the image in "photo" and its
+// "type" (before or after).
+func (h *PhotoHandler) Upload(c *gin.Context) {
+	authUser, ok := currentUser(c)
+	if !ok {
+		return
+	}
+	id, ok := paramInt32(c, "id")
+	if !ok {
+		return
+	}
+
+	// the multipart form is spooled to disk while parsing, so its size has
+	// to be limited before anything reads it
+	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, h.maxBody)
+
+	var req dto.UploadPhotoRequest
+	if err := c.ShouldBind(&req); err != nil {
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			writePhotoError(c, usecase.ErrPhotoTooLarge)
+			return
+		}
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	fileHeader, err := c.FormFile("photo")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "photo file is required"})
+		return
+	}
+	file, err := fileHeader.Open()
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "failed to read photo"})
+		return
+	}
+	defer file.Close()
+
+	photo, err := h.photoUC.Upload(c.Request.Context(), authUser, id, req.Type, file)
+	if err != nil {
+		writePhotoError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, photo)
+}
+
+func (h *PhotoHandler) List(c *gin.Context) {
+	authUser, ok := currentUser(c)
+	if !ok {
+		return
+	}
+	id, ok := paramInt32(c, "id")
+	if !ok {
+		return
+	}
+
+	photos, err := h.photoUC.List(c.Request.Context(), authUser, id)
+	if err != nil {
+		writePhotoError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"photos": photos})
+}
+
+// Get streams the image itself.
+func (h *PhotoHandler) Get(c *gin.Context) {
+	authUser, ok := currentUser(c)
+	if !ok {
+		return
+	}
+	id, ok := paramInt32(c, "id")
+	if !ok {
+		return
+	}
+	photoID, ok := paramInt32(c, "photoId")
+	if !ok {
+		return
+	}
+
+	rc, contentType, err := h.photoUC.Open(c.Request.Context(), authUser, id, photoID)
+	if err != nil {
+		writePhotoError(c, err)
+		return
+	}
+	defer rc.Close()
+
+	c.Header("Cache-Control", "private, max-age=3600")
+	c.DataFromReader(http.StatusOK, -1, contentType, rc, nil)
+}
+
+// writePhotoError maps photo usecase errors to HTTP responses and falls
+// back to writeOrderError for errors coming from the order lookup.
+func writePhotoError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, usecase.ErrPhotoNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case errors.Is(err, usecase.ErrPhotoTooLarge):
+		c.JSON(http.StatusRequestEntityTooLarge, gin.H{"error": err.Error()})
+	case errors.Is(err, usecase.ErrPhotoUnsupported):
+		c.JSON(http.StatusUnsupportedMediaType, gin.H{"error": err.Error()})
+	default:
+		writeOrderError(c, err)
+	}
+}
@@ -0,0 +1,121 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/AndikaPrasetia/wash-shoe/internal/dto"
+	"github.com/AndikaPrasetia/wash-shoe/internal/usecase"
+	"github.com/gin-gonic/gin"
+)
+
+type PromoHandler struct {
+	promoUC usecase.PromoUsecase
+	orderUC usecase.OrderUsecase
+}
+
+func NewPromoHandler(promoUC usecase.PromoUsecase, orderUC usecase.OrderUsecase) *PromoHandler {
+	return &PromoHandler{promoUC: promoUC, orderUC: orderUC}
+}
+
+// Validate quotes a cart with a promo code so checkout can show the
+// discount before the order is placed.
+func (h *PromoHandler) Validate(c *gin.Context) {
+	var req dto.ValidatePromoRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	quote, err := h.orderUC.Quote(c.Request.Context(), dto.CreateOrderRequest{
+		IsExpress: req.IsExpress,
+		PromoCode: req.PromoCode,
+		Services:  req.Services,
+	})
+	if err != nil {
+		writePromoError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, quote)
+}
+
+func (h *PromoHandler) List(c *gin.Context) {
+	promos, err := h.promoUC.List(c.Request.Context())
+	if err != nil {
+		writePromoError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"promos": promos})
+}
+
+func (h *PromoHandler) GetByCode(c *gin.Context) {
+	promo, err := h.promoUC.GetByCode(c.Request.Context(), c.Param("code"))
+	if err != nil {
+		writePromoError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, promo)
+}
+
+func (h *PromoHandler) Create(c *gin.Context) {
+	var req dto.CreatePromoRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	promo, err := h.promoUC.Create(c.Request.Context(), req)
+	if err != nil {
+		writePromoError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, promo)
+}
+
+func (h *PromoHandler) Update(c *gin.Context) {
+	var req dto.PromoRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	promo, err := h.promoUC.Update(c.Request.Context(), c.Param("code"), req)
+	if err != nil {
+		writePromoError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, promo)
+}
+
+func (h *PromoHandler) Delete(c *gin.Context) {
+	if err := h.promoUC.Delete(c.Request.Context(), c.Param("code")); err != nil {
+		writePromoError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "promo deleted successfully"})
+}
+
+func writePromoError(c *gin.Context, err error) {
+	status := http.StatusInternalServerError
+
+	switch {
+	case errors.Is(err, usecase.ErrPromoNotFound):
+		status = http.StatusNotFound
+	case errors.Is(err, usecase.ErrPromoCodeTaken):
+		status = http.StatusConflict
+	case errors.Is(err, usecase.ErrPromoExpired),
+		errors.Is(err, usecase.ErrPromoUsedUp),
+		errors.Is(err, usecase.ErrInvalidPromoDiscount),
+		errors.Is(err, usecase.ErrServiceTypeNotFound),
+		errors.Is(err, usecase.ErrPriceMismatch):
+		status = http.StatusBadRequest
+	}
+
+	c.JSON(status, gin.H{"error": err.Error()})
+}
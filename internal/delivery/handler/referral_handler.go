@@ -0,0 +1,32 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/AndikaPrasetia/wash-shoe/internal/usecase"
+	"github.com/gin-gonic/gin"
+)
+
+type ReferralHandler struct {
+	referralUC usecase.ReferralUsecase
+}
+
+func NewReferralHandler(referralUC usecase.ReferralUsecase) *ReferralHandler {
+	return &ReferralHandler{referralUC: referralUC}
+}
+
+// Summary returns the current user's invite code and referrals.
+func (h *ReferralHandler) Summary(c *gin.Context) {
+	authUser, ok := currentUser(c)
+	if !ok {
+		return
+	}
+
+	summary, err := h.referralUC.Summary(c.Request.Context(), authUser.ID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, summary)
+}
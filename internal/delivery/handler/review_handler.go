@@ -0,0 +1,74 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/AndikaPrasetia/wash-shoe/internal/dto"
+	"github.com/AndikaPrasetia/wash-shoe/internal/usecase"
+	"github.com/gin-gonic/gin"
+)
+
+type ReviewHandler struct {
+	reviewUC usecase.ReviewUsecase
+}
+
+func NewReviewHandler(reviewUC usecase.ReviewUsecase) *ReviewHandler {
+	return &ReviewHandler{reviewUC: reviewUC}
+}
+
+func (h *ReviewHandler) Create(c *gin.Context) {
+	authUser, ok := currentUser(c)
+	if !ok {
+		return
+	}
+	id, ok := paramInt32(c, "id")
+	if !ok {
+		return
+	}
+
+	var req dto.CreateReviewRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	review, err := h.reviewUC.Create(c.Request.Context(), authUser, id, req)
+	if err != nil {
+		writeReviewError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, review)
+}
+
+// ServiceTypeRating is public: average, distribution and recent comments
+// of a service type.
+func (h *ReviewHandler) ServiceTypeRating(c *gin.Context) {
+	id, ok := paramInt32(c, "id")
+	if !ok {
+		return
+	}
+
+	rating, err := h.reviewUC.ServiceTypeRating(c.Request.Context(), id)
+	if err != nil {
+		writeReviewError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, rating)
+}
+
+// writeReviewError maps review usecase errors to HTTP responses and falls
+// back to writeOrderError for errors coming from the order lookup.
+func writeReviewError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, usecase.ErrOrderNotReviewable),
+		errors.Is(err, usecase.ErrOrderAlreadyReviewed):
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	case errors.Is(err, usecase.ErrServiceTypeNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	default:
+		writeOrderError(c, err)
+	}
+}
@@ -0,0 +1,114 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/AndikaPrasetia/wash-shoe/internal/dto"
+	"github.com/AndikaPrasetia/wash-shoe/internal/usecase"
+	"github.com/gin-gonic/gin"
+)
+
+type ServiceTypeHandler struct {
+	serviceTypeUC usecase.ServiceTypeUsecase
+}
+
+func NewServiceTypeHandler(serviceTypeUC usecase.ServiceTypeUsecase) *ServiceTypeHandler {
+	return &ServiceTypeHandler{serviceTypeUC: serviceTypeUC}
+}
+
+// List is public: ?eco_friendly=true&sort=price_asc|price_desc
+func (h *ServiceTypeHandler) List(c *gin.Context) {
+	var query dto.ListServiceTypesQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	services, err := h.serviceTypeUC.List(c.Request.Context(), query)
+	if err != nil {
+		writeServiceTypeError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"services": services})
+}
+
+func (h *ServiceTypeHandler) GetByID(c *gin.Context) {
+	id, ok := paramInt32(c, "id")
+	if !ok {
+		return
+	}
+
+	service, err := h.serviceTypeUC.GetByID(c.Request.Context(), id)
+	if err != nil {
+		writeServiceTypeError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, service)
+}
+
+func (h *ServiceTypeHandler) Create(c *gin.Context) {
+	var req dto.ServiceTypeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	service, err := h.serviceTypeUC.Create(c.Request.Context(), req)
+	if err != nil {
+		writeServiceTypeError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, service)
+}
+
+func (h *ServiceTypeHandler) Update(c *gin.Context) {
+	id, ok := paramInt32(c, "id")
+	if !ok {
+		return
+	}
+
+	var req dto.ServiceTypeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	service, err := h.serviceTypeUC.Update(c.Request.Context(), id, req)
+	if err != nil {
+		writeServiceTypeError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, service)
+}
+
+func (h *ServiceTypeHandler) Archive(c *gin.Context) {
+	id, ok := paramInt32(c, "id")
+	if !ok {
+		return
+	}
+
+	if err := h.serviceTypeUC.Archive(c.Request.Context(), id); err != nil {
+		writeServiceTypeError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "service archived successfully"})
+}
+
+func writeServiceTypeError(c *gin.Context, err error) {
+	status := http.StatusInternalServerError
+
+	switch {
+	case errors.Is(err, usecase.ErrServiceTypeNotFound):
+		status = http.StatusNotFound
+	case errors.Is(err, usecase.ErrServiceTypeNameTaken):
+		status = http.StatusConflict
+	}
+
+	c.JSON(status, gin.H{"error": err.Error()})
+}
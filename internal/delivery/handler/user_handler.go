@@ -1,8 +1,10 @@
 package handler
 
 import (
+	"errors"
 	"net/http"
 
+	"github.com/AndikaPrasetia/wash-shoe/internal/dto"
 	"github.com/AndikaPrasetia/wash-shoe/internal/model"
 	"github.com/AndikaPrasetia/wash-shoe/internal/usecase"
 	"github.com/gin-gonic/gin"
@@ -86,3 +88,80 @@ func (h *UserHandler) Delete(c *gin.Context) {
     })
 }
 
+// Me returns the profile of the logged-in user.
+func (h *UserHandler) Me(c *gin.Context) {
+    authUser, ok := currentUser(c)
+    if !ok {
+        return
+    }
+    id, err := uuid.Parse(authUser.ID)
+    if err != nil {
+        c.JSON(http.StatusInternalServerError, gin.H{"error": "invalid current user ID"})
+        return
+    }
+
+    profile, err := h.userUC.GetByID(c.Request.Context(), pgtype.UUID{Bytes: id, Valid: true})
+    if err != nil {
+        writeUserError(c, err)
+        return
+    }
+
+    c.JSON(http.StatusOK, profile)
+}
+
+// UpdateMe changes full_name and phone_number of the logged-in user.
+func (h *UserHandler) UpdateMe(c *gin.Context) {
+    authUser, ok := currentUser(c)
+    if !ok {
+        return
+    }
+    id, err := uuid.Parse(authUser.ID)
+    if err != nil {
+        c.JSON(http.StatusInternalServerError, gin.H{"error": "invalid current user ID"})
+        return
+    }
+
+    var req dto.UpdateProfileRequest
+    if err := c.ShouldBindJSON(&req); err != nil {
+        c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+        return
+    }
+
+    profile, err := h.userUC.UpdateProfile(c.Request.Context(), pgtype.UUID{Bytes: id, Valid: true}, req)
+    if err != nil {
+        writeUserError(c, err)
+        return
+    }
+
+    c.JSON(http.StatusOK, profile)
+}
+
+// GetByID returns any user's profile (admin only).
+func (h *UserHandler) GetByID(c *gin.Context) {
+    userID, ok := paramUserID(c)
+    if !ok {
+        return
+    }
+
+    profile, err := h.userUC.GetByID(c.Request.Context(), pgtype.UUID{Bytes: uuid.MustParse(userID), Valid: true})
+    if err != nil {
+        writeUserError(c, err)
+        return
+    }
+
+    c.JSON(http.StatusOK, profile)
+}
+
+func writeUserError(c *gin.Context, err error) {
+    status := http.StatusInternalServerError
+
+    switch {
+    case errors.Is(err, usecase.ErrUserNotFound):
+        status = http.StatusNotFound
+    case errors.Is(err, usecase.ErrEmptyFullName),
+        errors.Is(err, usecase.ErrInvalidPhoneNumber):
+        status = http.StatusBadRequest
+    }
+
+    c.JSON(status, gin.H{"error": err.Error()})
+}
@@ -0,0 +1,97 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/AndikaPrasetia/wash-shoe/internal/dto"
+	"github.com/AndikaPrasetia/wash-shoe/internal/usecase"
+	"github.com/gin-gonic/gin"
+)
+
+type WalletHandler struct {
+	walletUC usecase.WalletUsecase
+}
+
+func NewWalletHandler(walletUC usecase.WalletUsecase) *WalletHandler {
+	return &WalletHandler{walletUC: walletUC}
+}
+
+// Balance returns the current user's wallet balance.
+func (h *WalletHandler) Balance(c *gin.Context) {
+	authUser, ok := currentUser(c)
+	if !ok {
+		return
+	}
+
+	wallet, err := h.walletUC.Balance(c.Request.Context(), authUser.ID)
+	if err != nil {
+		writeWalletError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, wallet)
+}
+
+// Statement lists the current user's wallet entries: ?limit=20&offset=0
+func (h *WalletHandler) Statement(c *gin.Context) {
+	authUser, ok := currentUser(c)
+	if !ok {
+		return
+	}
+
+	var query dto.WalletStatementQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	entries, err := h.walletUC.Statement(c.Request.Context(), authUser.ID, query)
+	if err != nil {
+		writeWalletError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"entries": entries})
+}
+
+// Adjust is admin only: credits or debits the wallet of user :id.
+func (h *WalletHandler) Adjust(c *gin.Context) {
+	authUser, ok := currentUser(c)
+	if !ok {
+		return
+	}
+	userID, ok := paramUserID(c)
+	if !ok {
+		return
+	}
+
+	var req dto.WalletAdjustmentRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	entry, err := h.walletUC.Adjust(c.Request.Context(), authUser, userID, req)
+	if err != nil {
+		writeWalletError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, entry)
+}
+
+func writeWalletError(c *gin.Context, err error) {
+	status := http.StatusInternalServerError
+
+	switch {
+	case errors.Is(err, usecase.ErrUserNotFound):
+		status = http.StatusNotFound
+	case errors.Is(err, usecase.ErrInvalidWalletAmount):
+		status = http.StatusBadRequest
+	case errors.Is(err, usecase.ErrInsufficientBalance):
+		status = http.StatusConflict
+	}
+
+	c.JSON(status, gin.H{"error": err.Error()})
+}
@@ -0,0 +1,22 @@
+package dto
+
+// ListUsersQuery searches users by email or name (Q) and filters them by
+// role, sign-up provider and suspension.
+type ListUsersQuery struct {
+	Q         string `form:"q" binding:"max=100"`
+	Role      string `form:"role" binding:"omitempty,oneof=admin user"`
+	Provider  string `form:"provider" binding:"max=50"`
+	Suspended *bool  `form:"suspended"`
+	Limit     int32  `form:"limit" binding:"omitempty,min=1,max=100"`
+	Offset    int32  `form:"offset" binding:"omitempty,min=0"`
+}
+
+type UpdateUserRoleRequest struct {
+	Role string `json:"role" binding:"required,oneof=admin user"`
+}
+
+// SuspendUserRequest optionally gives a reason, which is kept in the audit
+// log.
+type SuspendUserRequest struct {
+	Reason string `json:"reason" binding:"max=500"`
+}
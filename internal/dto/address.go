@@ -0,0 +1,10 @@
+package dto
+
+type AddressRequest struct {
+	Street     string `json:"street" binding:"required"`
+	City       string `json:"city" binding:"required"`
+	Province   string `json:"province" binding:"required"`
+	PostalCode string `json:"postal_code"`
+	Notes      string `json:"notes"`
+	IsPrimary  bool   `json:"is_primary"`
+}
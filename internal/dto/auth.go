@@ -23,11 +23,14 @@ type SignupRequest struct {
 	Email           string `json:"email" binding:"required,email"`
 	Password        string `json:"password" binding:"required"`
 	ConfirmPassword string `json:"confirm_password" binding:"required,min=8"`
+	ReferralCode    string `json:"referral_code"`
+	DeviceName      string `json:"device_name" binding:"omitempty,max=100"`
 }
 
 type LoginRequest struct {
-	Email    string `json:"email" binding:"required,email"`
-	Password string `json:"password" binding:"required,min=8"`
+	Email      string `json:"email" binding:"required,email"`
+	Password   string `json:"password" binding:"required,min=8"`
+	DeviceName string `json:"device_name" binding:"omitempty,max=100"`
 }
 
 type LoginResponse struct {
@@ -39,6 +42,15 @@ type LogoutResponse struct {
 	Message string `json:"message"`
 }
 
+type ChangePasswordRequest struct {
+	CurrentPassword string `json:"current_password" binding:"required"`
+	NewPassword     string `json:"new_password" binding:"required"`
+}
+
+type RevokeSessionsResponse struct {
+	Revoked int `json:"revoked"`
+}
+
 type RefreshTokenRequest struct {
 	RefreshToken string `json:"refresh_token" binding:"required"`
 }
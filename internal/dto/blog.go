@@ -0,0 +1,30 @@
+package dto
+
+import "time"
+
+// BlogPostRequest carries the Markdown source of a post. Without a slug one
+// is derived from the title when the post is created, and the current one
+// is kept when it is edited.
+type BlogPostRequest struct {
+	Title   string `json:"title" binding:"required,max=200"`
+	Slug    string `json:"slug" binding:"omitempty,max=100"`
+	Excerpt string `json:"excerpt" binding:"max=500"`
+	Content string `json:"content" binding:"required"`
+}
+
+// PublishBlogPostRequest publishes a post at PublishedAt, or right away
+// when it is empty. A time in the future schedules the post.
+type PublishBlogPostRequest struct {
+	PublishedAt *time.Time `json:"published_at"`
+}
+
+type BlogPostsQuery struct {
+	Limit  int32 `form:"limit" binding:"omitempty,min=1,max=100"`
+	Offset int32 `form:"offset" binding:"omitempty,min=0"`
+}
+
+// ListBlogPostsQuery filters the admin post listing by status.
+type ListBlogPostsQuery struct {
+	Status string `form:"status" binding:"omitempty,oneof=draft scheduled published"`
+	BlogPostsQuery
+}
@@ -0,0 +1,23 @@
+package dto
+
+type CreateComplaintRequest struct {
+	Description string `json:"description" binding:"required,max=2000"`
+}
+
+// ListComplaintsQuery filters the admin complaint queue. Ages are counted
+// in hours since the complaint was filed.
+type ListComplaintsQuery struct {
+	Status      string `form:"status" binding:"omitempty,oneof=open resolved closed"`
+	MinAgeHours int    `form:"min_age_hours" binding:"omitempty,min=1"`
+	MaxAgeHours int    `form:"max_age_hours" binding:"omitempty,min=1"`
+}
+
+type ComplaintNoteRequest struct {
+	Note string `json:"note" binding:"required,max=2000"`
+}
+
+// ComplaintStatusRequest optionally carries a resolution note that is added
+// together with the status change.
+type ComplaintStatusRequest struct {
+	Note string `json:"note" binding:"max=2000"`
+}
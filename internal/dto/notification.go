@@ -0,0 +1,8 @@
+package dto
+
+// ListNotificationsQuery pages through the inbox newest first. Cursor is the
+// next_cursor of the previous page.
+type ListNotificationsQuery struct {
+	Cursor *int32 `form:"cursor" binding:"omitempty,min=1"`
+	Limit  int32  `form:"limit" binding:"omitempty,min=1,max=100"`
+}
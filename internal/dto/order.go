@@ -0,0 +1,18 @@
+package dto
+
+type OrderServiceRequest struct {
+	ServiceTypeID int32    `json:"service_type_id" binding:"required"`
+	Quantity      int32    `json:"quantity" binding:"required,min=1"`
+	Price         *float64 `json:"price"` // optional, checked against base_price
+}
+
+type CreateOrderRequest struct {
+	AddressID *int32                `json:"address_id"`
+	IsExpress bool                  `json:"is_express"`
+	PromoCode string                `json:"promo_code"`
+	Services  []OrderServiceRequest `json:"services" binding:"required,min=1,dive"`
+}
+
+type UpdateOrderStatusRequest struct {
+	Status string `json:"status" binding:"required"`
+}
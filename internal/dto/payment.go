@@ -0,0 +1,15 @@
+package dto
+
+// CreatePaymentRequest pays an order through Method, after taking up to
+// WalletAmount from the customer's wallet.
+type CreatePaymentRequest struct {
+	Method       string  `json:"method" binding:"required,oneof=DANA OVO COD Bank_Transfer QRIS"`
+	WalletAmount float64 `json:"wallet_amount" binding:"gte=0"`
+}
+
+// RefundRequest refunds part of a payment, or all that is left when Amount
+// is omitted.
+type RefundRequest struct {
+	Amount *float64 `json:"amount" binding:"omitempty,gt=0"`
+	Reason string   `json:"reason" binding:"required"`
+}
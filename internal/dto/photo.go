@@ -0,0 +1,6 @@
+package dto
+
+// UploadPhotoRequest is the non-file part of the multipart photo upload.
+type UploadPhotoRequest struct {
+	Type string `form:"type" binding:"required,oneof=before after"`
+}
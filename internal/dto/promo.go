@@ -0,0 +1,22 @@
+package dto
+
+import "time"
+
+type PromoRequest struct {
+	DiscountType  string     `json:"discount_type" binding:"required,oneof=percentage fixed"`
+	DiscountValue float64    `json:"discount_value" binding:"required,gt=0"`
+	MaxUsage      *int32     `json:"max_usage" binding:"omitempty,min=1"`
+	ValidUntil    *time.Time `json:"valid_until"`
+}
+
+type CreatePromoRequest struct {
+	Code string `json:"code" binding:"required"`
+	PromoRequest
+}
+
+// ValidatePromoRequest quotes a cart against a promo code before checkout.
+type ValidatePromoRequest struct {
+	PromoCode string                `json:"promo_code" binding:"required"`
+	IsExpress bool                  `json:"is_express"`
+	Services  []OrderServiceRequest `json:"services" binding:"required,min=1,dive"`
+}
@@ -0,0 +1,6 @@
+package dto
+
+type CreateReviewRequest struct {
+	Rating  int32  `json:"rating" binding:"required,min=1,max=5"`
+	Comment string `json:"comment" binding:"max=1000"`
+}
@@ -0,0 +1,14 @@
+package dto
+
+type ListServiceTypesQuery struct {
+	EcoFriendly bool   `form:"eco_friendly"`
+	Sort        string `form:"sort" binding:"omitempty,oneof=price_asc price_desc"`
+}
+
+type ServiceTypeRequest struct {
+	Name                   string  `json:"name" binding:"required"`
+	Description            string  `json:"description"`
+	BasePrice              float64 `json:"base_price" binding:"required,gt=0"`
+	EstimatedDurationHours *int32  `json:"estimated_duration_hours" binding:"omitempty,min=1"`
+	IsEcoFriendly          bool    `json:"is_eco_friendly"`
+}
@@ -0,0 +1,8 @@
+package dto
+
+// UpdateProfileRequest only changes the fields that are present. An empty
+// phone_number removes the number.
+type UpdateProfileRequest struct {
+	FullName    *string `json:"full_name" binding:"omitempty,max=100"`
+	PhoneNumber *string `json:"phone_number" binding:"omitempty,max=30"`
+}
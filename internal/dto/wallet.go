@@ -0,0 +1,13 @@
+package dto
+
+type WalletStatementQuery struct {
+	Limit  int32 `form:"limit" binding:"omitempty,min=1,max=100"`
+	Offset int32 `form:"offset" binding:"omitempty,min=0"`
+}
+
+// WalletAdjustmentRequest credits a wallet, or debits it when Amount is
+// negative.
+type WalletAdjustmentRequest struct {
+	Amount float64 `json:"amount" binding:"required"`
+	Reason string  `json:"reason" binding:"required,max=500"`
+}
@@ -0,0 +1,40 @@
+// Package markdown renders the Markdown used for blog posts to HTML.
+//
+// Parsing and rendering are done by goldmark (CommonMark), the result is
+// sanitized by bluemonday. Raw HTML in the source is dropped, links and
+// images are limited to http, https, mailto and relative URLs, and links
+// get rel="nofollow". The output is safe to serve as is.
+package markdown
+
+import (
+	"bytes"
+	"regexp"
+
+	"github.com/microcosm-cc/bluemonday"
+	"github.com/yuin/goldmark"
+)
+
+var (
+	// renderer leaves goldmark's unsafe mode off, so raw HTML blocks and
+	// inline tags are omitted from its output.
+	renderer = goldmark.New()
+
+	// policy is bluemonday's policy for user generated content, which
+	// already limits URLs to http, https, mailto and relative ones, plus the
+	// language class goldmark puts on fenced code.
+	policy = func() *bluemonday.Policy {
+		p := bluemonday.UGCPolicy().RequireNoFollowOnLinks(true)
+		p.AllowAttrs("class").Matching(regexp.MustCompile(`^language-[A-Za-z0-9_+-]+$`)).OnElements("code")
+		return p
+	}()
+)
+
+// Render converts Markdown source to sanitized HTML.
+func Render(src string) string {
+	var buf bytes.Buffer
+	if err := renderer.Convert([]byte(src), &buf); err != nil {
+		// goldmark only fails when writing to buf fails, which it doesn't
+		return ""
+	}
+	return policy.Sanitize(buf.String())
+}
@@ -0,0 +1,59 @@
+package markdown
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestRender(t *testing.T) {
+	tests := []struct {
+		name    string
+		src     string
+		want    []string
+		notWant []string
+	}{
+		// raw HTML is dropped, wherever it appears
+		{"script tag", "<script>alert(1)</script>", nil, []string{"<script", "alert"}},
+		{"inline tag", "hi <img src=x onerror=alert(1)>", []string{"<p>hi"}, []string{"<img", "onerror"}},
+		{"tag in quote", "> <script>x</script>", []string{"<blockquote>"}, []string{"<script"}},
+		{"tag in code span", "`<b>`", []string{"<code>&lt;b&gt;</code>"}, []string{"<b>"}},
+		{"tag in code block", "```\n</code><script>x</script>\n```", []string{"&lt;script&gt;"}, []string{"<script"}},
+
+		// links and images outside http, https, mailto and relative URLs
+		// lose their URL
+		{"javascript link", "[x](javascript:alert(1))", []string{"x"}, []string{"href", "javascript"}},
+		{"javascript link mixed case", "[x](JaVaScRiPt:alert(1))", []string{"x"}, []string{"href", "JaVaScRiPt"}},
+		{"javascript link in brackets", "[x](<javascript:alert(1)>)", []string{"x"}, []string{"href", "javascript"}},
+		{"vbscript link", "[x](vbscript:msgbox)", []string{"x"}, []string{"href", "vbscript"}},
+		{"data link", "[x](data:text/html;base64,PHNjcmlwdD4=)", []string{"x"}, []string{"href", "data:"}},
+		{"data image", "![x](data:image/svg+xml;base64,PHN2Zz4=)", nil, []string{"src", "data:"}},
+
+		// quotes can't add attributes
+		{"title with handler", `[x](http://a "title" onclick="alert(1)")`, nil, []string{`onclick="`}},
+		{"quote in destination", `[x](http://a"onclick="alert(1))`, nil, []string{`onclick="`}},
+		{"quote in alt text", `![x" onerror="alert(1)](http://a/b.png)`, []string{`src="http://a/b.png"`}, []string{`onerror="`}},
+		{"quote in code language", "```js\" onclick=\"x\ncode\n```", []string{"<code>code"}, []string{"onclick"}},
+
+		// what is kept
+		{"code language", "```go\ncode\n```", []string{`<code class="language-go">`}, nil},
+		{"https link", "[**b**](https://example.com/a?b=1&c=2)", []string{`<a href="https://example.com/a?b=1&amp;c=2" rel="nofollow"><strong>b</strong></a>`}, nil},
+		{"mailto link", "[x](mailto:a@b.c)", []string{`<a href="mailto:a@b.c" rel="nofollow">x</a>`}, nil},
+		{"relative link", "[x](/blog/care)", []string{`<a href="/blog/care" rel="nofollow">x</a>`}, nil},
+		{"formatting", "# Title\n\n*a* **b**\n\n- one\n\n> quote\n\n---", []string{"<h1>Title</h1>", "<em>a</em>", "<strong>b</strong>", "<li>one</li>", "<blockquote>", "<hr>"}, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := Render(tt.src)
+			for _, w := range tt.want {
+				if !strings.Contains(got, w) {
+					t.Errorf("Render(%q) = %q, want it to contain %q", tt.src, got, w)
+				}
+			}
+			for _, w := range tt.notWant {
+				if strings.Contains(got, w) {
+					t.Errorf("Render(%q) = %q, must not contain %q", tt.src, got, w)
+				}
+			}
+		})
+	}
+}
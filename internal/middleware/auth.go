@@ -2,12 +2,15 @@
 package middleware
 
 import (
-	"fmt"
+	"context"
+	"errors"
 	"net/http"
 	"slices"
 	"strings"
 
 	"github.com/AndikaPrasetia/wash-shoe/internal/model"
+	"github.com/AndikaPrasetia/wash-shoe/internal/usecase"
+	modelUtils "github.com/AndikaPrasetia/wash-shoe/internal/utils/model-utils"
 	utils "github.com/AndikaPrasetia/wash-shoe/internal/utils/services"
 	"github.com/gin-gonic/gin"
 )
@@ -18,21 +21,34 @@ type AuthMiddleware interface {
 	RequireSelfOrAdmin() gin.HandlerFunc
 }
 
+// AccountChecker tells whether the user behind a valid token may still use
+// the API.
+type AccountChecker interface {
+	CheckActive(ctx context.Context, userID string) error
+}
+
+// RevocationChecker tells whether a valid token has been revoked before it
+// expired.
+type RevocationChecker interface {
+	CheckRevoked(ctx context.Context, token modelUtils.JwtPayloadClaim) error
+}
+
 type authMiddleware struct {
-	jwtService utils.JwtService
+	jwtService  utils.JwtService
+	accounts    AccountChecker
+	revocations RevocationChecker
 }
 
-func NewAuthMiddleware(jwtService utils.JwtService) AuthMiddleware {
+func NewAuthMiddleware(jwtService utils.JwtService, accounts AccountChecker, revocations RevocationChecker) AuthMiddleware {
 	return &authMiddleware{
-		jwtService: jwtService,
+		jwtService:  jwtService,
+		accounts:    accounts,
+		revocations: revocations,
 	}
 }
 
 func (a *authMiddleware) Middleware() gin.HandlerFunc {
 	return func(c *gin.Context) {
-		// [DEBUG] Log request path
-		fmt.Printf("Request path: %s\n", c.Request.URL.Path)
-
 		// Ekstrak header Authorization
 		authHeader := c.GetHeader("Authorization")
 		if authHeader == "" {
@@ -55,15 +71,19 @@ func (a *authMiddleware) Middleware() gin.HandlerFunc {
 		// Verifikasi token
 		tokenClaim, err := a.jwtService.VerifyToken(token)
 		if err != nil {
-			fmt.Printf("Token verification error: %v\n", err)
 			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{
 				"message": "Invalid token",
 			})
 			return
 		}
 
-		// [DEBUG] Log claims
-		fmt.Printf("Token claims: %+v\n", tokenClaim)
+		// Refresh tokens can only be exchanged at /auth/refresh
+		if tokenClaim.Type != utils.TokenTypeAccess {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{
+				"message": "Invalid token type",
+			})
+			return
+		}
 
 		// Pastikan user ID tidak kosong
 		userID := tokenClaim.Subject
@@ -73,18 +93,54 @@ func (a *authMiddleware) Middleware() gin.HandlerFunc {
 		}
 
 		if userID == "" {
-			fmt.Println("User ID is empty in token claims")
 			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{
 				"message": "Invalid token: missing user ID",
 			})
 			return
 		}
 
+		// Revoked tokens are refused, and so is every token while the
+		// revocation list can't be checked.
+		if err := a.revocations.CheckRevoked(c.Request.Context(), tokenClaim); err != nil {
+			if errors.Is(err, usecase.ErrTokenRevoked) {
+				c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{
+					"message": "Token revoked",
+				})
+			} else {
+				c.AbortWithStatusJSON(http.StatusServiceUnavailable, gin.H{
+					"message": "Unable to verify token",
+				})
+			}
+			return
+		}
+
+		// Suspended and deleted accounts lose access even with a valid
+		// token; when the status can't be checked the request is refused.
+		if err := a.accounts.CheckActive(c.Request.Context(), userID); err != nil {
+			switch {
+			case errors.Is(err, usecase.ErrAccountSuspended):
+				c.AbortWithStatusJSON(http.StatusForbidden, gin.H{
+					"message": "Account suspended",
+				})
+			case errors.Is(err, usecase.ErrUserNotFound):
+				c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{
+					"message": "Invalid token: user no longer exists",
+				})
+			default:
+				c.AbortWithStatusJSON(http.StatusServiceUnavailable, gin.H{
+					"message": "Unable to verify account",
+				})
+			}
+			return
+		}
+
 		// Simpan user di context
 		c.Set("user", model.User{
-			ID:   userID,
-			Role: tokenClaim.Role,
+			ID:    userID,
+			Email: tokenClaim.Email,
+			Role:  tokenClaim.Role,
 		})
+		c.Set("token", tokenClaim)
 		c.Next()
 	}
 }
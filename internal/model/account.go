@@ -0,0 +1,43 @@
+package model
+
+import "time"
+
+// Account is a user as admins see it: the profile together with the login
+// state kept in auth.users. SuspendedAt is set while the account is
+// suspended.
+type Account struct {
+	ID           string     `json:"id"`
+	Email        string     `json:"email"`
+	FullName     string     `json:"full_name"`
+	PhoneNumber  string     `json:"phone_number"`
+	Provider     string     `json:"provider"`
+	Role         string     `json:"role"`
+	CreatedAt    time.Time  `json:"created_at"`
+	LastSignInAt *time.Time `json:"last_sign_in_at,omitempty"`
+	SuspendedAt  *time.Time `json:"suspended_at,omitempty"`
+}
+
+// AccountFilter narrows the admin user listing. Search matches email or
+// full name; empty fields and a nil Suspended don't filter.
+type AccountFilter struct {
+	Search    string
+	Role      string
+	Provider  string
+	Suspended *bool
+}
+
+// AccountPage is one page of the user listing. Total counts every user
+// matching the filter.
+type AccountPage struct {
+	Users  []Account `json:"users"`
+	Total  int64     `json:"total"`
+	Limit  int32     `json:"limit"`
+	Offset int32     `json:"offset"`
+}
+
+// AccountDetail is a user with their orders and latest audit log entries.
+type AccountDetail struct {
+	Account
+	Orders   []Order    `json:"orders"`
+	AuditLog []AuditLog `json:"audit_log"`
+}
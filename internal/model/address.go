@@ -0,0 +1,15 @@
+package model
+
+import "time"
+
+type Address struct {
+	ID         int32     `json:"id"`
+	UserID     string    `json:"user_id"`
+	Street     string    `json:"street"`
+	City       string    `json:"city"`
+	Province   string    `json:"province"`
+	PostalCode string    `json:"postal_code"`
+	Notes      string    `json:"notes"`
+	IsPrimary  bool      `json:"is_primary"`
+	CreatedAt  time.Time `json:"created_at"`
+}
@@ -4,22 +4,25 @@ package model
 import "time"
 
 type AuthUser struct {
-	ID           string    `json:"id"`
-	Email        string    `json:"email"`
-	PasswordHash string    `json:"-"`
-	CreatedAt    time.Time `json:"created_at"`
-	UpdatedAt    time.Time `json:"updated_at"`
-	ConfirmedAt  time.Time `json:"confirmed_at"`
-	LastSignInAt time.Time `json:"last_sign_in_at"`
+	ID           string     `json:"id"`
+	Email        string     `json:"email"`
+	PasswordHash string     `json:"-"`
+	CreatedAt    time.Time  `json:"created_at"`
+	UpdatedAt    time.Time  `json:"updated_at"`
+	ConfirmedAt  time.Time  `json:"confirmed_at"`
+	LastSignInAt time.Time  `json:"last_sign_in_at"`
+	SuspendedAt  *time.Time `json:"suspended_at,omitempty"`
 }
 
 type RefreshToken struct {
-	ID        string    `json:"id"`
-	UserID    string    `json:"user_id"`
-	TokenHash string    `json:"token_hash"`
-	ExpiresAt time.Time `json:"expires_at"`
-	CreatedAt time.Time `json:"created_at"`
-	Revoked   bool      `json:"revoked"`
+	ID        string     `json:"id"`
+	UserID    string     `json:"user_id"`
+	FamilyID  string     `json:"family_id"`
+	TokenHash string     `json:"token_hash"`
+	ExpiresAt time.Time  `json:"expires_at"`
+	CreatedAt time.Time  `json:"created_at"`
+	RotatedAt *time.Time `json:"rotated_at,omitempty"`
+	Revoked   bool       `json:"revoked"`
 }
 
 type AuditLog struct {
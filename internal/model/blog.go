@@ -0,0 +1,29 @@
+package model
+
+import "time"
+
+// BlogPost is an article of the public blog. Content is the Markdown source
+// and ContentHTML its sanitized rendering, made when the post is read. Status is draft, scheduled or
+// published; a scheduled post goes live at PublishedAt.
+type BlogPost struct {
+	ID          int32      `json:"id"`
+	Slug        string     `json:"slug"`
+	Title       string     `json:"title"`
+	Excerpt     string     `json:"excerpt,omitempty"`
+	Content     string     `json:"content,omitempty"`
+	ContentHTML string     `json:"content_html,omitempty"`
+	AuthorID    string     `json:"author_id,omitempty"`
+	Status      string     `json:"status"`
+	PublishedAt *time.Time `json:"published_at,omitempty"`
+	CreatedAt   time.Time  `json:"created_at"`
+	UpdatedAt   time.Time  `json:"updated_at"`
+}
+
+// BlogPostPage is one page of a post listing. Total counts every post
+// matching the listing, not only those on the page.
+type BlogPostPage struct {
+	Posts  []BlogPost `json:"posts"`
+	Total  int64      `json:"total"`
+	Limit  int32      `json:"limit"`
+	Offset int32      `json:"offset"`
+}
@@ -0,0 +1,22 @@
+package model
+
+import "time"
+
+type Complaint struct {
+	ID          int32           `json:"id"`
+	UserID      string          `json:"user_id"`
+	OrderID     int32           `json:"order_id"`
+	Description string          `json:"description"`
+	Status      string          `json:"status"`
+	CreatedAt   time.Time       `json:"created_at"`
+	ResolvedAt  *time.Time      `json:"resolved_at,omitempty"`
+	Notes       []ComplaintNote `json:"notes,omitempty"`
+}
+
+type ComplaintNote struct {
+	ID          int32     `json:"id"`
+	ComplaintID int32     `json:"complaint_id"`
+	AuthorID    string    `json:"author_id,omitempty"`
+	Note        string    `json:"note"`
+	CreatedAt   time.Time `json:"created_at"`
+}
@@ -0,0 +1,32 @@
+package model
+
+import "time"
+
+// Notification is an in-app notification in the customer's inbox.
+type Notification struct {
+	ID        int32      `json:"id"`
+	OrderID   *int32     `json:"order_id,omitempty"`
+	Message   string     `json:"message"`
+	CreatedAt time.Time  `json:"created_at"`
+	ReadAt    *time.Time `json:"read_at,omitempty"`
+}
+
+// NotificationPage is one page of the inbox. NextCursor is set when there
+// are older notifications.
+type NotificationPage struct {
+	Notifications []Notification `json:"notifications"`
+	NextCursor    *int32         `json:"next_cursor,omitempty"`
+}
+
+// PendingNotification is a notification claimed for delivery, with the
+// contact details of the customer it goes to.
+type PendingNotification struct {
+	ID          int32  `json:"id"`
+	UserID      string `json:"user_id"`
+	OrderID     *int32 `json:"order_id,omitempty"`
+	Message     string `json:"message"`
+	Channel     string `json:"channel"`
+	Attempts    int32  `json:"attempts"`
+	Email       string `json:"email,omitempty"`
+	PhoneNumber string `json:"phone_number,omitempty"`
+}
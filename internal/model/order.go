@@ -2,10 +2,71 @@ package model
 
 import "time"
 
+// OrderTransitions lists, for every order_status, the statuses it may move
+// to. delivered and cancelled have no targets, so they are terminal.
+var OrderTransitions = map[string][]string{
+	"pending":            {"processing", "cancelled"},
+	"processing":         {"cleaning", "cancelled"},
+	"cleaning":           {"ready_for_delivery"},
+	"ready_for_delivery": {"completed"},
+	"completed":          {"delivered"},
+	"delivered":          {},
+	"cancelled":          {},
+}
+
 type Order struct {
-	ID          int32     `json:"id"`
-	UserID      string    `json:"user_id"`
-	ServiceType string    `json:"service_type"`
-	Status      string    `json:"status"`
-	CreatedAt   time.Time `json:"created_at"`
+	ID          int32          `json:"id"`
+	UserID      string         `json:"user_id"`
+	AddressID   *int32         `json:"address_id"`
+	TotalPrice  float64        `json:"total_price"`
+	Status      string         `json:"status"`
+	IsExpress   bool           `json:"is_express"`
+	ExpressFee  float64        `json:"express_fee"`
+	PromoCode   string         `json:"promo_code,omitempty"`
+	CreatedAt   time.Time      `json:"created_at"`
+	CompletedAt *time.Time     `json:"completed_at"`
+	PromisedBy  *time.Time     `json:"promised_by"`
+	Services    []OrderService `json:"services,omitempty"`
+}
+
+type OrderService struct {
+	ID            int32   `json:"id"`
+	ServiceTypeID int32   `json:"service_type_id"`
+	ServiceName   string  `json:"service_name"`
+	Quantity      int32   `json:"quantity"`
+	Price         float64 `json:"price"`
+}
+
+type OrderStatusHistory struct {
+	ID        int32     `json:"id"`
+	OrderID   int32     `json:"order_id"`
+	Status    string    `json:"status"`
+	UpdatedBy string    `json:"updated_by,omitempty"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// OrderEvent is a status change pushed to order tracking streams. Final is
+// set once the order can't change anymore.
+type OrderEvent struct {
+	OrderID int32     `json:"order_id"`
+	Status  string    `json:"status"`
+	Final   bool      `json:"final"`
+	At      time.Time `json:"at"`
+}
+
+// OrderQuote is the price breakdown of an order before it is placed.
+type OrderQuote struct {
+	Subtotal   float64   `json:"subtotal"`
+	ExpressFee float64   `json:"express_fee"`
+	PromoCode  string    `json:"promo_code,omitempty"`
+	Discount   float64   `json:"discount"`
+	Total      float64   `json:"total"`
+	PromisedBy time.Time `json:"promised_by"`
+}
+
+// AtRiskOrder is an open order that is unlikely to make its promised_by
+// deadline from its current status.
+type AtRiskOrder struct {
+	Order
+	Breached bool `json:"breached"`
 }
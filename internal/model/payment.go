@@ -0,0 +1,26 @@
+package model
+
+import "time"
+
+type Payment struct {
+	ID      int32   `json:"id"`
+	OrderID int32   `json:"order_id"`
+	Method  string  `json:"method"`
+	Amount  float64 `json:"amount"`
+	// WalletAmount is the part of the order paid with wallet credit, on top
+	// of Amount charged through Method.
+	WalletAmount  float64    `json:"wallet_amount"`
+	TransactionID string     `json:"transaction_id,omitempty"`
+	Status        string     `json:"status"`
+	PaidAt        *time.Time `json:"paid_at"`
+}
+
+type Refund struct {
+	ID         int32     `json:"id"`
+	PaymentID  int32     `json:"payment_id"`
+	OrderID    int32     `json:"order_id"`
+	Amount     float64   `json:"amount"`
+	Reason     string    `json:"reason"`
+	RefundedBy string    `json:"refunded_by,omitempty"`
+	CreatedAt  time.Time `json:"created_at"`
+}
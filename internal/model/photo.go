@@ -0,0 +1,14 @@
+package model
+
+import "time"
+
+type Photo struct {
+	ID         int32     `json:"id"`
+	OrderID    int32     `json:"order_id"`
+	Type       string    `json:"type"`
+	URL        string    `json:"url"`
+	UploadedBy string    `json:"uploaded_by,omitempty"`
+	UploadedAt time.Time `json:"uploaded_at"`
+	// Key locates the image in the blob store (photo_evidences.photo_url).
+	Key string `json:"-"`
+}
@@ -0,0 +1,13 @@
+package model
+
+import "time"
+
+type Promo struct {
+	Code          string     `json:"code"`
+	DiscountType  string     `json:"discount_type"`
+	DiscountValue float64    `json:"discount_value"`
+	MaxUsage      *int32     `json:"max_usage"`
+	UsedCount     int32      `json:"used_count"`
+	ValidUntil    *time.Time `json:"valid_until"`
+	CreatedAt     time.Time  `json:"created_at"`
+}
@@ -0,0 +1,25 @@
+package model
+
+import "time"
+
+type Referral struct {
+	ID             int32      `json:"id"`
+	ReferrerID     string     `json:"referrer_id"`
+	ReferredEmail  string     `json:"referred_email"`
+	ReferredID     string     `json:"referred_id,omitempty"`
+	IsCompleted    bool       `json:"is_completed"`
+	CashbackAmount float64    `json:"cashback_amount"`
+	CreatedAt      time.Time  `json:"created_at"`
+	CompletedAt    *time.Time `json:"completed_at,omitempty"`
+}
+
+// ReferralSummary is what a user sees of the referral program: their
+// invite code and the people who signed up with it.
+type ReferralSummary struct {
+	Code            string     `json:"code"`
+	Total           int        `json:"total"`
+	Completed       int        `json:"completed"`
+	EarnedCashback  float64    `json:"earned_cashback"`
+	PendingCashback float64    `json:"pending_cashback"`
+	Referrals       []Referral `json:"referrals"`
+}
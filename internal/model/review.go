@@ -0,0 +1,29 @@
+package model
+
+import "time"
+
+type Review struct {
+	ID        int32     `json:"id"`
+	UserID    string    `json:"user_id"`
+	OrderID   int32     `json:"order_id"`
+	Rating    int32     `json:"rating"`
+	Comment   string    `json:"comment,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// ServiceTypeRating aggregates the reviews of orders containing a service
+// type.
+type ServiceTypeRating struct {
+	ServiceTypeID  int32           `json:"service_type_id"`
+	Average        float64         `json:"average"`
+	Count          int32           `json:"count"`
+	Distribution   map[int32]int32 `json:"distribution"`
+	RecentComments []ReviewComment `json:"recent_comments"`
+}
+
+type ReviewComment struct {
+	Rating       int32     `json:"rating"`
+	Comment      string    `json:"comment"`
+	ReviewerName string    `json:"reviewer_name"`
+	CreatedAt    time.Time `json:"created_at"`
+}
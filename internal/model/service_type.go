@@ -0,0 +1,14 @@
+package model
+
+import "time"
+
+type ServiceType struct {
+	ID                     int32      `json:"id"`
+	Name                   string     `json:"name"`
+	Description            string     `json:"description"`
+	BasePrice              float64    `json:"base_price"`
+	EstimatedDurationHours *int32     `json:"estimated_duration_hours"`
+	IsEcoFriendly          bool       `json:"is_eco_friendly"`
+	CreatedAt              time.Time  `json:"created_at"`
+	ArchivedAt             *time.Time `json:"archived_at,omitempty"`
+}
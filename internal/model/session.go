@@ -0,0 +1,21 @@
+package model
+
+import "time"
+
+// Device describes the client a session was started or last used from.
+type Device struct {
+	Name      string `json:"device_name"`
+	UserAgent string `json:"user_agent"`
+	IPAddress string `json:"ip_address"`
+}
+
+// Session is a login on one device, backed by a refresh token family.
+// Current marks the session of the token the request was made with.
+type Session struct {
+	ID     string `json:"id"`
+	UserID string `json:"-"`
+	Device
+	CreatedAt  time.Time `json:"created_at"`
+	LastUsedAt time.Time `json:"last_used_at"`
+	Current    bool      `json:"current"`
+}
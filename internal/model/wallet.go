@@ -0,0 +1,22 @@
+package model
+
+import "time"
+
+type Wallet struct {
+	UserID  string  `json:"user_id"`
+	Balance float64 `json:"balance"`
+}
+
+// WalletEntry is one line of a wallet statement. Amount is positive for
+// credit added to the wallet and negative for credit spent; Balance is the
+// wallet balance right after the entry.
+type WalletEntry struct {
+	ID            int32     `json:"id"`
+	TransactionID int32     `json:"transaction_id"`
+	Kind          string    `json:"kind"`
+	OrderID       *int32    `json:"order_id,omitempty"`
+	Description   string    `json:"description"`
+	Amount        float64   `json:"amount"`
+	Balance       float64   `json:"balance"`
+	CreatedAt     time.Time `json:"created_at"`
+}
@@ -0,0 +1,118 @@
+package notification
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"mime"
+	"net"
+	"net/mail"
+	"net/smtp"
+	"strings"
+	"time"
+)
+
+// SMTPConfig points the email notifier at an SMTP server. Username may be
+// left empty for servers that don't authenticate, such as a local fake
+// server in development and tests.
+type SMTPConfig struct {
+	Host     string
+	Port     string
+	Username string
+	Password string
+	From     string
+}
+
+// emailNotifier sends plain text mail over SMTP, upgrading to TLS when the
+// server offers STARTTLS.
+type emailNotifier struct {
+	cfg  SMTPConfig
+	auth smtp.Auth
+}
+
+func NewEmailNotifier(cfg SMTPConfig) Notifier {
+	n := &emailNotifier{cfg: cfg}
+	if cfg.Username != "" {
+		n.auth = smtp.PlainAuth("", cfg.Username, cfg.Password, cfg.Host)
+	}
+	return n
+}
+
+func (n *emailNotifier) Channel() string {
+	return ChannelEmail
+}
+
+func (n *emailNotifier) Send(ctx context.Context, m Message) error {
+	if m.To == "" {
+		return ErrNoRecipient
+	}
+	to, err := mail.ParseAddress(m.To)
+	if err != nil {
+		return &PermanentError{Err: fmt.Errorf("invalid recipient: %w", err)}
+	}
+	from, err := mail.ParseAddress(n.cfg.From)
+	if err != nil {
+		return &PermanentError{Err: fmt.Errorf("invalid sender: %w", err)}
+	}
+
+	var d net.Dialer
+	conn, err := d.DialContext(ctx, "tcp", net.JoinHostPort(n.cfg.Host, n.cfg.Port))
+	if err != nil {
+		return err
+	}
+	if deadline, ok := ctx.Deadline(); ok {
+		_ = conn.SetDeadline(deadline)
+	}
+	c, err := smtp.NewClient(conn, n.cfg.Host)
+	if err != nil {
+		conn.Close()
+		return err
+	}
+	defer c.Close()
+
+	if ok, _ := c.Extension("STARTTLS"); ok {
+		if err := c.StartTLS(&tls.Config{ServerName: n.cfg.Host}); err != nil {
+			return err
+		}
+	}
+	if n.auth != nil {
+		if err := c.Auth(n.auth); err != nil {
+			return err
+		}
+	}
+	if err := c.Mail(from.Address); err != nil {
+		return err
+	}
+	if err := c.Rcpt(to.Address); err != nil {
+		return err
+	}
+	w, err := c.Data()
+	if err != nil {
+		return err
+	}
+	if _, err := w.Write(n.compose(from, to, m)); err != nil {
+		return err
+	}
+	if err := w.Close(); err != nil {
+		return err
+	}
+	return c.Quit()
+}
+
+// compose builds the RFC 5322 message. The subject is Q-encoded, which also
+// keeps line breaks in it from injecting headers.
+func (n *emailNotifier) compose(from, to *mail.Address, m Message) []byte {
+	var b strings.Builder
+	b.WriteString("From: " + from.String() + "\r\n")
+	b.WriteString("To: " + to.String() + "\r\n")
+	b.WriteString("Subject: " + mime.QEncoding.Encode("utf-8", m.Subject) + "\r\n")
+	b.WriteString("Date: " + time.Now().Format(time.RFC1123Z) + "\r\n")
+	b.WriteString("MIME-Version: 1.0\r\n")
+	b.WriteString("Content-Type: text/plain; charset=utf-8\r\n")
+	b.WriteString("Content-Transfer-Encoding: 8bit\r\n")
+	b.WriteString("\r\n")
+	body := strings.ReplaceAll(m.Body, "\r\n", "\n")
+	b.WriteString(strings.ReplaceAll(body, "\n", "\r\n"))
+	b.WriteString("\r\n")
+	return []byte(b.String())
+}
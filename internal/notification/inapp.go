@@ -0,0 +1,19 @@
+package notification
+
+import "context"
+
+// inAppNotifier handles in-app notifications. The notifications row is the
+// notification itself, so there is nothing to deliver.
+type inAppNotifier struct{}
+
+func NewInAppNotifier() Notifier {
+	return inAppNotifier{}
+}
+
+func (inAppNotifier) Channel() string {
+	return ChannelInApp
+}
+
+func (inAppNotifier) Send(_ context.Context, _ Message) error {
+	return nil
+}
@@ -0,0 +1,87 @@
+// Package notification defines the drivers that deliver customer
+// notifications, one per notification_channel value.
+package notification
+
+import (
+	"context"
+	"errors"
+	"sort"
+)
+
+// notification_channel values
+const (
+	ChannelEmail    = "email"
+	ChannelWhatsApp = "whatsapp"
+	ChannelInApp    = "in_app"
+)
+
+// ErrNoRecipient is returned when the customer has no address for the
+// channel, such as a WhatsApp message to a user without a phone number.
+var ErrNoRecipient = errors.New("notification has no recipient")
+
+// Message is a notification ready to be delivered. To is the email address
+// or phone number for the channel and is empty for in-app notifications.
+type Message struct {
+	ID      int32
+	UserID  string
+	To      string
+	Subject string
+	Body    string
+}
+
+// Notifier delivers messages through a single channel.
+type Notifier interface {
+	// Channel returns the notification_channel value handled by the notifier.
+	Channel() string
+	Send(ctx context.Context, m Message) error
+}
+
+// PermanentError marks a delivery failure that retrying won't fix.
+type PermanentError struct {
+	Err error
+}
+
+func (e *PermanentError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *PermanentError) Unwrap() error {
+	return e.Err
+}
+
+// IsPermanent reports whether err should fail the notification right away
+// instead of being retried.
+func IsPermanent(err error) bool {
+	var pe *PermanentError
+	return errors.As(err, &pe) || errors.Is(err, ErrNoRecipient)
+}
+
+// Registry looks up the notifier for a channel.
+type Registry struct {
+	notifiers map[string]Notifier
+}
+
+// NewRegistry registers the given notifiers. A later notifier for the same
+// channel replaces an earlier one.
+func NewRegistry(notifiers ...Notifier) *Registry {
+	r := &Registry{notifiers: make(map[string]Notifier, len(notifiers))}
+	for _, n := range notifiers {
+		r.notifiers[n.Channel()] = n
+	}
+	return r
+}
+
+func (r *Registry) Get(channel string) (Notifier, bool) {
+	n, ok := r.notifiers[channel]
+	return n, ok
+}
+
+// Channels returns the enabled channels, sorted.
+func (r *Registry) Channels() []string {
+	channels := make([]string, 0, len(r.notifiers))
+	for c := range r.notifiers {
+		channels = append(channels, c)
+	}
+	sort.Strings(channels)
+	return channels
+}
@@ -0,0 +1,90 @@
+package notification
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+)
+
+// WhatsAppProvider sends a text message to a phone number through a
+// WhatsApp Business API provider.
+type WhatsAppProvider interface {
+	SendText(ctx context.Context, phone, text string) error
+}
+
+// whatsAppNotifier delivers messages through a WhatsAppProvider.
+type whatsAppNotifier struct {
+	provider WhatsAppProvider
+}
+
+func NewWhatsAppNotifier(provider WhatsAppProvider) Notifier {
+	return &whatsAppNotifier{provider: provider}
+}
+
+func (n *whatsAppNotifier) Channel() string {
+	return ChannelWhatsApp
+}
+
+func (n *whatsAppNotifier) Send(ctx context.Context, m Message) error {
+	if m.To == "" {
+		return ErrNoRecipient
+	}
+	return n.provider.SendText(ctx, m.To, m.Body)
+}
+
+// httpWhatsAppProvider posts {"to": ..., "text": ...} as JSON to URL with a
+// bearer token. A 4xx answer other than 429 means the request itself is
+// wrong and is not retried.
+type httpWhatsAppProvider struct {
+	url    string
+	token  string
+	client *http.Client
+}
+
+func NewHTTPWhatsAppProvider(url, token string) WhatsAppProvider {
+	return &httpWhatsAppProvider{
+		url:    url,
+		token:  token,
+		client: &http.Client{Timeout: 10 * time.Second},
+	}
+}
+
+type whatsAppTextRequest struct {
+	To   string `json:"to"`
+	Text string `json:"text"`
+}
+
+func (p *httpWhatsAppProvider) SendText(ctx context.Context, phone, text string) error {
+	body, err := json.Marshal(whatsAppTextRequest{To: phone, Text: text})
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, p.url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if p.token != "" {
+		req.Header.Set("Authorization", "Bearer "+p.token)
+	}
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		return nil
+	}
+
+	msg, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+	err = fmt.Errorf("whatsapp provider answered %d: %s", resp.StatusCode, bytes.TrimSpace(msg))
+	if resp.StatusCode >= 400 && resp.StatusCode < 500 && resp.StatusCode != http.StatusTooManyRequests {
+		return &PermanentError{Err: err}
+	}
+	return err
+}
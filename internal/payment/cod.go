@@ -0,0 +1,26 @@
+package payment
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+)
+
+// codGateway handles cash on delivery. Nothing is charged up front: the
+// order is accepted right away and the courier collects cash on delivery.
+type codGateway struct{}
+
+func NewCODGateway() PaymentGateway {
+	return codGateway{}
+}
+
+func (codGateway) Method() string {
+	return MethodCOD
+}
+
+func (codGateway) Charge(_ context.Context, _ Charge) (Result, error) {
+	return Result{
+		TransactionID: "cod_" + uuid.NewString(),
+		Status:        StatusSuccess,
+	}, nil
+}
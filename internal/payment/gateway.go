@@ -0,0 +1,78 @@
+// Package payment defines the gateways used to charge orders, one per
+// payment_method value.
+package payment
+
+import (
+	"context"
+	"sort"
+)
+
+// payment_method values
+const (
+	MethodDANA         = "DANA"
+	MethodOVO          = "OVO"
+	MethodCOD          = "COD"
+	MethodBankTransfer = "Bank_Transfer"
+	MethodQRIS         = "QRIS"
+)
+
+// payment_status values a gateway may report
+const (
+	StatusPending = "pending"
+	StatusSuccess = "success"
+	StatusFailed  = "failed"
+)
+
+// Charge is what a gateway is asked to collect for an order. PaymentID is
+// the pending payment row reserved for the charge; gateways pass it to the
+// provider as the merchant reference.
+type Charge struct {
+	PaymentID int32
+	OrderID   int32
+	UserID    string
+	Amount    float64
+}
+
+// Result is the gateway's answer to a Charge. Status is pending when the
+// provider settles asynchronously.
+type Result struct {
+	TransactionID string
+	Status        string
+}
+
+// PaymentGateway charges an order through a single payment method.
+type PaymentGateway interface {
+	// Method returns the payment_method value handled by the gateway.
+	Method() string
+	Charge(ctx context.Context, c Charge) (Result, error)
+}
+
+// Registry looks up the gateway for a payment method.
+type Registry struct {
+	gateways map[string]PaymentGateway
+}
+
+// NewRegistry registers the given gateways. A later gateway for the same
+// method replaces an earlier one.
+func NewRegistry(gateways ...PaymentGateway) *Registry {
+	r := &Registry{gateways: make(map[string]PaymentGateway, len(gateways))}
+	for _, g := range gateways {
+		r.gateways[g.Method()] = g
+	}
+	return r
+}
+
+func (r *Registry) Get(method string) (PaymentGateway, bool) {
+	g, ok := r.gateways[method]
+	return g, ok
+}
+
+// Methods returns the enabled payment methods, sorted.
+func (r *Registry) Methods() []string {
+	methods := make([]string, 0, len(r.gateways))
+	for m := range r.gateways {
+		methods = append(methods, m)
+	}
+	sort.Strings(methods)
+	return methods
+}
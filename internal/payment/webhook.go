@@ -0,0 +1,47 @@
+package payment
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"strings"
+)
+
+// SignatureHeader carries the hex HMAC-SHA256 of the raw webhook body.
+const SignatureHeader = "X-Signature"
+
+// WebhookEvent is the body providers post to /webhooks/payments/:provider.
+type WebhookEvent struct {
+	TransactionID string `json:"transaction_id"`
+	Status        string `json:"status"`
+}
+
+// Sign returns the signature a provider sends for body. It doubles as the
+// local signing stub when exercising the webhook without a real provider.
+func Sign(secret, body []byte) string {
+	mac := hmac.New(sha256.New, secret)
+	mac.Write(body)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// VerifySignature checks signature against body in constant time.
+func VerifySignature(secret, body []byte, signature string) bool {
+	got, err := hex.DecodeString(signature)
+	if err != nil || len(secret) == 0 {
+		return false
+	}
+	mac := hmac.New(sha256.New, secret)
+	mac.Write(body)
+	return hmac.Equal(got, mac.Sum(nil))
+}
+
+// MethodForProvider maps a webhook :provider path segment such as "dana" or
+// "bank_transfer" to its payment_method value.
+func MethodForProvider(provider string) (string, bool) {
+	for _, m := range []string{MethodDANA, MethodOVO, MethodBankTransfer, MethodQRIS} {
+		if strings.EqualFold(provider, m) {
+			return m, true
+		}
+	}
+	return "", false
+}
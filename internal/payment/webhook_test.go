@@ -0,0 +1,52 @@
+package payment
+
+import "testing"
+
+func TestVerifySignature(t *testing.T) {
+	secret := []byte("webhook-secret")
+	body := []byte(`{"transaction_id":"trx-1","status":"success"}`)
+
+	tests := []struct {
+		name      string
+		secret    []byte
+		body      []byte
+		signature string
+		want      bool
+	}{
+		{"valid", secret, body, Sign(secret, body), true},
+		{"tampered body", secret, []byte(`{"transaction_id":"trx-1","status":"failed"}`), Sign(secret, body), false},
+		{"other secret", secret, body, Sign([]byte("other-secret"), body), false},
+		{"empty secret", nil, body, Sign(nil, body), false},
+		{"empty signature", secret, body, "", false},
+		{"not hex", secret, body, "not-a-signature", false},
+		{"truncated", secret, body, Sign(secret, body)[:32], false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := VerifySignature(tt.secret, tt.body, tt.signature); got != tt.want {
+				t.Errorf("VerifySignature() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMethodForProvider(t *testing.T) {
+	tests := []struct {
+		provider string
+		want     string
+		ok       bool
+	}{
+		{"dana", MethodDANA, true},
+		{"bank_transfer", MethodBankTransfer, true},
+		{"QRIS", MethodQRIS, true},
+		// COD has no provider to call back
+		{"cod", "", false},
+		{"paypal", "", false},
+	}
+	for _, tt := range tests {
+		got, ok := MethodForProvider(tt.provider)
+		if got != tt.want || ok != tt.ok {
+			t.Errorf("MethodForProvider(%q) = %q, %v, want %q, %v", tt.provider, got, ok, tt.want, tt.ok)
+		}
+	}
+}
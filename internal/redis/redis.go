@@ -34,6 +34,25 @@ func (rc *RedisClient) GetClient() *redis.Client {
 	return rc.client
 }
 
+// incrIfExists adjusts a counter only while the key exists, so a counter
+// that expired is rebuilt from its source instead of restarting at zero.
+var incrIfExists = redis.NewScript(`
+if redis.call('EXISTS', KEYS[1]) == 1 then
+  return redis.call('INCRBY', KEYS[1], ARGV[1])
+end
+return false
+`)
+
+// IncrIfExists adds delta to the counter at key when it exists and leaves a
+// missing key missing.
+func (rc *RedisClient) IncrIfExists(ctx context.Context, key string, delta int64) error {
+	err := incrIfExists.Run(ctx, rc.client, []string{key}, delta).Err()
+	if err == redis.Nil {
+		return nil
+	}
+	return err
+}
+
 func (rc *RedisClient) Close() error {
 	return rc.client.Close()
 }
@@ -0,0 +1,258 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/AndikaPrasetia/wash-shoe/internal/model"
+	"github.com/AndikaPrasetia/wash-shoe/internal/sqlc/account"
+	"github.com/AndikaPrasetia/wash-shoe/internal/sqlc/user"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// likeEscaper escapes the ILIKE wildcards in a search term so it is matched
+// literally.
+var likeEscaper = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+
+type AccountRepo interface {
+	List(ctx context.Context, filter model.AccountFilter, limit, offset int32) ([]model.Account, int64, error)
+	FindByID(ctx context.Context, id string) (*model.Account, error)
+	// SuspendedAt returns when the account was suspended, or nil when it
+	// is active.
+	SuspendedAt(ctx context.Context, id string) (*time.Time, error)
+	// ListAuditLogs returns the latest entries made by the user or about
+	// them, newest first.
+	ListAuditLogs(ctx context.Context, id string, limit int32) ([]model.AuditLog, error)
+	// UpdateRole changes the user's role through UpdateUserRole and records
+	// the change in the audit log.
+	UpdateRole(ctx context.Context, id, role, actorID string) (model.Account, error)
+	// SetSuspended suspends or reinstates the account and records it in the
+	// audit log. It reports false when the account already was in that
+	// state.
+	SetSuspended(ctx context.Context, id string, suspended bool, reason, actorID string) (model.Account, bool, error)
+}
+
+type accountRepo struct {
+	db *pgxpool.Pool
+	q  *account.Queries
+}
+
+func NewAccountRepo(db *pgxpool.Pool) AccountRepo {
+	return &accountRepo{db: db, q: account.New(db)}
+}
+
+func (r *accountRepo) List(ctx context.Context, filter model.AccountFilter, limit, offset int32) ([]model.Account, int64, error) {
+	var suspended pgtype.Bool
+	if filter.Suspended != nil {
+		suspended = pgtype.Bool{Bool: *filter.Suspended, Valid: true}
+	}
+	search := textFromString(likeEscaper.Replace(filter.Search))
+
+	rows, err := r.q.ListAccounts(ctx, account.ListAccountsParams{
+		Search:    search,
+		Role:      textFromString(filter.Role),
+		Provider:  textFromString(filter.Provider),
+		Suspended: suspended,
+		RowLimit:  limit,
+		RowOffset: offset,
+	})
+	if err != nil {
+		return nil, 0, err
+	}
+	total, err := r.q.CountAccounts(ctx, account.CountAccountsParams{
+		Search:    search,
+		Role:      textFromString(filter.Role),
+		Provider:  textFromString(filter.Provider),
+		Suspended: suspended,
+	})
+	if err != nil {
+		return nil, 0, err
+	}
+
+	res := make([]model.Account, len(rows))
+	for i, row := range rows {
+		res[i] = toAccountModel(account.GetAccountRow(row))
+	}
+	return res, total, nil
+}
+
+func (r *accountRepo) FindByID(ctx context.Context, id string) (*model.Account, error) {
+	uid, err := pgUUID(id)
+	if err != nil {
+		return nil, err
+	}
+	row, err := r.q.GetAccount(ctx, uid)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrUserNotFound
+		}
+		return nil, err
+	}
+	res := toAccountModel(row)
+	return &res, nil
+}
+
+func (r *accountRepo) SuspendedAt(ctx context.Context, id string) (*time.Time, error) {
+	uid, err := pgUUID(id)
+	if err != nil {
+		return nil, err
+	}
+	at, err := r.q.GetAccountSuspendedAt(ctx, uid)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrUserNotFound
+		}
+		return nil, err
+	}
+	return timePtr(at), nil
+}
+
+func (r *accountRepo) ListAuditLogs(ctx context.Context, id string, limit int32) ([]model.AuditLog, error) {
+	uid, err := pgUUID(id)
+	if err != nil {
+		return nil, err
+	}
+	logs, err := r.q.ListAccountAuditLogs(ctx, account.ListAccountAuditLogsParams{
+		UserID:   uid,
+		RowLimit: limit,
+	})
+	if err != nil {
+		return nil, err
+	}
+	res := make([]model.AuditLog, len(logs))
+	for i, al := range logs {
+		res[i] = model.AuditLog{
+			ID:        al.ID,
+			ActorID:   uuidString(al.ActorID),
+			Action:    al.Action,
+			Details:   string(al.Details),
+			CreatedAt: al.CreatedAt.Time,
+		}
+	}
+	return res, nil
+}
+
+func (r *accountRepo) UpdateRole(ctx context.Context, id, role, actorID string) (model.Account, error) {
+	uid, err := pgUUID(id)
+	if err != nil {
+		return model.Account{}, err
+	}
+
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return model.Account{}, err
+	}
+	defer tx.Rollback(ctx)
+	q := r.q.WithTx(tx)
+	uq := user.New(tx)
+
+	before, err := q.GetAccount(ctx, uid)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return model.Account{}, ErrUserNotFound
+		}
+		return model.Account{}, err
+	}
+	if before.Role == role {
+		return toAccountModel(before), nil
+	}
+
+	if err := uq.UpdateUserRole(ctx, user.UpdateUserRoleParams{Role: role, ID: uid}); err != nil {
+		return model.Account{}, err
+	}
+	err = recordAccountChange(ctx, uq, actorID, "user_role_change", map[string]any{
+		"user_id": id,
+		"from":    before.Role,
+		"to":      role,
+	})
+	if err != nil {
+		return model.Account{}, err
+	}
+
+	after, err := q.GetAccount(ctx, uid)
+	if err != nil {
+		return model.Account{}, err
+	}
+	return toAccountModel(after), tx.Commit(ctx)
+}
+
+func (r *accountRepo) SetSuspended(ctx context.Context, id string, suspended bool, reason, actorID string) (model.Account, bool, error) {
+	uid, err := pgUUID(id)
+	if err != nil {
+		return model.Account{}, false, err
+	}
+
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return model.Account{}, false, err
+	}
+	defer tx.Rollback(ctx)
+	q := r.q.WithTx(tx)
+
+	var n int64
+	action := "user_unsuspend"
+	if suspended {
+		action = "user_suspend"
+		n, err = q.SuspendAccount(ctx, uid)
+	} else {
+		n, err = q.UnsuspendAccount(ctx, uid)
+	}
+	if err != nil {
+		return model.Account{}, false, err
+	}
+
+	if n > 0 {
+		details := map[string]any{"user_id": id}
+		if reason != "" {
+			details["reason"] = reason
+		}
+		if err := recordAccountChange(ctx, user.New(tx), actorID, action, details); err != nil {
+			return model.Account{}, false, err
+		}
+	}
+
+	row, err := q.GetAccount(ctx, uid)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return model.Account{}, false, ErrUserNotFound
+		}
+		return model.Account{}, false, err
+	}
+	return toAccountModel(row), n > 0, tx.Commit(ctx)
+}
+
+func recordAccountChange(ctx context.Context, q *user.Queries, actorID, action string, details map[string]any) error {
+	actor, err := pgUUID(actorID)
+	if err != nil {
+		return err
+	}
+	b, err := json.Marshal(details)
+	if err != nil {
+		return err
+	}
+	_, err = q.CreateAuditLog(ctx, user.CreateAuditLogParams{
+		ActorID: actor,
+		Action:  action,
+		Details: b,
+	})
+	return err
+}
+
+func toAccountModel(row account.GetAccountRow) model.Account {
+	return model.Account{
+		ID:           uuidString(row.ID),
+		Email:        row.Email,
+		FullName:     row.FullName,
+		PhoneNumber:  row.PhoneNumber.String,
+		Provider:     row.Provider.String,
+		Role:         row.Role,
+		CreatedAt:    row.CreatedAt.Time,
+		LastSignInAt: timePtr(row.LastSignInAt),
+		SuspendedAt:  timePtr(row.SuspendedAt),
+	}
+}
@@ -0,0 +1,203 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"github.com/AndikaPrasetia/wash-shoe/internal/model"
+	"github.com/AndikaPrasetia/wash-shoe/internal/sqlc/address"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+var (
+	ErrAddressNotFound = errors.New("address not found")
+	ErrAddressInUse    = errors.New("address is used by an open order")
+)
+
+type AddressRepo interface {
+	Create(ctx context.Context, a model.Address) (model.Address, error)
+	ListByUser(ctx context.Context, userID string) ([]model.Address, error)
+	FindByID(ctx context.Context, userID string, id int32) (*model.Address, error)
+	Update(ctx context.Context, a model.Address) (model.Address, error)
+	SetPrimary(ctx context.Context, userID string, id int32) error
+	Delete(ctx context.Context, userID string, id int32) error
+}
+
+type addressRepo struct {
+	db *pgxpool.Pool
+	q  *address.Queries
+}
+
+func NewAddressRepo(db *pgxpool.Pool) AddressRepo {
+	return &addressRepo{db: db, q: address.New(db)}
+}
+
+// Create inserts the address; when it is primary the flag is moved to it
+// in the same transaction.
+func (r *addressRepo) Create(ctx context.Context, a model.Address) (model.Address, error) {
+	userID, err := pgUUID(a.UserID)
+	if err != nil {
+		return model.Address{}, err
+	}
+
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return model.Address{}, err
+	}
+	defer tx.Rollback(ctx)
+	q := r.q.WithTx(tx)
+
+	created, err := q.CreateAddress(ctx, address.CreateAddressParams{
+		UserID:     userID,
+		Street:     a.Street,
+		City:       a.City,
+		Province:   a.Province,
+		PostalCode: textFromString(a.PostalCode),
+		Notes:      textFromString(a.Notes),
+	})
+	if err != nil {
+		return model.Address{}, err
+	}
+	if a.IsPrimary {
+		if _, err := q.SetPrimaryAddress(ctx, address.SetPrimaryAddressParams{
+			ID:     created.ID,
+			UserID: userID,
+		}); err != nil {
+			return model.Address{}, err
+		}
+		created.IsPrimary.Bool, created.IsPrimary.Valid = true, true
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return model.Address{}, err
+	}
+	return toAddressModel(created), nil
+}
+
+func (r *addressRepo) ListByUser(ctx context.Context, userID string) ([]model.Address, error) {
+	uid, err := pgUUID(userID)
+	if err != nil {
+		return nil, err
+	}
+	as, err := r.q.ListAddressesByUser(ctx, uid)
+	if err != nil {
+		return nil, err
+	}
+	res := make([]model.Address, len(as))
+	for i, a := range as {
+		res[i] = toAddressModel(a)
+	}
+	return res, nil
+}
+
+func (r *addressRepo) FindByID(ctx context.Context, userID string, id int32) (*model.Address, error) {
+	uid, err := pgUUID(userID)
+	if err != nil {
+		return nil, err
+	}
+	a, err := r.q.GetAddressByID(ctx, address.GetAddressByIDParams{ID: id, UserID: uid})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrAddressNotFound
+		}
+		return nil, err
+	}
+	res := toAddressModel(a)
+	return &res, nil
+}
+
+// Update replaces the address fields. Setting IsPrimary moves the flag to
+// this address; clearing it is done by marking another address primary.
+func (r *addressRepo) Update(ctx context.Context, a model.Address) (model.Address, error) {
+	userID, err := pgUUID(a.UserID)
+	if err != nil {
+		return model.Address{}, err
+	}
+
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return model.Address{}, err
+	}
+	defer tx.Rollback(ctx)
+	q := r.q.WithTx(tx)
+
+	updated, err := q.UpdateAddress(ctx, address.UpdateAddressParams{
+		ID:         a.ID,
+		UserID:     userID,
+		Street:     a.Street,
+		City:       a.City,
+		Province:   a.Province,
+		PostalCode: textFromString(a.PostalCode),
+		Notes:      textFromString(a.Notes),
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return model.Address{}, ErrAddressNotFound
+		}
+		return model.Address{}, err
+	}
+	if a.IsPrimary && !updated.IsPrimary.Bool {
+		if _, err := q.SetPrimaryAddress(ctx, address.SetPrimaryAddressParams{
+			ID:     updated.ID,
+			UserID: userID,
+		}); err != nil {
+			return model.Address{}, err
+		}
+		updated.IsPrimary.Bool, updated.IsPrimary.Valid = true, true
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return model.Address{}, err
+	}
+	return toAddressModel(updated), nil
+}
+
+func (r *addressRepo) SetPrimary(ctx context.Context, userID string, id int32) error {
+	uid, err := pgUUID(userID)
+	if err != nil {
+		return err
+	}
+	n, err := r.q.SetPrimaryAddress(ctx, address.SetPrimaryAddressParams{ID: id, UserID: uid})
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return ErrAddressNotFound
+	}
+	return nil
+}
+
+func (r *addressRepo) Delete(ctx context.Context, userID string, id int32) error {
+	uid, err := pgUUID(userID)
+	if err != nil {
+		return err
+	}
+	n, err := r.q.DeleteUnusedAddress(ctx, address.DeleteUnusedAddressParams{ID: id, UserID: uid})
+	if err != nil {
+		return err
+	}
+	if n > 0 {
+		return nil
+	}
+
+	// nothing deleted: either the address doesn't exist or it is still in use
+	if _, err := r.FindByID(ctx, userID, id); err != nil {
+		return err
+	}
+	return ErrAddressInUse
+}
+
+func toAddressModel(a address.Address) model.Address {
+	return model.Address{
+		ID:         a.ID,
+		UserID:     uuidString(a.UserID),
+		Street:     a.Street,
+		City:       a.City,
+		Province:   a.Province,
+		PostalCode: a.PostalCode.String,
+		Notes:      a.Notes.String,
+		IsPrimary:  a.IsPrimary.Bool,
+		CreatedAt:  a.CreatedAt.Time,
+	}
+}
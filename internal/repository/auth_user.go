@@ -21,6 +21,8 @@ type AuthUserRepo interface {
 	Delete(ctx context.Context, id pgtype.UUID) error
 	CreateRefreshToken(ctx context.Context, arg user.CreateRefreshTokenParams) (model.RefreshToken, error)
 	GetAuthUserByEmail(ctx context.Context, email string) (*model.AuthUser, error)
+	GetAuthUserByID(ctx context.Context, id pgtype.UUID) (*model.AuthUser, error)
+	UpdatePassword(ctx context.Context, id pgtype.UUID, passwordHash string) error
 	RevokeRefreshToken(ctx context.Context, id pgtype.UUID) error
 	RevokeAllTokens(ctx context.Context, userID pgtype.UUID) error
 	GetRefreshTokenByHash(ctx context.Context, tokenHash string) (*model.RefreshToken, error)
@@ -89,9 +91,38 @@ func (r *authUserRepo) GetAuthUserByEmail(ctx context.Context, email string) (*m
 		UpdatedAt:    time.Now(),
 		ConfirmedAt:  time.Now(),
 		LastSignInAt: time.Now(),
+		SuspendedAt:  timePtr(u.SuspendedAt),
 	}, nil
 }
 
+func (r *authUserRepo) GetAuthUserByID(ctx context.Context, id pgtype.UUID) (*model.AuthUser, error) {
+	u, err := r.q.GetAuthUserByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, sqlErrNoRows) {
+			return nil, ErrUserNotFound
+		}
+		return nil, err
+	}
+
+	return &model.AuthUser{
+		ID:           u.ID.String(),
+		Email:        u.Email,
+		PasswordHash: u.PasswordHash.String,
+		CreatedAt:    u.CreatedAt.Time,
+		UpdatedAt:    u.UpdatedAt.Time,
+		ConfirmedAt:  u.ConfirmedAt.Time,
+		LastSignInAt: u.LastSignInAt.Time,
+		SuspendedAt:  timePtr(u.SuspendedAt),
+	}, nil
+}
+
+func (r *authUserRepo) UpdatePassword(ctx context.Context, id pgtype.UUID, passwordHash string) error {
+	return r.q.UpdateAuthUserPassword(ctx, user.UpdateAuthUserPasswordParams{
+		ID:           id,
+		PasswordHash: pgtype.Text{String: passwordHash, Valid: true},
+	})
+}
+
 func (r *authUserRepo) CreateRefreshToken(ctx context.Context, arg user.CreateRefreshTokenParams) (model.RefreshToken, error) {
 	tkn, err := r.q.CreateRefreshToken(ctx, arg)
 	if err != nil {
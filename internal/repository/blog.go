@@ -0,0 +1,180 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/AndikaPrasetia/wash-shoe/internal/model"
+	"github.com/AndikaPrasetia/wash-shoe/internal/sqlc/blog"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+var (
+	ErrBlogPostNotFound = errors.New("blog post not found")
+	ErrBlogSlugTaken    = errors.New("blog post slug already exists")
+)
+
+type BlogRepo interface {
+	Create(ctx context.Context, p model.BlogPost) (model.BlogPost, error)
+	FindByID(ctx context.Context, id int32) (*model.BlogPost, error)
+	Update(ctx context.Context, p model.BlogPost) (model.BlogPost, error)
+	Publish(ctx context.Context, id int32, at time.Time) (model.BlogPost, error)
+	Unpublish(ctx context.Context, id int32) (model.BlogPost, error)
+	// List returns posts of any status, or only those with the given one,
+	// together with how many there are in total.
+	List(ctx context.Context, status string, limit, offset int32) ([]model.BlogPost, int64, error)
+	// ListPublished returns live posts newest first, together with how many
+	// there are in total.
+	ListPublished(ctx context.Context, limit, offset int32) ([]model.BlogPost, int64, error)
+	FindPublishedBySlug(ctx context.Context, slug string) (*model.BlogPost, error)
+}
+
+type blogRepo struct {
+	q blog.Querier
+}
+
+func NewBlogRepo(q blog.Querier) BlogRepo {
+	return &blogRepo{q: q}
+}
+
+func (r *blogRepo) Create(ctx context.Context, p model.BlogPost) (model.BlogPost, error) {
+	authorID, err := pgUUID(p.AuthorID)
+	if err != nil {
+		return model.BlogPost{}, err
+	}
+	created, err := r.q.CreateBlogPost(ctx, blog.CreateBlogPostParams{
+		Title:    p.Title,
+		Slug:     p.Slug,
+		Excerpt:  textFromString(p.Excerpt),
+		Content:  p.Content,
+		AuthorID: authorID,
+	})
+	if err != nil {
+		return model.BlogPost{}, mapBlogError(err)
+	}
+	return toBlogPostModel(created), nil
+}
+
+func (r *blogRepo) FindByID(ctx context.Context, id int32) (*model.BlogPost, error) {
+	p, err := r.q.GetBlogPostByID(ctx, id)
+	if err != nil {
+		return nil, mapBlogError(err)
+	}
+	res := toBlogPostModel(p)
+	return &res, nil
+}
+
+func (r *blogRepo) Update(ctx context.Context, p model.BlogPost) (model.BlogPost, error) {
+	updated, err := r.q.UpdateBlogPost(ctx, blog.UpdateBlogPostParams{
+		ID:      p.ID,
+		Title:   p.Title,
+		Slug:    p.Slug,
+		Excerpt: textFromString(p.Excerpt),
+		Content: p.Content,
+	})
+	if err != nil {
+		return model.BlogPost{}, mapBlogError(err)
+	}
+	return toBlogPostModel(updated), nil
+}
+
+func (r *blogRepo) Publish(ctx context.Context, id int32, at time.Time) (model.BlogPost, error) {
+	p, err := r.q.PublishBlogPost(ctx, blog.PublishBlogPostParams{
+		ID:          id,
+		PublishedAt: pgtype.Timestamptz{Time: at, Valid: true},
+	})
+	if err != nil {
+		return model.BlogPost{}, mapBlogError(err)
+	}
+	return toBlogPostModel(p), nil
+}
+
+func (r *blogRepo) Unpublish(ctx context.Context, id int32) (model.BlogPost, error) {
+	p, err := r.q.UnpublishBlogPost(ctx, id)
+	if err != nil {
+		return model.BlogPost{}, mapBlogError(err)
+	}
+	return toBlogPostModel(p), nil
+}
+
+func (r *blogRepo) List(ctx context.Context, status string, limit, offset int32) ([]model.BlogPost, int64, error) {
+	ps, err := r.q.ListBlogPosts(ctx, blog.ListBlogPostsParams{
+		Status:    textFromString(status),
+		RowLimit:  limit,
+		RowOffset: offset,
+	})
+	if err != nil {
+		return nil, 0, err
+	}
+	total, err := r.q.CountBlogPosts(ctx, textFromString(status))
+	if err != nil {
+		return nil, 0, err
+	}
+	return toBlogPostModels(ps), total, nil
+}
+
+func (r *blogRepo) ListPublished(ctx context.Context, limit, offset int32) ([]model.BlogPost, int64, error) {
+	ps, err := r.q.ListPublishedBlogPosts(ctx, blog.ListPublishedBlogPostsParams{
+		RowLimit:  limit,
+		RowOffset: offset,
+	})
+	if err != nil {
+		return nil, 0, err
+	}
+	total, err := r.q.CountPublishedBlogPosts(ctx)
+	if err != nil {
+		return nil, 0, err
+	}
+	return toBlogPostModels(ps), total, nil
+}
+
+func (r *blogRepo) FindPublishedBySlug(ctx context.Context, slug string) (*model.BlogPost, error) {
+	p, err := r.q.GetPublishedBlogPostBySlug(ctx, slug)
+	if err != nil {
+		return nil, mapBlogError(err)
+	}
+	res := toBlogPostModel(p)
+	return &res, nil
+}
+
+func mapBlogError(err error) error {
+	switch {
+	case errors.Is(err, pgx.ErrNoRows):
+		return ErrBlogPostNotFound
+	case isUniqueViolation(err):
+		return ErrBlogSlugTaken
+	}
+	return err
+}
+
+func toBlogPostModels(ps []blog.BlogPost) []model.BlogPost {
+	res := make([]model.BlogPost, len(ps))
+	for i, p := range ps {
+		res[i] = toBlogPostModel(p)
+	}
+	return res
+}
+
+func toBlogPostModel(p blog.BlogPost) model.BlogPost {
+	status := "draft"
+	if p.IsPublished.Bool && p.PublishedAt.Valid {
+		status = "published"
+		if p.PublishedAt.Time.After(time.Now()) {
+			status = "scheduled"
+		}
+	}
+	return model.BlogPost{
+		ID:          p.ID,
+		Slug:        p.Slug,
+		Title:       p.Title,
+		Excerpt:     p.Excerpt.String,
+		Content:     p.Content,
+		AuthorID:    uuidString(p.AuthorID),
+		Status:      status,
+		PublishedAt: timePtr(p.PublishedAt),
+		CreatedAt:   p.CreatedAt.Time,
+		UpdatedAt:   p.UpdatedAt.Time,
+	}
+}
@@ -0,0 +1,287 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/AndikaPrasetia/wash-shoe/internal/model"
+	"github.com/AndikaPrasetia/wash-shoe/internal/sqlc/complaint"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+var (
+	ErrComplaintNotFound      = errors.New("complaint not found")
+	ErrComplaintStatusChanged = errors.New("complaint status was changed concurrently")
+)
+
+// ComplaintRepo stores complaints. Every write is audited in auth.audit_log
+// and queues notice as an in-app notification for the complaint's owner,
+// in the same transaction as the change itself.
+type ComplaintRepo interface {
+	Create(ctx context.Context, c model.Complaint, notice string) (model.Complaint, error)
+	// FindByID returns the complaint together with its notes.
+	FindByID(ctx context.Context, id int32) (*model.Complaint, error)
+	ListByUser(ctx context.Context, userID string) ([]model.Complaint, error)
+	// List returns complaints oldest first. Empty status and nil bounds
+	// don't filter.
+	List(ctx context.Context, status string, createdBefore, createdAfter *time.Time) ([]model.Complaint, error)
+	AddNote(ctx context.Context, id int32, authorID, note, notice string) (model.ComplaintNote, error)
+	// UpdateStatus moves a complaint from one status to another, adding note
+	// first when it's not empty.
+	UpdateStatus(ctx context.Context, id int32, from, to, actorID, note, notice string) (model.Complaint, error)
+}
+
+type complaintRepo struct {
+	db *pgxpool.Pool
+	q  *complaint.Queries
+}
+
+func NewComplaintRepo(db *pgxpool.Pool) ComplaintRepo {
+	return &complaintRepo{db: db, q: complaint.New(db)}
+}
+
+func (r *complaintRepo) Create(ctx context.Context, c model.Complaint, notice string) (model.Complaint, error) {
+	userID, err := pgUUID(c.UserID)
+	if err != nil {
+		return model.Complaint{}, err
+	}
+
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return model.Complaint{}, err
+	}
+	defer tx.Rollback(ctx)
+	q := r.q.WithTx(tx)
+
+	created, err := q.CreateComplaint(ctx, complaint.CreateComplaintParams{
+		UserID:      userID,
+		OrderID:     c.OrderID,
+		Description: c.Description,
+	})
+	if err != nil {
+		return model.Complaint{}, err
+	}
+
+	err = recordComplaintChange(ctx, q, created, userID, "complaint_open", map[string]any{
+		"complaint_id": created.ID,
+		"order_id":     created.OrderID,
+	}, notice)
+	if err != nil {
+		return model.Complaint{}, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return model.Complaint{}, err
+	}
+	return toComplaintModel(created), nil
+}
+
+func (r *complaintRepo) FindByID(ctx context.Context, id int32) (*model.Complaint, error) {
+	c, err := r.q.GetComplaintByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrComplaintNotFound
+		}
+		return nil, err
+	}
+
+	notes, err := r.q.ListComplaintNotes(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	res := toComplaintModel(c)
+	res.Notes = make([]model.ComplaintNote, len(notes))
+	for i, n := range notes {
+		res.Notes[i] = toComplaintNoteModel(n)
+	}
+	return &res, nil
+}
+
+func (r *complaintRepo) ListByUser(ctx context.Context, userID string) ([]model.Complaint, error) {
+	uid, err := pgUUID(userID)
+	if err != nil {
+		return nil, err
+	}
+	cs, err := r.q.ListComplaintsByUser(ctx, uid)
+	if err != nil {
+		return nil, err
+	}
+	return toComplaintModels(cs), nil
+}
+
+func (r *complaintRepo) List(ctx context.Context, status string, createdBefore, createdAfter *time.Time) ([]model.Complaint, error) {
+	cs, err := r.q.ListComplaints(ctx, complaint.ListComplaintsParams{
+		Status:        textFromString(status),
+		CreatedBefore: timestamptzFromPtr(createdBefore),
+		CreatedAfter:  timestamptzFromPtr(createdAfter),
+	})
+	if err != nil {
+		return nil, err
+	}
+	return toComplaintModels(cs), nil
+}
+
+func (r *complaintRepo) AddNote(ctx context.Context, id int32, authorID, note, notice string) (model.ComplaintNote, error) {
+	author, err := pgUUID(authorID)
+	if err != nil {
+		return model.ComplaintNote{}, err
+	}
+
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return model.ComplaintNote{}, err
+	}
+	defer tx.Rollback(ctx)
+	q := r.q.WithTx(tx)
+
+	c, err := q.GetComplaintForUpdate(ctx, id)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return model.ComplaintNote{}, ErrComplaintNotFound
+		}
+		return model.ComplaintNote{}, err
+	}
+
+	created, err := q.CreateComplaintNote(ctx, complaint.CreateComplaintNoteParams{
+		ComplaintID: id,
+		AuthorID:    author,
+		Note:        note,
+	})
+	if err != nil {
+		return model.ComplaintNote{}, err
+	}
+
+	err = recordComplaintChange(ctx, q, c, author, "complaint_note", map[string]any{
+		"complaint_id": id,
+		"order_id":     c.OrderID,
+		"note_id":      created.ID,
+	}, notice)
+	if err != nil {
+		return model.ComplaintNote{}, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return model.ComplaintNote{}, err
+	}
+	return toComplaintNoteModel(created), nil
+}
+
+// UpdateStatus locks the complaint row first, so a change made by someone
+// else in between is reported as ErrComplaintStatusChanged.
+func (r *complaintRepo) UpdateStatus(ctx context.Context, id int32, from, to, actorID, note, notice string) (model.Complaint, error) {
+	actor, err := pgUUID(actorID)
+	if err != nil {
+		return model.Complaint{}, err
+	}
+
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return model.Complaint{}, err
+	}
+	defer tx.Rollback(ctx)
+	q := r.q.WithTx(tx)
+
+	c, err := q.GetComplaintForUpdate(ctx, id)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return model.Complaint{}, ErrComplaintNotFound
+		}
+		return model.Complaint{}, err
+	}
+	if c.Status.String != from {
+		return model.Complaint{}, ErrComplaintStatusChanged
+	}
+
+	details := map[string]any{
+		"complaint_id": id,
+		"order_id":     c.OrderID,
+		"from":         from,
+		"to":           to,
+	}
+	if note != "" {
+		created, err := q.CreateComplaintNote(ctx, complaint.CreateComplaintNoteParams{
+			ComplaintID: id,
+			AuthorID:    actor,
+			Note:        note,
+		})
+		if err != nil {
+			return model.Complaint{}, err
+		}
+		details["note_id"] = created.ID
+	}
+
+	updated, err := q.UpdateComplaintStatus(ctx, complaint.UpdateComplaintStatusParams{
+		Status: to,
+		ID:     id,
+	})
+	if err != nil {
+		return model.Complaint{}, err
+	}
+
+	if err := recordComplaintChange(ctx, q, updated, actor, "complaint_"+to, details, notice); err != nil {
+		return model.Complaint{}, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return model.Complaint{}, err
+	}
+	return toComplaintModel(updated), nil
+}
+
+// recordComplaintChange writes the audit entry of a complaint change and
+// queues notice for the complaint's owner.
+func recordComplaintChange(ctx context.Context, q *complaint.Queries, c complaint.Complaint, actor pgtype.UUID, action string, details map[string]any, notice string) error {
+	data, err := json.Marshal(details)
+	if err != nil {
+		return err
+	}
+	err = q.CreateAuditLog(ctx, complaint.CreateAuditLogParams{
+		ActorID: actor,
+		Action:  action,
+		Details: data,
+	})
+	if err != nil {
+		return err
+	}
+	return q.CreateNotification(ctx, complaint.CreateNotificationParams{
+		UserID:  c.UserID,
+		OrderID: pgtype.Int4{Int32: c.OrderID, Valid: true},
+		Message: notice,
+		Channel: complaint.NotificationChannelInApp,
+	})
+}
+
+func toComplaintModels(cs []complaint.Complaint) []model.Complaint {
+	res := make([]model.Complaint, len(cs))
+	for i, c := range cs {
+		res[i] = toComplaintModel(c)
+	}
+	return res
+}
+
+func toComplaintModel(c complaint.Complaint) model.Complaint {
+	return model.Complaint{
+		ID:          c.ID,
+		UserID:      uuidString(c.UserID),
+		OrderID:     c.OrderID,
+		Description: c.Description,
+		Status:      c.Status.String,
+		CreatedAt:   c.CreatedAt.Time,
+		ResolvedAt:  timePtr(c.ResolvedAt),
+	}
+}
+
+func toComplaintNoteModel(n complaint.ComplaintNote) model.ComplaintNote {
+	return model.ComplaintNote{
+		ID:          n.ID,
+		ComplaintID: n.ComplaintID,
+		AuthorID:    uuidString(n.AuthorID),
+		Note:        n.Note,
+		CreatedAt:   n.CreatedAt.Time,
+	}
+}
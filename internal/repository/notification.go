@@ -0,0 +1,155 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/AndikaPrasetia/wash-shoe/internal/model"
+	"github.com/AndikaPrasetia/wash-shoe/internal/sqlc/notification"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+var ErrNotificationNotFound = errors.New("notification not found")
+
+type NotificationRepo interface {
+	// ClaimPending claims up to limit due pending notifications for one
+	// delivery attempt. They are not handed out again before lease has
+	// passed, unless MarkRetry says otherwise.
+	ClaimPending(ctx context.Context, limit int32, lease time.Duration) ([]model.PendingNotification, error)
+	MarkSent(ctx context.Context, id int32) error
+	// MarkRetry keeps the notification pending until next.
+	MarkRetry(ctx context.Context, id int32, next time.Time, reason string) error
+	MarkFailed(ctx context.Context, id int32, reason string) error
+
+	// ListInbox returns up to limit dispatched in-app notifications of the
+	// user, newest first, older than cursor when it is set.
+	ListInbox(ctx context.Context, userID string, cursor *int32, limit int32) ([]model.Notification, error)
+	// MarkRead marks an inbox notification read and reports whether it was
+	// unread before.
+	MarkRead(ctx context.Context, userID string, id int32) (model.Notification, bool, error)
+	MarkAllRead(ctx context.Context, userID string) (int64, error)
+	CountUnread(ctx context.Context, userID string) (int64, error)
+}
+
+type notificationRepo struct {
+	q *notification.Queries
+}
+
+func NewNotificationRepo(db *pgxpool.Pool) NotificationRepo {
+	return &notificationRepo{q: notification.New(db)}
+}
+
+func (r *notificationRepo) ClaimPending(ctx context.Context, limit int32, lease time.Duration) ([]model.PendingNotification, error) {
+	rows, err := r.q.ClaimPendingNotifications(ctx, notification.ClaimPendingNotificationsParams{
+		LeaseSeconds: int32(lease.Seconds()),
+		BatchSize:    limit,
+	})
+	if err != nil {
+		return nil, err
+	}
+	res := make([]model.PendingNotification, len(rows))
+	for i, n := range rows {
+		res[i] = model.PendingNotification{
+			ID:          n.ID,
+			UserID:      uuidString(n.UserID),
+			OrderID:     int4Ptr(n.OrderID),
+			Message:     n.Message,
+			Channel:     string(n.Channel),
+			Attempts:    n.Attempts,
+			Email:       n.Email.String,
+			PhoneNumber: n.PhoneNumber.String,
+		}
+	}
+	return res, nil
+}
+
+func (r *notificationRepo) MarkSent(ctx context.Context, id int32) error {
+	return r.q.MarkNotificationSent(ctx, id)
+}
+
+func (r *notificationRepo) MarkRetry(ctx context.Context, id int32, next time.Time, reason string) error {
+	return r.q.MarkNotificationRetry(ctx, notification.MarkNotificationRetryParams{
+		NextAttemptAt: timestamptzFromPtr(&next),
+		LastError:     textFromString(reason),
+		ID:            id,
+	})
+}
+
+func (r *notificationRepo) MarkFailed(ctx context.Context, id int32, reason string) error {
+	return r.q.MarkNotificationFailed(ctx, notification.MarkNotificationFailedParams{
+		ID:        id,
+		LastError: textFromString(reason),
+	})
+}
+
+func (r *notificationRepo) ListInbox(ctx context.Context, userID string, cursor *int32, limit int32) ([]model.Notification, error) {
+	uid, err := pgUUID(userID)
+	if err != nil {
+		return nil, err
+	}
+	ns, err := r.q.ListInboxNotifications(ctx, notification.ListInboxNotificationsParams{
+		UserID:   uid,
+		Cursor:   int4FromPtr(cursor),
+		RowLimit: limit,
+	})
+	if err != nil {
+		return nil, err
+	}
+	res := make([]model.Notification, len(ns))
+	for i, n := range ns {
+		res[i] = toNotificationModel(n)
+	}
+	return res, nil
+}
+
+func (r *notificationRepo) MarkRead(ctx context.Context, userID string, id int32) (model.Notification, bool, error) {
+	uid, err := pgUUID(userID)
+	if err != nil {
+		return model.Notification{}, false, err
+	}
+	n, err := r.q.MarkNotificationRead(ctx, notification.MarkNotificationReadParams{ID: id, UserID: uid})
+	if err == nil {
+		return toNotificationModel(n), true, nil
+	}
+	if !errors.Is(err, pgx.ErrNoRows) {
+		return model.Notification{}, false, err
+	}
+
+	// already read, or not in the user's inbox at all
+	n, err = r.q.GetInboxNotification(ctx, notification.GetInboxNotificationParams{ID: id, UserID: uid})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return model.Notification{}, false, ErrNotificationNotFound
+		}
+		return model.Notification{}, false, err
+	}
+	return toNotificationModel(n), false, nil
+}
+
+func (r *notificationRepo) MarkAllRead(ctx context.Context, userID string) (int64, error) {
+	uid, err := pgUUID(userID)
+	if err != nil {
+		return 0, err
+	}
+	return r.q.MarkAllNotificationsRead(ctx, uid)
+}
+
+func (r *notificationRepo) CountUnread(ctx context.Context, userID string) (int64, error) {
+	uid, err := pgUUID(userID)
+	if err != nil {
+		return 0, err
+	}
+	return r.q.CountUnreadNotifications(ctx, uid)
+}
+
+func toNotificationModel(n notification.Notification) model.Notification {
+	return model.Notification{
+		ID:        n.ID,
+		OrderID:   int4Ptr(n.OrderID),
+		Message:   n.Message,
+		CreatedAt: n.CreatedAt.Time,
+		ReadAt:    timePtr(n.ReadAt),
+	}
+}
@@ -2,97 +2,287 @@ package repository
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 
-	"github.com/AndikaPrasetia/wash-shoe/internal/db/order"
 	"github.com/AndikaPrasetia/wash-shoe/internal/model"
-	// "github.com/jackc/pgx/v5/pgtype"
+	"github.com/AndikaPrasetia/wash-shoe/internal/sqlc/ledger"
+	"github.com/AndikaPrasetia/wash-shoe/internal/sqlc/order"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
 )
 
+var (
+	ErrOrderNotFound      = errors.New("order not found")
+	ErrOrderStatusChanged = errors.New("order status was changed concurrently")
+	ErrPromoNotRedeemable = errors.New("promo is expired or has no usage left")
+)
+
+// sqlStatePromoNotRedeemable is raised by create_order_with_services when
+// the promo can't be redeemed anymore.
+const sqlStatePromoNotRedeemable = "PR001"
+
 type OrderRepo interface {
-	Create(ctx context.Context, u order.Order) (model.Order, error)
-	// FindByEmail(ctx context.Context, email string) (*model.Order, error)
-	// FindByID(ctx context.Context, id pgtype.UUID) (*model.Order, error)
-	// Update(ctx context.Context, u order.Order) (model.Order, error)
-	// Delete(ctx context.Context, id pgtype.UUID) error
+	Create(ctx context.Context, o model.Order) (int32, error)
+	FindByID(ctx context.Context, id int32) (*model.Order, error)
+	ListByUser(ctx context.Context, userID string) ([]model.Order, error)
+	ListServiceTypes(ctx context.Context, ids []int32) ([]model.ServiceType, error)
+	UpdateStatus(ctx context.Context, id int32, from, to, updatedBy string) error
+	ListStatusHistory(ctx context.Context, id int32) ([]model.OrderStatusHistory, error)
+	// ListOpenWithDeadline returns unfinished orders that have a promised_by,
+	// earliest deadline first.
+	ListOpenWithDeadline(ctx context.Context) ([]model.Order, error)
 }
 
 type orderRepo struct {
-	q *order.Queries
+	db *pgxpool.Pool
+	q  *order.Queries
+}
+
+func NewOrderRepo(db *pgxpool.Pool) OrderRepo {
+	return &orderRepo{db: db, q: order.New(db)}
 }
 
-func NewOrderRepo(q *order.Queries) OrderRepo {
-	return &orderRepo{q: q}
+// orderServiceLine is the JSONB shape expected by create_order_with_services.
+type orderServiceLine struct {
+	ServiceTypeID int32   `json:"service_type_id"`
+	Quantity      int32   `json:"quantity"`
+	Price         float64 `json:"price"`
 }
 
-func (r *orderRepo) Create(ctx context.Context, u order.Order) (model.Order, error) {
-	params := order.CreateOrderParams{
-		UserID:      u.UserID,
-		ServiceType: u.ServiceType,
-		Status:      u.Status,
+func (r *orderRepo) Create(ctx context.Context, o model.Order) (int32, error) {
+	userID, err := pgUUID(o.UserID)
+	if err != nil {
+		return 0, err
+	}
+
+	lines := make([]orderServiceLine, len(o.Services))
+	for i, s := range o.Services {
+		lines[i] = orderServiceLine{
+			ServiceTypeID: s.ServiceTypeID,
+			Quantity:      s.Quantity,
+			Price:         s.Price,
+		}
 	}
-	created, err := r.q.CreateOrder(ctx, params)
+	services, err := json.Marshal(lines)
 	if err != nil {
-		return model.Order{}, err
+		return 0, err
 	}
-	return model.Order{
-		UserID:      created.UserID.String(),
-		ServiceType: created.ServiceType.String,
-		Status:      created.Status.String,
-	}, nil
+
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback(ctx)
+	q := r.q.WithTx(tx)
+
+	id, err := q.CreateOrderWithServices(ctx, order.CreateOrderWithServicesParams{
+		UserID:     userID,
+		AddressID:  int4FromPtr(o.AddressID),
+		TotalPrice: numericFromFloat(o.TotalPrice),
+		IsExpress:  o.IsExpress,
+		ExpressFee: numericFromFloat(o.ExpressFee),
+		PromoCode:  textFromString(o.PromoCode),
+		Services:   services,
+	})
+	if err != nil {
+		if hasSQLState(err, sqlStatePromoNotRedeemable) {
+			return 0, ErrPromoNotRedeemable
+		}
+		return 0, err
+	}
+
+	err = q.SetOrderPromisedBy(ctx, order.SetOrderPromisedByParams{
+		ID:         id,
+		PromisedBy: timestamptzFromPtr(o.PromisedBy),
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return id, tx.Commit(ctx)
+}
+
+func (r *orderRepo) FindByID(ctx context.Context, id int32) (*model.Order, error) {
+	o, err := r.q.GetOrderByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrOrderNotFound
+		}
+		return nil, err
+	}
+
+	lines, err := r.q.ListOrderServices(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	res := toOrderModel(o)
+	res.Services = make([]model.OrderService, len(lines))
+	for i, l := range lines {
+		res.Services[i] = model.OrderService{
+			ID:            l.ID,
+			ServiceTypeID: l.ServiceTypeID,
+			ServiceName:   l.ServiceName,
+			Quantity:      l.Quantity.Int32,
+			Price:         floatFromNumeric(l.Price),
+		}
+	}
+	return &res, nil
+}
+
+func (r *orderRepo) ListByUser(ctx context.Context, userID string) ([]model.Order, error) {
+	uid, err := pgUUID(userID)
+	if err != nil {
+		return nil, err
+	}
+	orders, err := r.q.ListOrdersByUser(ctx, uid)
+	if err != nil {
+		return nil, err
+	}
+	res := make([]model.Order, len(orders))
+	for i, o := range orders {
+		res[i] = toOrderModel(o)
+	}
+	return res, nil
 }
 
-// func (r *orderRepo) FindByEmail(ctx context.Context, id string) (*model.Order, error) {
-// 	order, err := r.q.GetOrderByID(ctx, id)
-// 	if err != nil {
-// 		return nil, err
-// 	}
-// 	return &model.Order{
-// 		ID:        user.ID.String(),
-// 		Name:      user.Name,
-// 		Email:     user.Email,
-// 		Role:      user.Role,
-// 		CreatedAt: user.CreatedAt.Time,
-// 		UpdatedAt: user.UpdatedAt.Time,
-// 	}, nil
-// }
-//
-// func (r *userRepo) FindByID(ctx context.Context, id pgtype.UUID) (*model.User, error) {
-// 	user, err := r.q.GetUserByID(ctx, id)
-// 	if err != nil {
-// 		return nil, err
-// 	}
-// 	return &model.User{
-// 		ID:        user.ID.String(),
-// 		Name:      user.Name,
-// 		Email:     user.Email,
-// 		Role:      user.Role,
-// 		CreatedAt: user.CreatedAt.Time,
-// 		UpdatedAt: user.UpdatedAt.Time,
-// 	}, nil
-// }
-//
-// func (r *userRepo) Update(ctx context.Context, u order.Order) (model.Order, error) {
-// 	params := order.UpdateOrderStatusParams{
-// 		ID:       u.ID,
-// 		Name:     u.Name,
-// 		Email:    u.Email,
-// 		Password: u.Password,
-// 		Role:     u.Role,
-// 	}
-// 	updated, err := r.q.UpdateUser(ctx, params)
-// 	if err != nil {
-// 		return model.Order{}, err
-// 	}
-// 	return model.Order{
-// 		ID:        updated.ID.String(),
-// 		Name:      updated.Name,
-// 		Email:     updated.Email,
-// 		Role:      updated.Role,
-// 		CreatedAt: updated.CreatedAt.Time,
-// 		UpdatedAt: updated.UpdatedAt.Time,
-// 	}, nil
-// }
-//
-// func (r *userRepo) Delete(ctx context.Context, id pgtype.UUID) error {
-// 	return r.q.DeleteUser(ctx, id)
-// }
+func (r *orderRepo) ListServiceTypes(ctx context.Context, ids []int32) ([]model.ServiceType, error) {
+	sts, err := r.q.ListServiceTypesByIDs(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+	res := make([]model.ServiceType, len(sts))
+	for i, st := range sts {
+		res[i] = model.ServiceType{
+			ID:                     st.ID,
+			Name:                   st.Name,
+			Description:            st.Description.String,
+			BasePrice:              floatFromNumeric(st.BasePrice),
+			EstimatedDurationHours: int4Ptr(st.EstimatedDurationHours),
+			IsEcoFriendly:          st.IsEcoFriendly.Bool,
+			CreatedAt:              st.CreatedAt.Time,
+		}
+	}
+	return res, nil
+}
+
+// UpdateStatus moves an order from one status to another through
+// update_order_status. The order row is locked first, so a change made by
+// someone else in between is reported as ErrOrderStatusChanged. Completing
+// an order also completes the customer's pending referral, if any, and
+// credits the cashback to the referrer's wallet. Cancelling an order gives
+// its promo usage back, and the wallet credit spent on its payment.
+func (r *orderRepo) UpdateStatus(ctx context.Context, id int32, from, to, updatedBy string) error {
+	by, err := pgUUID(updatedBy)
+	if err != nil {
+		return err
+	}
+
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+	q := r.q.WithTx(tx)
+
+	current, err := q.GetOrderStatusForUpdate(ctx, id)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return ErrOrderNotFound
+		}
+		return err
+	}
+	if string(current.Status.OrderStatus) != from {
+		return ErrOrderStatusChanged
+	}
+
+	err = q.UpdateOrderStatus(ctx, order.UpdateOrderStatusParams{
+		OrderID:   id,
+		Status:    order.OrderStatus(to),
+		UpdatedBy: by,
+	})
+	if err != nil {
+		return err
+	}
+	if to == string(order.OrderStatusCompleted) {
+		if err := q.MarkOrderCompleted(ctx, id); err != nil {
+			return err
+		}
+		referral, err := q.CompleteReferralForOrder(ctx, id)
+		if err != nil && !errors.Is(err, pgx.ErrNoRows) {
+			return err
+		}
+		if err == nil && cents(floatFromNumeric(referral.CashbackAmount)) > 0 {
+			_, err = postToWallet(ctx, ledger.New(tx), walletPosting{
+				UserID:      uuidString(referral.ReferrerID),
+				Amount:      floatFromNumeric(referral.CashbackAmount),
+				Kind:        ledgerKindReferralCashback,
+				OrderID:     &id,
+				Description: "Referral cashback",
+				CreatedBy:   updatedBy,
+			})
+			if err != nil {
+				return err
+			}
+		}
+	}
+	if to == string(order.OrderStatusCancelled) {
+		if current.PromoCode.Valid {
+			if err := q.ReleasePromoUsage(ctx, current.PromoCode.String); err != nil {
+				return err
+			}
+		}
+		if err := returnWalletPayment(ctx, tx, id, updatedBy); err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit(ctx)
+}
+
+func (r *orderRepo) ListStatusHistory(ctx context.Context, id int32) ([]model.OrderStatusHistory, error) {
+	hs, err := r.q.ListOrderStatusHistory(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	res := make([]model.OrderStatusHistory, len(hs))
+	for i, h := range hs {
+		res[i] = model.OrderStatusHistory{
+			ID:        h.ID,
+			OrderID:   h.OrderID,
+			Status:    string(h.Status),
+			UpdatedBy: uuidString(h.UpdatedBy),
+			UpdatedAt: h.UpdatedAt.Time,
+		}
+	}
+	return res, nil
+}
+
+func (r *orderRepo) ListOpenWithDeadline(ctx context.Context) ([]model.Order, error) {
+	orders, err := r.q.ListOpenOrdersWithDeadline(ctx)
+	if err != nil {
+		return nil, err
+	}
+	res := make([]model.Order, len(orders))
+	for i, o := range orders {
+		res[i] = toOrderModel(o)
+	}
+	return res, nil
+}
+
+func toOrderModel(o order.Order) model.Order {
+	return model.Order{
+		ID:          o.ID,
+		UserID:      uuidString(o.UserID),
+		AddressID:   int4Ptr(o.AddressID),
+		TotalPrice:  floatFromNumeric(o.TotalPrice),
+		Status:      string(o.Status.OrderStatus),
+		IsExpress:   o.IsExpress.Bool,
+		ExpressFee:  floatFromNumeric(o.ExpressFee),
+		PromoCode:   o.PromoCode.String,
+		CreatedAt:   o.CreatedAt.Time,
+		CompletedAt: timePtr(o.CompletedAt),
+		PromisedBy:  timePtr(o.PromisedBy),
+	}
+}
@@ -0,0 +1,108 @@
+package repository
+
+import (
+	"context"
+	"os"
+	"testing"
+
+	"github.com/AndikaPrasetia/wash-shoe/internal/model"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// testDB connects to the migrated database in TEST_DATABASE_URL and skips
+// the test when it isn't set.
+func testDB(t *testing.T) *pgxpool.Pool {
+	t.Helper()
+	url := os.Getenv("TEST_DATABASE_URL")
+	if url == "" {
+		t.Skip("TEST_DATABASE_URL is not set")
+	}
+	db, err := pgxpool.New(context.Background(), url)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(db.Close)
+	return db
+}
+
+// testCustomer creates a customer with a pending order of total and
+// removes both when the test ends.
+func testCustomer(t *testing.T, db *pgxpool.Pool, total float64) (string, int32) {
+	t.Helper()
+	ctx := context.Background()
+	userID := uuid.NewString()
+
+	_, err := db.Exec(ctx, `INSERT INTO auth.users (id, email) VALUES ($1, $2)`, userID, userID+"@test.local")
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = db.Exec(ctx, `INSERT INTO public.users (id, full_name) VALUES ($1, 'Ledger Test')`, userID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var orderID int32
+	err = db.QueryRow(ctx, `INSERT INTO public.orders (user_id, total_price) VALUES ($1, $2) RETURNING id`, userID, total).Scan(&orderID)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Cleanup(func() {
+		_, _ = db.Exec(ctx, `DELETE FROM public.orders WHERE id = $1`, orderID)
+		_, _ = db.Exec(ctx, `DELETE FROM auth.users WHERE id = $1`, userID)
+	})
+	return userID, orderID
+}
+
+func TestCancelReturnsWalletPayment(t *testing.T) {
+	db := testDB(t)
+	ctx := context.Background()
+	userID, orderID := testCustomer(t, db, 50000)
+
+	wallets := NewWalletRepo(db)
+	payments := NewPaymentRepo(db)
+	orders := NewOrderRepo(db)
+
+	balance := func() float64 {
+		t.Helper()
+		b, err := wallets.Balance(ctx, userID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return b
+	}
+
+	if _, err := wallets.Adjust(ctx, userID, 20000, "test credit", ""); err != nil {
+		t.Fatal(err)
+	}
+	_, err := payments.Reserve(ctx, model.Payment{OrderID: orderID, Method: "DANA", Amount: 30000, WalletAmount: 20000})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := balance(); got != 0 {
+		t.Fatalf("balance after paying = %v, want 0", got)
+	}
+
+	if err := orders.UpdateStatus(ctx, orderID, "pending", "cancelled", ""); err != nil {
+		t.Fatal(err)
+	}
+	if got := balance(); got != 20000 {
+		t.Fatalf("balance after cancelling = %v, want 20000", got)
+	}
+
+	// the provider still charges the cancelled order: that part is refunded
+	// and the wallet part isn't given back twice
+	if err := payments.Complete(ctx, orderID, "trx-"+userID, "success"); err != nil {
+		t.Fatal(err)
+	}
+	if got := balance(); got != 50000 {
+		t.Fatalf("balance after the late payment = %v, want 50000", got)
+	}
+	p, err := payments.FindByOrderID(ctx, orderID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if p.Status != "refunded" {
+		t.Errorf("payment status = %s, want refunded", p.Status)
+	}
+}
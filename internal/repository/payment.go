@@ -0,0 +1,536 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"slices"
+
+	"github.com/AndikaPrasetia/wash-shoe/internal/model"
+	"github.com/AndikaPrasetia/wash-shoe/internal/sqlc/ledger"
+	"github.com/AndikaPrasetia/wash-shoe/internal/sqlc/payment"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+var (
+	ErrPaymentNotFound = errors.New("payment not found")
+	ErrPaymentExists   = errors.New("order already has a payment")
+
+	ErrPaymentNotRefundable = errors.New("payment is not refundable")
+	ErrRefundExceedsPayment = errors.New("refund exceeds the remaining payment amount")
+	ErrOrderNotCancellable  = errors.New("order can no longer be cancelled")
+)
+
+type PaymentRepo interface {
+	// Reserve stores p as the pending payment of its order before anything
+	// is charged and takes its WalletAmount from the customer's wallet.
+	Reserve(ctx context.Context, p model.Payment) (model.Payment, error)
+	// Complete settles the payment reserved for the order with the
+	// gateway's answer. A successful payment moves the order to
+	// processing; a failed one gives the wallet credit back.
+	Complete(ctx context.Context, orderID int32, transactionID, status string) error
+	FindByOrderID(ctx context.Context, orderID int32) (*model.Payment, error)
+	// ApplyStatus sets the status of the payment identified by method and
+	// transactionID and returns the payment's order ID. It reports false
+	// when the payment is no longer pending, which makes redelivered events
+	// no-ops. Wallet credit spent on a payment that fails is given back.
+	ApplyStatus(ctx context.Context, method, transactionID, status string) (int32, bool, error)
+	// Refund stores a refund against the order's payment, wallet part
+	// included, and credits it to the customer's wallet. An amount of 0
+	// refunds whatever has not been refunded yet.
+	Refund(ctx context.Context, orderID int32, amount float64, reason, actorID string) (model.Refund, error)
+	ListRefunds(ctx context.Context, orderID int32) ([]model.Refund, error)
+}
+
+type paymentRepo struct {
+	db *pgxpool.Pool
+	q  *payment.Queries
+}
+
+func NewPaymentRepo(db *pgxpool.Pool) PaymentRepo {
+	return &paymentRepo{db: db, q: payment.New(db)}
+}
+
+// Reserve locks the order and requires it to still be pending. payments
+// has one row per order, so a failed attempt is replaced while any other
+// existing payment is reported as ErrPaymentExists.
+func (r *paymentRepo) Reserve(ctx context.Context, p model.Payment) (model.Payment, error) {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return model.Payment{}, err
+	}
+	defer tx.Rollback(ctx)
+	q := r.q.WithTx(tx)
+
+	status, err := q.GetOrderStatusForUpdate(ctx, p.OrderID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return model.Payment{}, ErrOrderNotFound
+		}
+		return model.Payment{}, err
+	}
+	if status.OrderStatus != payment.OrderStatusPending {
+		return model.Payment{}, ErrOrderStatusChanged
+	}
+
+	if err := q.DeleteFailedPayment(ctx, p.OrderID); err != nil {
+		return model.Payment{}, err
+	}
+	if _, err := q.GetPaymentByOrderID(ctx, p.OrderID); err == nil {
+		return model.Payment{}, ErrPaymentExists
+	} else if !errors.Is(err, pgx.ErrNoRows) {
+		return model.Payment{}, err
+	}
+
+	reserved, err := q.ReservePayment(ctx, payment.ReservePaymentParams{
+		OrderID:      p.OrderID,
+		Method:       payment.PaymentMethod(p.Method),
+		Amount:       numericFromFloat(p.Amount),
+		WalletAmount: numericFromFloat(p.WalletAmount),
+	})
+	if err != nil {
+		return model.Payment{}, err
+	}
+
+	if cents(p.WalletAmount) > 0 {
+		err = postOrderWallet(ctx, tx, p.OrderID, walletPosting{
+			Amount:      -p.WalletAmount,
+			Kind:        ledgerKindOrderPayment,
+			Description: fmt.Sprintf("Payment for order #%d", p.OrderID),
+		})
+		if err != nil {
+			return model.Payment{}, err
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return model.Payment{}, err
+	}
+	return toPaymentModel(reserved), nil
+}
+
+// Complete only settles a reservation: a payment that has a transaction ID
+// or is no longer pending is reported as ErrPaymentNotFound.
+func (r *paymentRepo) Complete(ctx context.Context, orderID int32, transactionID, status string) error {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+	q := r.q.WithTx(tx)
+
+	orderStatus, err := q.GetOrderStatusForUpdate(ctx, orderID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return ErrOrderNotFound
+		}
+		return err
+	}
+	p, err := q.GetPaymentByOrderIDForUpdate(ctx, orderID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return ErrPaymentNotFound
+		}
+		return err
+	}
+	if p.TransactionID.Valid || p.Status.PaymentStatus != payment.PaymentStatusPending {
+		return ErrPaymentNotFound
+	}
+
+	if transactionID != "" {
+		err = q.SetPaymentTransactionID(ctx, payment.SetPaymentTransactionIDParams{
+			ID:            p.ID,
+			TransactionID: textFromString(transactionID),
+		})
+		if err != nil {
+			return err
+		}
+	}
+	if status != string(payment.PaymentStatusPending) {
+		if err := r.settle(ctx, tx, p, orderStatus.OrderStatus, status); err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit(ctx)
+}
+
+func (r *paymentRepo) FindByOrderID(ctx context.Context, orderID int32) (*model.Payment, error) {
+	p, err := r.q.GetPaymentByOrderID(ctx, orderID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrPaymentNotFound
+		}
+		return nil, err
+	}
+	res := toPaymentModel(p)
+	return &res, nil
+}
+
+// ApplyStatus locks the order and then the payment row, the same order
+// Complete and Refund take them in, so concurrent deliveries of the same
+// event are applied once. On success a still pending order is moved to
+// processing through update_order_status.
+func (r *paymentRepo) ApplyStatus(ctx context.Context, method, transactionID, status string) (int32, bool, error) {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return 0, false, err
+	}
+	defer tx.Rollback(ctx)
+	q := r.q.WithTx(tx)
+
+	found, err := q.GetPaymentByTransactionID(ctx, payment.GetPaymentByTransactionIDParams{
+		TransactionID: textFromString(transactionID),
+		Method:        payment.PaymentMethod(method),
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return 0, false, ErrPaymentNotFound
+		}
+		return 0, false, err
+	}
+	orderStatus, err := q.GetOrderStatusForUpdate(ctx, found.OrderID)
+	if err != nil {
+		return 0, false, err
+	}
+	p, err := q.GetPaymentByOrderIDForUpdate(ctx, found.OrderID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return 0, false, ErrPaymentNotFound
+		}
+		return 0, false, err
+	}
+	// the payment may have failed and been replaced before the lock was taken
+	if p.ID != found.ID {
+		return 0, false, ErrPaymentNotFound
+	}
+	pending, err := checkWebhookPayment(p, method)
+	if err != nil {
+		return 0, false, err
+	}
+	if !pending {
+		return p.OrderID, false, nil
+	}
+
+	if err := r.settle(ctx, tx, p, orderStatus.OrderStatus, status); err != nil {
+		return 0, false, err
+	}
+	return p.OrderID, true, tx.Commit(ctx)
+}
+
+// settle moves the locked pending payment p to status within tx, where
+// orderStatus is the status of its order, locked by the caller. Wallet
+// credit spent on a payment that fails is given back; a successful payment
+// moves a still pending order to processing. An order cancelled while the
+// provider was settling stays cancelled and the payment is refunded to the
+// customer's wallet right away, audit log entry included.
+func (r *paymentRepo) settle(ctx context.Context, tx pgx.Tx, p payment.Payment, orderStatus payment.OrderStatus, status string) error {
+	q := r.q.WithTx(tx)
+	err := q.UpdatePaymentStatus(ctx, payment.UpdatePaymentStatusParams{
+		Status: payment.PaymentStatus(status),
+		ID:     p.ID,
+	})
+	if err != nil {
+		return err
+	}
+
+	if status == string(payment.PaymentStatusFailed) {
+		// a cancelled order may have had its wallet part back already
+		refunded, err := q.SumRefundsByPayment(ctx, p.ID)
+		if err != nil {
+			return err
+		}
+		if left := walletLeft(p, refunded); left > 0 {
+			err = postOrderWallet(ctx, tx, p.OrderID, walletPosting{
+				Amount:      float64(left) / 100,
+				Kind:        ledgerKindOrderPaymentReversal,
+				Description: fmt.Sprintf("Payment for order #%d failed", p.OrderID),
+			})
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	if status == string(payment.PaymentStatusSuccess) {
+		switch {
+		case orderStatus == payment.OrderStatusCancelled:
+			_, _, err = refundPayment(ctx, tx, p, 0, "order was cancelled before the payment settled", "", ledgerKindRefund)
+			if err != nil {
+				return err
+			}
+		case canMoveOrder(orderStatus, payment.OrderStatusProcessing):
+			err = q.UpdateOrderStatus(ctx, payment.UpdateOrderStatusParams{
+				OrderID: p.OrderID,
+				Status:  payment.OrderStatusProcessing,
+			})
+			if err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// canMoveOrder tells whether model.OrderTransitions lets an order move
+// from one status to the other.
+func canMoveOrder(from, to payment.OrderStatus) bool {
+	return slices.Contains(model.OrderTransitions[string(from)], string(to))
+}
+
+// checkWebhookPayment tells whether an event delivered for method may
+// settle p. A payment of another method is reported as ErrPaymentNotFound,
+// so one provider can't settle the transactions of another. A payment that
+// is no longer pending was settled by an earlier delivery and is left alone.
+func checkWebhookPayment(p payment.Payment, method string) (bool, error) {
+	if string(p.Method) != method {
+		return false, ErrPaymentNotFound
+	}
+	return p.Status.PaymentStatus == payment.PaymentStatusPending, nil
+}
+
+// Refund runs in one transaction with the order and payment rows locked.
+// Once the refunds cover the whole payment it is marked refunded, the order
+// is cancelled through update_order_status and its promo usage is given
+// back. A full refund of an order that model.OrderTransitions no longer
+// lets be cancelled fails with ErrOrderNotCancellable. Every refund, partial or not, is credited to the customer's wallet
+// and written to auth.audit_log.
+func (r *paymentRepo) Refund(ctx context.Context, orderID int32, amount float64, reason, actorID string) (model.Refund, error) {
+	actor, err := pgUUID(actorID)
+	if err != nil {
+		return model.Refund{}, err
+	}
+
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return model.Refund{}, err
+	}
+	defer tx.Rollback(ctx)
+	q := r.q.WithTx(tx)
+
+	o, err := q.GetOrderForRefund(ctx, orderID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return model.Refund{}, ErrOrderNotFound
+		}
+		return model.Refund{}, err
+	}
+	p, err := q.GetPaymentByOrderIDForUpdate(ctx, orderID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return model.Refund{}, ErrPaymentNotFound
+		}
+		return model.Refund{}, err
+	}
+	if p.Status.PaymentStatus != payment.PaymentStatusSuccess {
+		return model.Refund{}, ErrPaymentNotRefundable
+	}
+
+	created, full, err := refundPayment(ctx, tx, p, amount, reason, actorID, ledgerKindRefund)
+	if err != nil {
+		return model.Refund{}, err
+	}
+
+	if full && o.Status.OrderStatus != payment.OrderStatusCancelled {
+		if !canMoveOrder(o.Status.OrderStatus, payment.OrderStatusCancelled) {
+			return model.Refund{}, ErrOrderNotCancellable
+		}
+		err = q.UpdateOrderStatus(ctx, payment.UpdateOrderStatusParams{
+			OrderID:   orderID,
+			Status:    payment.OrderStatusCancelled,
+			UpdatedBy: actor,
+		})
+		if err != nil {
+			return model.Refund{}, err
+		}
+		// a cancelled order gave its promo usage back already
+		if o.PromoCode.Valid {
+			if err := q.ReleasePromoUsage(ctx, o.PromoCode.String); err != nil {
+				return model.Refund{}, err
+			}
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return model.Refund{}, err
+	}
+	return toRefundModel(created, orderID), nil
+}
+
+// refundPayment stores a refund of amount against the locked payment p
+// within tx, or of whatever is left of it when amount is 0, credits it to
+// the customer's wallet as a ledger transaction of kind and writes it to
+// auth.audit_log. Once refunds cover the whole payment it is marked
+// refunded and full is reported. An empty actorID records a refund made by
+// the system.
+func refundPayment(ctx context.Context, tx pgx.Tx, p payment.Payment, amount float64, reason, actorID, kind string) (payment.Refund, bool, error) {
+	actor, err := pgUUID(actorID)
+	if err != nil {
+		return payment.Refund{}, false, err
+	}
+	q := payment.New(tx)
+
+	refunded, err := q.SumRefundsByPayment(ctx, p.ID)
+	if err != nil {
+		return payment.Refund{}, false, err
+	}
+	paid := cents(floatFromNumeric(p.Amount)) + cents(floatFromNumeric(p.WalletAmount))
+	remaining := paid - cents(floatFromNumeric(refunded))
+	if amount == 0 {
+		amount = float64(remaining) / 100
+	}
+	if cents(amount) > remaining || remaining <= 0 {
+		return payment.Refund{}, false, ErrRefundExceedsPayment
+	}
+	full := cents(amount) == remaining
+
+	created, err := q.CreateRefund(ctx, payment.CreateRefundParams{
+		PaymentID:  p.ID,
+		Amount:     numericFromFloat(amount),
+		Reason:     reason,
+		RefundedBy: actor,
+	})
+	if err != nil {
+		return payment.Refund{}, false, err
+	}
+	if full {
+		err = q.UpdatePaymentStatus(ctx, payment.UpdatePaymentStatusParams{
+			Status: payment.PaymentStatusRefunded,
+			ID:     p.ID,
+		})
+		if err != nil {
+			return payment.Refund{}, false, err
+		}
+	}
+
+	err = postOrderWallet(ctx, tx, p.OrderID, walletPosting{
+		Amount:      amount,
+		Kind:        kind,
+		Description: fmt.Sprintf("Refund for order #%d: %s", p.OrderID, reason),
+		CreatedBy:   actorID,
+	})
+	if err != nil {
+		return payment.Refund{}, false, err
+	}
+
+	details, err := json.Marshal(map[string]any{
+		"order_id":    p.OrderID,
+		"payment_id":  p.ID,
+		"refund_id":   created.ID,
+		"amount":      amount,
+		"reason":      reason,
+		"full_refund": full,
+	})
+	if err != nil {
+		return payment.Refund{}, false, err
+	}
+	err = q.CreateAuditLog(ctx, payment.CreateAuditLogParams{
+		ActorID: actor,
+		Action:  "payment_refund",
+		Details: details,
+	})
+	if err != nil {
+		return payment.Refund{}, false, err
+	}
+	return created, full, nil
+}
+
+func (r *paymentRepo) ListRefunds(ctx context.Context, orderID int32) ([]model.Refund, error) {
+	rs, err := r.q.ListRefundsByOrder(ctx, orderID)
+	if err != nil {
+		return nil, err
+	}
+	res := make([]model.Refund, len(rs))
+	for i, rf := range rs {
+		res[i] = toRefundModel(rf, orderID)
+	}
+	return res, nil
+}
+
+// cents converts a money amount to whole cents so amounts compare exactly.
+func cents(v float64) int64 {
+	return int64(math.Round(v * 100))
+}
+
+func toRefundModel(rf payment.Refund, orderID int32) model.Refund {
+	return model.Refund{
+		ID:         rf.ID,
+		PaymentID:  rf.PaymentID,
+		OrderID:    orderID,
+		Amount:     floatFromNumeric(rf.Amount),
+		Reason:     rf.Reason,
+		RefundedBy: uuidString(rf.RefundedBy),
+		CreatedAt:  rf.CreatedAt.Time,
+	}
+}
+
+func toPaymentModel(p payment.Payment) model.Payment {
+	return model.Payment{
+		ID:            p.ID,
+		OrderID:       p.OrderID,
+		Method:        string(p.Method),
+		Amount:        floatFromNumeric(p.Amount),
+		TransactionID: p.TransactionID.String,
+		Status:        string(p.Status.PaymentStatus),
+		PaidAt:        timePtr(p.PaidAt),
+		WalletAmount:  floatFromNumeric(p.WalletAmount),
+	}
+}
+
+// returnWalletPayment gives the wallet credit spent on the payment of a
+// cancelled order back within tx. It is stored as a refund of the payment,
+// so it can't be refunded a second time; what the provider took is left to
+// an admin refund, or refunded by settle when a pending payment still
+// goes through.
+func returnWalletPayment(ctx context.Context, tx pgx.Tx, orderID int32, actorID string) error {
+	q := payment.New(tx)
+	p, err := q.GetPaymentByOrderIDForUpdate(ctx, orderID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil
+		}
+		return err
+	}
+	// failed payments gave their wallet part back when they failed
+	if p.Status.PaymentStatus != payment.PaymentStatusPending && p.Status.PaymentStatus != payment.PaymentStatusSuccess {
+		return nil
+	}
+	refunded, err := q.SumRefundsByPayment(ctx, p.ID)
+	if err != nil {
+		return err
+	}
+	left := walletLeft(p, refunded)
+	if left <= 0 {
+		return nil
+	}
+	_, _, err = refundPayment(ctx, tx, p, float64(left)/100, "order was cancelled", actorID, ledgerKindOrderPaymentReversal)
+	return err
+}
+
+// walletLeft returns, in cents, the wallet credit spent on p that refunds
+// haven't given back yet. Only a successful payment was also paid through
+// the provider, and refunds count against that part first.
+func walletLeft(p payment.Payment, refunded pgtype.Numeric) int64 {
+	wallet := cents(floatFromNumeric(p.WalletAmount))
+	paid := wallet
+	if p.Status.PaymentStatus == payment.PaymentStatusSuccess {
+		paid += cents(floatFromNumeric(p.Amount))
+	}
+	return max(min(wallet, paid-cents(floatFromNumeric(refunded))), 0)
+}
+
+// postOrderWallet posts p to the wallet of the order's customer within tx.
+func postOrderWallet(ctx context.Context, tx pgx.Tx, orderID int32, p walletPosting) error {
+	userID, err := payment.New(tx).GetOrderUserID(ctx, orderID)
+	if err != nil {
+		return err
+	}
+	p.UserID = uuidString(userID)
+	p.OrderID = &orderID
+	_, err = postToWallet(ctx, ledger.New(tx), p)
+	return err
+}
@@ -0,0 +1,98 @@
+package repository
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/AndikaPrasetia/wash-shoe/internal/sqlc/payment"
+)
+
+func TestCheckWebhookPayment(t *testing.T) {
+	withStatus := func(method payment.PaymentMethod, status payment.PaymentStatus) payment.Payment {
+		return payment.Payment{
+			ID:     1,
+			Method: method,
+			Status: payment.NullPaymentStatus{PaymentStatus: status, Valid: true},
+		}
+	}
+
+	tests := []struct {
+		name    string
+		payment payment.Payment
+		method  string
+		want    bool
+		wantErr error
+	}{
+		{"pending", withStatus(payment.PaymentMethodDANA, payment.PaymentStatusPending), "DANA", true, nil},
+		{"other provider", withStatus(payment.PaymentMethodOVO, payment.PaymentStatusPending), "DANA", false, ErrPaymentNotFound},
+		{"redelivered success", withStatus(payment.PaymentMethodDANA, payment.PaymentStatusSuccess), "DANA", false, nil},
+		{"redelivered failure", withStatus(payment.PaymentMethodDANA, payment.PaymentStatusFailed), "DANA", false, nil},
+		{"refunded", withStatus(payment.PaymentMethodDANA, payment.PaymentStatusRefunded), "DANA", false, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := checkWebhookPayment(tt.payment, tt.method)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("checkWebhookPayment() error = %v, want %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("checkWebhookPayment() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCanMoveOrder(t *testing.T) {
+	tests := []struct {
+		from, to payment.OrderStatus
+		want     bool
+	}{
+		{payment.OrderStatusPending, payment.OrderStatusProcessing, true},
+		{payment.OrderStatusPending, payment.OrderStatusCancelled, true},
+		{payment.OrderStatusProcessing, payment.OrderStatusCancelled, true},
+		// a full refund can't cancel orders that are being worked on
+		{payment.OrderStatusCleaning, payment.OrderStatusCancelled, false},
+		{payment.OrderStatusDelivered, payment.OrderStatusCancelled, false},
+		// a late payment doesn't reopen a cancelled order
+		{payment.OrderStatusCancelled, payment.OrderStatusProcessing, false},
+		{payment.OrderStatusProcessing, payment.OrderStatusProcessing, false},
+	}
+	for _, tt := range tests {
+		if got := canMoveOrder(tt.from, tt.to); got != tt.want {
+			t.Errorf("canMoveOrder(%s, %s) = %v, want %v", tt.from, tt.to, got, tt.want)
+		}
+	}
+}
+
+func TestWalletLeft(t *testing.T) {
+	// 30000 charged through the provider, 20000 paid from the wallet
+	withStatus := func(status payment.PaymentStatus) payment.Payment {
+		return payment.Payment{
+			Amount:       numericFromFloat(30000),
+			WalletAmount: numericFromFloat(20000),
+			Status:       payment.NullPaymentStatus{PaymentStatus: status, Valid: true},
+		}
+	}
+
+	tests := []struct {
+		name     string
+		payment  payment.Payment
+		refunded float64
+		want     int64
+	}{
+		{"pending", withStatus(payment.PaymentStatusPending), 0, 2000000},
+		{"pending, wallet given back", withStatus(payment.PaymentStatusPending), 20000, 0},
+		{"paid", withStatus(payment.PaymentStatusSuccess), 0, 2000000},
+		{"paid, provider part refunded", withStatus(payment.PaymentStatusSuccess), 30000, 2000000},
+		{"paid, partly refunded into the wallet part", withStatus(payment.PaymentStatusSuccess), 45000, 500000},
+		{"paid, fully refunded", withStatus(payment.PaymentStatusSuccess), 50000, 0},
+		{"no wallet part", payment.Payment{Amount: numericFromFloat(30000), WalletAmount: numericFromFloat(0)}, 0, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := walletLeft(tt.payment, numericFromFloat(tt.refunded)); got != tt.want {
+				t.Errorf("walletLeft() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,78 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"github.com/AndikaPrasetia/wash-shoe/internal/model"
+	"github.com/AndikaPrasetia/wash-shoe/internal/sqlc/photo"
+	"github.com/jackc/pgx/v5"
+)
+
+var ErrPhotoNotFound = errors.New("photo not found")
+
+type PhotoRepo interface {
+	Create(ctx context.Context, p model.Photo) (model.Photo, error)
+	ListByOrder(ctx context.Context, orderID int32) ([]model.Photo, error)
+	FindByID(ctx context.Context, orderID, id int32) (*model.Photo, error)
+}
+
+type photoRepo struct {
+	q photo.Querier
+}
+
+func NewPhotoRepo(q photo.Querier) PhotoRepo {
+	return &photoRepo{q: q}
+}
+
+func (r *photoRepo) Create(ctx context.Context, p model.Photo) (model.Photo, error) {
+	uploadedBy, err := pgUUID(p.UploadedBy)
+	if err != nil {
+		return model.Photo{}, err
+	}
+	created, err := r.q.CreatePhotoEvidence(ctx, photo.CreatePhotoEvidenceParams{
+		OrderID:    p.OrderID,
+		PhotoUrl:   p.Key,
+		Type:       photo.PhotoType(p.Type),
+		UploadedBy: uploadedBy,
+	})
+	if err != nil {
+		return model.Photo{}, err
+	}
+	return toPhotoModel(created), nil
+}
+
+func (r *photoRepo) ListByOrder(ctx context.Context, orderID int32) ([]model.Photo, error) {
+	ps, err := r.q.ListPhotoEvidencesByOrder(ctx, orderID)
+	if err != nil {
+		return nil, err
+	}
+	res := make([]model.Photo, len(ps))
+	for i, p := range ps {
+		res[i] = toPhotoModel(p)
+	}
+	return res, nil
+}
+
+func (r *photoRepo) FindByID(ctx context.Context, orderID, id int32) (*model.Photo, error) {
+	p, err := r.q.GetPhotoEvidence(ctx, photo.GetPhotoEvidenceParams{ID: id, OrderID: orderID})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrPhotoNotFound
+		}
+		return nil, err
+	}
+	res := toPhotoModel(p)
+	return &res, nil
+}
+
+func toPhotoModel(p photo.PhotoEvidence) model.Photo {
+	return model.Photo{
+		ID:         p.ID,
+		OrderID:    p.OrderID,
+		Type:       string(p.Type),
+		UploadedBy: uuidString(p.UploadedBy),
+		UploadedAt: p.UploadedAt.Time,
+		Key:        p.PhotoUrl,
+	}
+}
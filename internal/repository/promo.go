@@ -0,0 +1,112 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"github.com/AndikaPrasetia/wash-shoe/internal/model"
+	"github.com/AndikaPrasetia/wash-shoe/internal/sqlc/promo"
+	"github.com/jackc/pgx/v5"
+)
+
+var (
+	ErrPromoNotFound  = errors.New("promo not found")
+	ErrPromoCodeTaken = errors.New("promo code already exists")
+)
+
+type PromoRepo interface {
+	List(ctx context.Context) ([]model.Promo, error)
+	FindByCode(ctx context.Context, code string) (*model.Promo, error)
+	Create(ctx context.Context, p model.Promo) (model.Promo, error)
+	Update(ctx context.Context, p model.Promo) (model.Promo, error)
+	Delete(ctx context.Context, code string) error
+}
+
+type promoRepo struct {
+	q promo.Querier
+}
+
+func NewPromoRepo(q promo.Querier) PromoRepo {
+	return &promoRepo{q: q}
+}
+
+func (r *promoRepo) List(ctx context.Context) ([]model.Promo, error) {
+	ps, err := r.q.ListPromos(ctx)
+	if err != nil {
+		return nil, err
+	}
+	res := make([]model.Promo, len(ps))
+	for i, p := range ps {
+		res[i] = toPromoModel(p)
+	}
+	return res, nil
+}
+
+func (r *promoRepo) FindByCode(ctx context.Context, code string) (*model.Promo, error) {
+	p, err := r.q.GetPromoByCode(ctx, code)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrPromoNotFound
+		}
+		return nil, err
+	}
+	res := toPromoModel(p)
+	return &res, nil
+}
+
+func (r *promoRepo) Create(ctx context.Context, p model.Promo) (model.Promo, error) {
+	created, err := r.q.CreatePromo(ctx, promo.CreatePromoParams{
+		Code:          p.Code,
+		DiscountType:  p.DiscountType,
+		DiscountValue: numericFromFloat(p.DiscountValue),
+		MaxUsage:      int4FromPtr(p.MaxUsage),
+		ValidUntil:    timestamptzFromPtr(p.ValidUntil),
+	})
+	if err != nil {
+		if isUniqueViolation(err) {
+			return model.Promo{}, ErrPromoCodeTaken
+		}
+		return model.Promo{}, err
+	}
+	return toPromoModel(created), nil
+}
+
+func (r *promoRepo) Update(ctx context.Context, p model.Promo) (model.Promo, error) {
+	updated, err := r.q.UpdatePromo(ctx, promo.UpdatePromoParams{
+		Code:          p.Code,
+		DiscountType:  p.DiscountType,
+		DiscountValue: numericFromFloat(p.DiscountValue),
+		MaxUsage:      int4FromPtr(p.MaxUsage),
+		ValidUntil:    timestamptzFromPtr(p.ValidUntil),
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return model.Promo{}, ErrPromoNotFound
+		}
+		return model.Promo{}, err
+	}
+	return toPromoModel(updated), nil
+}
+
+func (r *promoRepo) Delete(ctx context.Context, code string) error {
+	n, err := r.q.DeletePromo(ctx, code)
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return ErrPromoNotFound
+	}
+	return nil
+}
+
+func toPromoModel(p promo.Promo) model.Promo {
+	return model.Promo{
+		Code:          p.Code,
+		DiscountType:  p.DiscountType,
+		DiscountValue: floatFromNumeric(p.DiscountValue),
+		MaxUsage:      int4Ptr(p.MaxUsage),
+		UsedCount:     p.UsedCount.Int32,
+		ValidUntil:    timePtr(p.ValidUntil),
+		CreatedAt:     p.CreatedAt.Time,
+	}
+}
@@ -0,0 +1,128 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"github.com/AndikaPrasetia/wash-shoe/internal/model"
+	"github.com/AndikaPrasetia/wash-shoe/internal/sqlc/referral"
+	"github.com/jackc/pgx/v5"
+)
+
+var (
+	ErrReferralCodeNotFound = errors.New("referral code not found")
+	ErrReferralCodeTaken    = errors.New("referral code already taken")
+	ErrAlreadyReferred      = errors.New("user already referred")
+)
+
+type ReferralRepo interface {
+	FindCode(ctx context.Context, userID string) (string, error)
+	// CreateCode stores code for the user, or returns the code they already
+	// have. ErrReferralCodeTaken means code belongs to someone else.
+	CreateCode(ctx context.Context, userID, code string) (string, error)
+	FindReferrer(ctx context.Context, code string) (string, error)
+	Create(ctx context.Context, rf model.Referral) error
+	ListByReferrer(ctx context.Context, userID string) ([]model.Referral, error)
+}
+
+type referralRepo struct {
+	q referral.Querier
+}
+
+func NewReferralRepo(q referral.Querier) ReferralRepo {
+	return &referralRepo{q: q}
+}
+
+func (r *referralRepo) FindCode(ctx context.Context, userID string) (string, error) {
+	uid, err := pgUUID(userID)
+	if err != nil {
+		return "", err
+	}
+	code, err := r.q.GetReferralCodeByUser(ctx, uid)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return "", ErrReferralCodeNotFound
+		}
+		return "", err
+	}
+	return code, nil
+}
+
+func (r *referralRepo) CreateCode(ctx context.Context, userID, code string) (string, error) {
+	uid, err := pgUUID(userID)
+	if err != nil {
+		return "", err
+	}
+	code, err = r.q.CreateReferralCode(ctx, referral.CreateReferralCodeParams{
+		UserID: uid,
+		Code:   code,
+	})
+	if err != nil {
+		if isUniqueViolation(err) {
+			return "", ErrReferralCodeTaken
+		}
+		return "", err
+	}
+	return code, nil
+}
+
+func (r *referralRepo) FindReferrer(ctx context.Context, code string) (string, error) {
+	uid, err := r.q.GetReferrerByCode(ctx, code)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return "", ErrReferralCodeNotFound
+		}
+		return "", err
+	}
+	return uuidString(uid), nil
+}
+
+func (r *referralRepo) Create(ctx context.Context, rf model.Referral) error {
+	referrerID, err := pgUUID(rf.ReferrerID)
+	if err != nil {
+		return err
+	}
+	referredID, err := pgUUID(rf.ReferredID)
+	if err != nil {
+		return err
+	}
+	err = r.q.CreateReferral(ctx, referral.CreateReferralParams{
+		ReferrerID:     referrerID,
+		ReferredEmail:  rf.ReferredEmail,
+		ReferredID:     referredID,
+		CashbackAmount: numericFromFloat(rf.CashbackAmount),
+	})
+	if err != nil {
+		// referrals.referred_id is unique
+		if isUniqueViolation(err) {
+			return ErrAlreadyReferred
+		}
+		return err
+	}
+	return nil
+}
+
+func (r *referralRepo) ListByReferrer(ctx context.Context, userID string) ([]model.Referral, error) {
+	uid, err := pgUUID(userID)
+	if err != nil {
+		return nil, err
+	}
+	rs, err := r.q.ListReferralsByReferrer(ctx, uid)
+	if err != nil {
+		return nil, err
+	}
+	res := make([]model.Referral, len(rs))
+	for i, rf := range rs {
+		res[i] = model.Referral{
+			ID:             rf.ID,
+			ReferrerID:     uuidString(rf.ReferrerID),
+			ReferredEmail:  rf.ReferredEmail,
+			ReferredID:     uuidString(rf.ReferredID),
+			IsCompleted:    rf.IsCompleted.Bool,
+			CashbackAmount: floatFromNumeric(rf.CashbackAmount),
+			CreatedAt:      rf.CreatedAt.Time,
+			CompletedAt:    timePtr(rf.CompletedAt),
+		}
+	}
+	return res, nil
+}
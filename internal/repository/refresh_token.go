@@ -0,0 +1,290 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"time"
+
+	"github.com/AndikaPrasetia/wash-shoe/internal/model"
+	"github.com/AndikaPrasetia/wash-shoe/internal/sqlc/user"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+var (
+	ErrRefreshTokenRevoked = errors.New("refresh token has been revoked")
+	ErrRefreshTokenReused  = errors.New("refresh token was already used")
+	ErrRefreshTokenRetried = errors.New("refresh token was rotated moments ago")
+	ErrSessionNotFound     = errors.New("session not found")
+)
+
+// RefreshRetryGrace is how long after its rotation a refresh token may be
+// presented again as a retry, e.g. by a client that lost the response or
+// sent the refresh twice, without counting as reuse.
+const RefreshRetryGrace = 30 * time.Second
+
+// RefreshTokenRepo stores refresh tokens by hash. Tokens issued at login
+// start a family, and every refresh rotates the presented token into the
+// next one of its family. Each family is a session in auth.sessions, with
+// the same ID.
+type RefreshTokenRepo interface {
+	// StartSession stores the session s and t as the first token of its
+	// family.
+	StartSession(ctx context.Context, s model.Session, t model.RefreshToken) (model.Session, error)
+	// Rotate replaces the token stored under tokenHash by next, which must
+	// name the same family, and records device as the session's latest use.
+	// A token that was rotated already has been presented twice, so its
+	// whole family is revoked, the reuse is recorded in the audit log and
+	// ErrRefreshTokenReused is returned. Within RefreshRetryGrace of the
+	// rotation, while its successor is unused, that is a retry instead:
+	// nothing changes and the successor is returned with
+	// ErrRefreshTokenRetried.
+	Rotate(ctx context.Context, tokenHash string, next model.RefreshToken, device model.Device) (model.RefreshToken, error)
+	// ListSessions returns the user's active sessions, most recently used
+	// first.
+	ListSessions(ctx context.Context, userID string) ([]model.Session, error)
+	// RevokeSession revokes the tokens of one of the user's active sessions.
+	RevokeSession(ctx context.Context, userID, sessionID string) error
+	// RevokeOtherSessions revokes every session of the user but keepID and
+	// returns the IDs of the sessions it revoked.
+	RevokeOtherSessions(ctx context.Context, userID, keepID string) ([]string, error)
+}
+
+type refreshTokenRepo struct {
+	db *pgxpool.Pool
+	q  *user.Queries
+}
+
+func NewRefreshTokenRepo(db *pgxpool.Pool) RefreshTokenRepo {
+	return &refreshTokenRepo{db: db, q: user.New(db)}
+}
+
+func (r *refreshTokenRepo) StartSession(ctx context.Context, s model.Session, t model.RefreshToken) (model.Session, error) {
+	id, err := pgUUID(s.ID)
+	if err != nil {
+		return model.Session{}, err
+	}
+	userID, err := pgUUID(s.UserID)
+	if err != nil {
+		return model.Session{}, err
+	}
+
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return model.Session{}, err
+	}
+	defer tx.Rollback(ctx)
+	q := r.q.WithTx(tx)
+
+	row, err := q.CreateSession(ctx, user.CreateSessionParams{
+		ID:         id,
+		UserID:     userID,
+		DeviceName: textFromString(s.Name),
+		UserAgent:  textFromString(s.UserAgent),
+		IpAddress:  textFromString(s.IPAddress),
+	})
+	if err != nil {
+		return model.Session{}, err
+	}
+	t.FamilyID = s.ID
+	if _, err := createRefreshToken(ctx, q, t); err != nil {
+		return model.Session{}, err
+	}
+	return toSessionModel(row), tx.Commit(ctx)
+}
+
+func (r *refreshTokenRepo) Rotate(ctx context.Context, tokenHash string, next model.RefreshToken, device model.Device) (model.RefreshToken, error) {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return model.RefreshToken{}, err
+	}
+	defer tx.Rollback(ctx)
+	q := r.q.WithTx(tx)
+
+	current, err := q.GetRefreshTokenByHashForUpdate(ctx, tokenHash)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return model.RefreshToken{}, ErrTokenNotFound
+		}
+		return model.RefreshToken{}, err
+	}
+	if uuidString(current.UserID) != next.UserID || uuidString(current.FamilyID) != next.FamilyID {
+		return model.RefreshToken{}, ErrTokenNotFound
+	}
+	if current.Revoked.Bool {
+		return model.RefreshToken{}, ErrRefreshTokenRevoked
+	}
+
+	if current.RotatedAt.Valid {
+		if withinRetryGrace(current.RotatedAt.Time, time.Now()) {
+			successor, err := q.GetRefreshTokenByIDForUpdate(ctx, current.ReplacedBy)
+			if err != nil && !errors.Is(err, pgx.ErrNoRows) {
+				return model.RefreshToken{}, err
+			}
+			if err == nil && !successor.RotatedAt.Valid && !successor.Revoked.Bool {
+				return toRefreshTokenModel(successor), ErrRefreshTokenRetried
+			}
+		}
+		if err := q.RevokeRefreshTokenFamily(ctx, current.FamilyID); err != nil {
+			return model.RefreshToken{}, err
+		}
+		err = recordAccountChange(ctx, q, next.UserID, "refresh_token_reuse", map[string]any{
+			"user_id":   next.UserID,
+			"family_id": uuidString(current.FamilyID),
+			"token_id":  uuidString(current.ID),
+		})
+		if err != nil {
+			return model.RefreshToken{}, err
+		}
+		if err := tx.Commit(ctx); err != nil {
+			return model.RefreshToken{}, err
+		}
+		return model.RefreshToken{}, ErrRefreshTokenReused
+	}
+
+	created, err := createRefreshToken(ctx, q, next)
+	if err != nil {
+		return model.RefreshToken{}, err
+	}
+	newID, err := pgUUID(created.ID)
+	if err != nil {
+		return model.RefreshToken{}, err
+	}
+	err = q.MarkRefreshTokenRotated(ctx, user.MarkRefreshTokenRotatedParams{
+		ID:         current.ID,
+		ReplacedBy: newID,
+	})
+	if err != nil {
+		return model.RefreshToken{}, err
+	}
+	err = q.TouchSession(ctx, user.TouchSessionParams{
+		ID:        current.FamilyID,
+		UserAgent: textFromString(device.UserAgent),
+		IpAddress: textFromString(device.IPAddress),
+	})
+	if err != nil {
+		return model.RefreshToken{}, err
+	}
+	return created, tx.Commit(ctx)
+}
+
+func (r *refreshTokenRepo) ListSessions(ctx context.Context, userID string) ([]model.Session, error) {
+	uid, err := pgUUID(userID)
+	if err != nil {
+		return nil, err
+	}
+	rows, err := r.q.ListActiveSessions(ctx, uid)
+	if err != nil {
+		return nil, err
+	}
+	res := make([]model.Session, len(rows))
+	for i, row := range rows {
+		res[i] = toSessionModel(row)
+	}
+	return res, nil
+}
+
+func (r *refreshTokenRepo) RevokeSession(ctx context.Context, userID, sessionID string) error {
+	uid, err := pgUUID(userID)
+	if err != nil {
+		return err
+	}
+	sid, err := uuid.Parse(sessionID)
+	if err != nil {
+		return ErrSessionNotFound
+	}
+	n, err := r.q.RevokeUserRefreshTokenFamily(ctx, user.RevokeUserRefreshTokenFamilyParams{
+		UserID:   uid,
+		FamilyID: pgtype.UUID{Bytes: sid, Valid: true},
+	})
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return ErrSessionNotFound
+	}
+	return nil
+}
+
+func (r *refreshTokenRepo) RevokeOtherSessions(ctx context.Context, userID, keepID string) ([]string, error) {
+	uid, err := pgUUID(userID)
+	if err != nil {
+		return nil, err
+	}
+	keep, err := pgUUID(keepID)
+	if err != nil {
+		return nil, err
+	}
+	families, err := r.q.RevokeOtherRefreshTokenFamilies(ctx, user.RevokeOtherRefreshTokenFamiliesParams{
+		UserID:   uid,
+		FamilyID: keep,
+	})
+	if err != nil {
+		return nil, err
+	}
+	// a family has a row for every rotation
+	var revoked []string
+	for _, f := range families {
+		if id := uuidString(f); !slices.Contains(revoked, id) {
+			revoked = append(revoked, id)
+		}
+	}
+	return revoked, nil
+}
+
+// withinRetryGrace reports whether a token rotated at rotatedAt is still in
+// its RefreshRetryGrace at now.
+func withinRetryGrace(rotatedAt, now time.Time) bool {
+	return now.Sub(rotatedAt) < RefreshRetryGrace
+}
+
+func createRefreshToken(ctx context.Context, q *user.Queries, t model.RefreshToken) (model.RefreshToken, error) {
+	userID, err := pgUUID(t.UserID)
+	if err != nil {
+		return model.RefreshToken{}, err
+	}
+	familyID, err := pgUUID(t.FamilyID)
+	if err != nil {
+		return model.RefreshToken{}, err
+	}
+	row, err := q.CreateRefreshToken(ctx, user.CreateRefreshTokenParams{
+		UserID:    userID,
+		TokenHash: t.TokenHash,
+		ExpiresAt: timestamptzFromPtr(&t.ExpiresAt),
+		FamilyID:  familyID,
+	})
+	if err != nil {
+		return model.RefreshToken{}, err
+	}
+	return toRefreshTokenModel(row), nil
+}
+
+func toSessionModel(row user.AuthSession) model.Session {
+	return model.Session{
+		ID:     uuidString(row.ID),
+		UserID: uuidString(row.UserID),
+		Device: model.Device{
+			Name:      row.DeviceName.String,
+			UserAgent: row.UserAgent.String,
+			IPAddress: row.IpAddress.String,
+		},
+		CreatedAt:  row.CreatedAt.Time,
+		LastUsedAt: row.LastUsedAt.Time,
+	}
+}
+
+func toRefreshTokenModel(row user.AuthRefreshToken) model.RefreshToken {
+	return model.RefreshToken{
+		ID:        uuidString(row.ID),
+		UserID:    uuidString(row.UserID),
+		FamilyID:  uuidString(row.FamilyID),
+		TokenHash: row.TokenHash,
+		ExpiresAt: row.ExpiresAt.Time,
+		CreatedAt: row.CreatedAt.Time,
+		RotatedAt: timePtr(row.RotatedAt),
+		Revoked:   row.Revoked.Bool,
+	}
+}
@@ -0,0 +1,123 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/AndikaPrasetia/wash-shoe/internal/model"
+	"github.com/google/uuid"
+)
+
+func TestWithinRetryGrace(t *testing.T) {
+	now := time.Now()
+	tests := []struct {
+		name      string
+		rotatedAt time.Time
+		want      bool
+	}{
+		{"just rotated", now, true},
+		{"inside the grace", now.Add(-RefreshRetryGrace + time.Second), true},
+		{"at the end of the grace", now.Add(-RefreshRetryGrace), false},
+		{"long ago", now.Add(-time.Hour), false},
+		// the database clock may run a little ahead
+		{"rotated in the future", now.Add(time.Second), true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := withinRetryGrace(tt.rotatedAt, now); got != tt.want {
+				t.Errorf("withinRetryGrace() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRotate(t *testing.T) {
+	db := testDB(t)
+	ctx := context.Background()
+	userID, _ := testCustomer(t, db, 0)
+	repo := NewRefreshTokenRepo(db)
+
+	sessionID := uuid.NewString()
+	// token hashes are unique across all users
+	hash := func(name string) string { return sessionID + "/" + name }
+	token := func(name string) model.RefreshToken {
+		return model.RefreshToken{UserID: userID, FamilyID: sessionID, TokenHash: hash(name), ExpiresAt: time.Now().Add(time.Hour)}
+	}
+	_, err := repo.StartSession(ctx, model.Session{ID: sessionID, UserID: userID}, token("t0"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// t0 -> t1
+	t1, err := repo.Rotate(ctx, hash("t0"), token("t1"), model.Device{})
+	if err != nil {
+		t.Fatalf("Rotate(t0) error = %v", err)
+	}
+	if t1.TokenHash != hash("t1") {
+		t.Errorf("Rotate(t0) = %q, want t1", t1.TokenHash)
+	}
+
+	// t0 again right away is a retry, which gets t1 and changes nothing
+	got, err := repo.Rotate(ctx, hash("t0"), token("t1-retry"), model.Device{})
+	if !errors.Is(err, ErrRefreshTokenRetried) {
+		t.Fatalf("retried Rotate(t0) error = %v, want %v", err, ErrRefreshTokenRetried)
+	}
+	if got.ID != t1.ID || got.TokenHash != hash("t1") {
+		t.Errorf("retried Rotate(t0) = %+v, want t1 %s", got, t1.ID)
+	}
+
+	// t1 -> t2, after which t0 is no longer a retry but reuse
+	if _, err := repo.Rotate(ctx, hash("t1"), token("t2"), model.Device{}); err != nil {
+		t.Fatalf("Rotate(t1) error = %v", err)
+	}
+	if _, err := repo.Rotate(ctx, hash("t0"), token("t2-reuse"), model.Device{}); !errors.Is(err, ErrRefreshTokenReused) {
+		t.Fatalf("reused Rotate(t0) error = %v, want %v", err, ErrRefreshTokenReused)
+	}
+	// the reuse revoked the whole family
+	if _, err := repo.Rotate(ctx, hash("t2"), token("t3"), model.Device{}); !errors.Is(err, ErrRefreshTokenRevoked) {
+		t.Fatalf("Rotate(t2) error = %v, want %v", err, ErrRefreshTokenRevoked)
+	}
+	var reuses int
+	err = db.QueryRow(ctx, `SELECT count(*) FROM auth.audit_log WHERE action = 'refresh_token_reuse' AND details->>'family_id' = $1`, sessionID).Scan(&reuses)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if reuses != 1 {
+		t.Errorf("%d reuses in the audit log, want 1", reuses)
+	}
+}
+
+func TestRotateAfterRetryGrace(t *testing.T) {
+	db := testDB(t)
+	ctx := context.Background()
+	userID, _ := testCustomer(t, db, 0)
+	repo := NewRefreshTokenRepo(db)
+
+	sessionID := uuid.NewString()
+	// token hashes are unique across all users
+	hash := func(name string) string { return sessionID + "/" + name }
+	token := func(name string) model.RefreshToken {
+		return model.RefreshToken{UserID: userID, FamilyID: sessionID, TokenHash: hash(name), ExpiresAt: time.Now().Add(time.Hour)}
+	}
+	if _, err := repo.StartSession(ctx, model.Session{ID: sessionID, UserID: userID}, token("t0")); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := repo.Rotate(ctx, hash("t0"), token("t1"), model.Device{}); err != nil {
+		t.Fatal(err)
+	}
+	_, err := db.Exec(ctx, `UPDATE auth.refresh_tokens SET rotated_at = rotated_at - $1::interval WHERE token_hash = $2`,
+		RefreshRetryGrace.String(), hash("t0"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// even with t1 unused, t0 is reuse once the grace is over
+	if _, err := repo.Rotate(ctx, hash("t0"), token("t1-late"), model.Device{}); !errors.Is(err, ErrRefreshTokenReused) {
+		t.Fatalf("late Rotate(t0) error = %v, want %v", err, ErrRefreshTokenReused)
+	}
+	if _, err := repo.Rotate(ctx, hash("t1"), token("t2"), model.Device{}); !errors.Is(err, ErrRefreshTokenRevoked) {
+		t.Fatalf("Rotate(t1) error = %v, want %v", err, ErrRefreshTokenRevoked)
+	}
+}
@@ -0,0 +1,87 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"github.com/AndikaPrasetia/wash-shoe/internal/model"
+	"github.com/AndikaPrasetia/wash-shoe/internal/sqlc/review"
+)
+
+var ErrOrderAlreadyReviewed = errors.New("order already reviewed")
+
+type ReviewRepo interface {
+	Create(ctx context.Context, rv model.Review) (model.Review, error)
+	// RatingCounts returns the number of reviews per rating for a service
+	// type.
+	RatingCounts(ctx context.Context, serviceTypeID int32) (map[int32]int32, error)
+	RecentComments(ctx context.Context, serviceTypeID int32, limit int32) ([]model.ReviewComment, error)
+}
+
+type reviewRepo struct {
+	q review.Querier
+}
+
+func NewReviewRepo(q review.Querier) ReviewRepo {
+	return &reviewRepo{q: q}
+}
+
+func (r *reviewRepo) Create(ctx context.Context, rv model.Review) (model.Review, error) {
+	userID, err := pgUUID(rv.UserID)
+	if err != nil {
+		return model.Review{}, err
+	}
+	created, err := r.q.CreateReview(ctx, review.CreateReviewParams{
+		UserID:  userID,
+		OrderID: rv.OrderID,
+		Rating:  rv.Rating,
+		Comment: textFromString(rv.Comment),
+	})
+	if err != nil {
+		// reviews.order_id is unique
+		if isUniqueViolation(err) {
+			return model.Review{}, ErrOrderAlreadyReviewed
+		}
+		return model.Review{}, err
+	}
+	return model.Review{
+		ID:        created.ID,
+		UserID:    uuidString(created.UserID),
+		OrderID:   created.OrderID,
+		Rating:    created.Rating,
+		Comment:   created.Comment.String,
+		CreatedAt: created.CreatedAt.Time,
+	}, nil
+}
+
+func (r *reviewRepo) RatingCounts(ctx context.Context, serviceTypeID int32) (map[int32]int32, error) {
+	rows, err := r.q.ListServiceTypeRatingCounts(ctx, serviceTypeID)
+	if err != nil {
+		return nil, err
+	}
+	res := make(map[int32]int32, len(rows))
+	for _, row := range rows {
+		res[row.Rating] = row.Count
+	}
+	return res, nil
+}
+
+func (r *reviewRepo) RecentComments(ctx context.Context, serviceTypeID int32, limit int32) ([]model.ReviewComment, error) {
+	rows, err := r.q.ListRecentServiceTypeComments(ctx, review.ListRecentServiceTypeCommentsParams{
+		ServiceTypeID: serviceTypeID,
+		RowLimit:      limit,
+	})
+	if err != nil {
+		return nil, err
+	}
+	res := make([]model.ReviewComment, len(rows))
+	for i, row := range rows {
+		res[i] = model.ReviewComment{
+			Rating:       row.Rating,
+			Comment:      row.Comment.String,
+			ReviewerName: row.ReviewerName,
+			CreatedAt:    row.CreatedAt.Time,
+		}
+	}
+	return res, nil
+}
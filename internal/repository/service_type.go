@@ -0,0 +1,121 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"github.com/AndikaPrasetia/wash-shoe/internal/model"
+	"github.com/AndikaPrasetia/wash-shoe/internal/sqlc/catalog"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+var (
+	ErrServiceTypeNotFound  = errors.New("service type not found")
+	ErrServiceTypeNameTaken = errors.New("service type name already exists")
+)
+
+type ServiceTypeRepo interface {
+	List(ctx context.Context, ecoFriendlyOnly bool, sort string) ([]model.ServiceType, error)
+	FindByID(ctx context.Context, id int32) (*model.ServiceType, error)
+	Create(ctx context.Context, st model.ServiceType) (model.ServiceType, error)
+	Update(ctx context.Context, st model.ServiceType) (model.ServiceType, error)
+	Archive(ctx context.Context, id int32) error
+}
+
+type serviceTypeRepo struct {
+	q catalog.Querier
+}
+
+func NewServiceTypeRepo(q catalog.Querier) ServiceTypeRepo {
+	return &serviceTypeRepo{q: q}
+}
+
+func (r *serviceTypeRepo) List(ctx context.Context, ecoFriendlyOnly bool, sort string) ([]model.ServiceType, error) {
+	sts, err := r.q.ListServiceTypes(ctx, catalog.ListServiceTypesParams{
+		EcoFriendlyOnly: ecoFriendlyOnly,
+		Sort:            sort,
+	})
+	if err != nil {
+		return nil, err
+	}
+	res := make([]model.ServiceType, len(sts))
+	for i, st := range sts {
+		res[i] = toServiceTypeModel(st)
+	}
+	return res, nil
+}
+
+func (r *serviceTypeRepo) FindByID(ctx context.Context, id int32) (*model.ServiceType, error) {
+	st, err := r.q.GetServiceTypeByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrServiceTypeNotFound
+		}
+		return nil, err
+	}
+	res := toServiceTypeModel(st)
+	return &res, nil
+}
+
+func (r *serviceTypeRepo) Create(ctx context.Context, st model.ServiceType) (model.ServiceType, error) {
+	created, err := r.q.CreateServiceType(ctx, catalog.CreateServiceTypeParams{
+		Name:                   st.Name,
+		Description:            textFromString(st.Description),
+		BasePrice:              numericFromFloat(st.BasePrice),
+		EstimatedDurationHours: int4FromPtr(st.EstimatedDurationHours),
+		IsEcoFriendly:          pgtype.Bool{Bool: st.IsEcoFriendly, Valid: true},
+	})
+	if err != nil {
+		if isUniqueViolation(err) {
+			return model.ServiceType{}, ErrServiceTypeNameTaken
+		}
+		return model.ServiceType{}, err
+	}
+	return toServiceTypeModel(created), nil
+}
+
+func (r *serviceTypeRepo) Update(ctx context.Context, st model.ServiceType) (model.ServiceType, error) {
+	updated, err := r.q.UpdateServiceType(ctx, catalog.UpdateServiceTypeParams{
+		ID:                     st.ID,
+		Name:                   st.Name,
+		Description:            textFromString(st.Description),
+		BasePrice:              numericFromFloat(st.BasePrice),
+		EstimatedDurationHours: int4FromPtr(st.EstimatedDurationHours),
+		IsEcoFriendly:          pgtype.Bool{Bool: st.IsEcoFriendly, Valid: true},
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return model.ServiceType{}, ErrServiceTypeNotFound
+		}
+		if isUniqueViolation(err) {
+			return model.ServiceType{}, ErrServiceTypeNameTaken
+		}
+		return model.ServiceType{}, err
+	}
+	return toServiceTypeModel(updated), nil
+}
+
+func (r *serviceTypeRepo) Archive(ctx context.Context, id int32) error {
+	n, err := r.q.ArchiveServiceType(ctx, id)
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return ErrServiceTypeNotFound
+	}
+	return nil
+}
+
+func toServiceTypeModel(st catalog.ServiceType) model.ServiceType {
+	return model.ServiceType{
+		ID:                     st.ID,
+		Name:                   st.Name,
+		Description:            st.Description.String,
+		BasePrice:              floatFromNumeric(st.BasePrice),
+		EstimatedDurationHours: int4Ptr(st.EstimatedDurationHours),
+		IsEcoFriendly:          st.IsEcoFriendly.Bool,
+		CreatedAt:              st.CreatedAt.Time,
+		ArchivedAt:             timePtr(st.ArchivedAt),
+	}
+}
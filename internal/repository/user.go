@@ -48,14 +48,20 @@ func (r *userRepo) Create(ctx context.Context, arg user.CreatePublicUserParams)
 }
 
 func (r *userRepo) FindByID(ctx context.Context, id pgtype.UUID) (*model.User, error) {
-	u, err := r.q.GetUserByID(ctx, id)
+	u, err := r.q.GetUserProfileByID(ctx, id)
 	if err != nil {
+		if errors.Is(err, sqlErrNoRows) {
+			return nil, ErrUserNotFound
+		}
 		return nil, err
 	}
 	return &model.User{
 		ID:          u.ID.String(),
 		FullName:    u.FullName,
+		Email:       u.Email,
 		PhoneNumber: u.PhoneNumber.String,
+		Provider:    u.Provider.String,
+		ProviderID:  u.ProviderID.String,
 		Role:        u.Role,
 		CreatedAt:   u.CreatedAt.Time,
 		UpdatedAt:   u.UpdatedAt.Time,
@@ -84,12 +90,17 @@ func (r *userRepo) FindByEmail(ctx context.Context, email string) (*model.User,
 func (r *userRepo) Update(ctx context.Context, arg user.UpdatePublicUserParams) (model.User, error) {
 	u, err := r.q.UpdatePublicUser(ctx, arg)
 	if err != nil {
+		if errors.Is(err, sqlErrNoRows) {
+			return model.User{}, ErrUserNotFound
+		}
 		return model.User{}, err
 	}
 	return model.User{
 		ID:          u.ID.String(),
 		FullName:    u.FullName,
 		PhoneNumber: u.PhoneNumber.String,
+		Provider:    u.Provider.String,
+		ProviderID:  u.ProviderID.String,
 		Role:        u.Role,
 		CreatedAt:   u.CreatedAt.Time,
 		UpdatedAt:   u.UpdatedAt.Time,
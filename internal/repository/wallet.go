@@ -0,0 +1,230 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/AndikaPrasetia/wash-shoe/internal/model"
+	"github.com/AndikaPrasetia/wash-shoe/internal/sqlc/ledger"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+var ErrInsufficientBalance = errors.New("insufficient wallet balance")
+
+// sqlStateForeignKeyViolation is raised when a wallet is opened for a user
+// that doesn't exist.
+const sqlStateForeignKeyViolation = "23503"
+
+// Ledger transaction kinds.
+const (
+	ledgerKindReferralCashback     = "referral_cashback"
+	ledgerKindOrderPayment         = "order_payment"
+	ledgerKindOrderPaymentReversal = "order_payment_reversal"
+	ledgerKindRefund               = "refund"
+	ledgerKindAdjustment           = "adjustment"
+)
+
+// ledgerContraAccounts names, per transaction kind, the system account that
+// balances the wallet side of the transaction.
+var ledgerContraAccounts = map[string]string{
+	ledgerKindReferralCashback:     "referral_cashback",
+	ledgerKindOrderPayment:         "order_payments",
+	ledgerKindOrderPaymentReversal: "order_payments",
+	ledgerKindRefund:               "refunds",
+	ledgerKindAdjustment:           "adjustments",
+}
+
+type WalletRepo interface {
+	Balance(ctx context.Context, userID string) (float64, error)
+	// Statement returns wallet entries newest first.
+	Statement(ctx context.Context, userID string, limit, offset int32) ([]model.WalletEntry, error)
+	// Adjust credits (or, when negative, debits) a wallet on behalf of an
+	// admin and writes the adjustment to auth.audit_log.
+	Adjust(ctx context.Context, userID string, amount float64, reason, actorID string) (model.WalletEntry, error)
+}
+
+type walletRepo struct {
+	db *pgxpool.Pool
+	q  *ledger.Queries
+}
+
+func NewWalletRepo(db *pgxpool.Pool) WalletRepo {
+	return &walletRepo{db: db, q: ledger.New(db)}
+}
+
+func (r *walletRepo) Balance(ctx context.Context, userID string) (float64, error) {
+	uid, err := pgUUID(userID)
+	if err != nil {
+		return 0, err
+	}
+	balance, err := r.q.GetWalletBalance(ctx, uid)
+	if err != nil {
+		return 0, err
+	}
+	return floatFromNumeric(balance), nil
+}
+
+func (r *walletRepo) Statement(ctx context.Context, userID string, limit, offset int32) ([]model.WalletEntry, error) {
+	uid, err := pgUUID(userID)
+	if err != nil {
+		return nil, err
+	}
+	rows, err := r.q.ListWalletEntries(ctx, ledger.ListWalletEntriesParams{
+		UserID:    uid,
+		RowLimit:  limit,
+		RowOffset: offset,
+	})
+	if err != nil {
+		return nil, err
+	}
+	res := make([]model.WalletEntry, len(rows))
+	for i, row := range rows {
+		res[i] = model.WalletEntry{
+			ID:            row.ID,
+			TransactionID: row.TransactionID,
+			Kind:          row.Kind,
+			OrderID:       int4Ptr(row.OrderID),
+			Description:   row.Description,
+			Amount:        floatFromNumeric(row.Amount),
+			Balance:       floatFromNumeric(row.Balance),
+			CreatedAt:     row.CreatedAt.Time,
+		}
+	}
+	return res, nil
+}
+
+func (r *walletRepo) Adjust(ctx context.Context, userID string, amount float64, reason, actorID string) (model.WalletEntry, error) {
+	actor, err := pgUUID(actorID)
+	if err != nil {
+		return model.WalletEntry{}, err
+	}
+
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return model.WalletEntry{}, err
+	}
+	defer tx.Rollback(ctx)
+	q := r.q.WithTx(tx)
+
+	entry, err := postToWallet(ctx, q, walletPosting{
+		UserID:      userID,
+		Amount:      amount,
+		Kind:        ledgerKindAdjustment,
+		Description: reason,
+		CreatedBy:   actorID,
+	})
+	if err != nil {
+		if hasSQLState(err, sqlStateForeignKeyViolation) {
+			return model.WalletEntry{}, ErrUserNotFound
+		}
+		return model.WalletEntry{}, err
+	}
+
+	details, err := json.Marshal(map[string]any{
+		"user_id":        userID,
+		"transaction_id": entry.TransactionID,
+		"amount":         amount,
+		"reason":         reason,
+		"balance":        entry.Balance,
+	})
+	if err != nil {
+		return model.WalletEntry{}, err
+	}
+	err = q.CreateAuditLog(ctx, ledger.CreateAuditLogParams{
+		ActorID: actor,
+		Action:  "wallet_adjustment",
+		Details: details,
+	})
+	if err != nil {
+		return model.WalletEntry{}, err
+	}
+
+	return entry, tx.Commit(ctx)
+}
+
+// walletPosting moves Amount into a user's wallet, or out of it when
+// negative, against the system account of Kind.
+type walletPosting struct {
+	UserID      string
+	Amount      float64
+	Kind        string
+	OrderID     *int32
+	Description string
+	CreatedBy   string
+}
+
+// postToWallet records p as a balanced ledger transaction. q must be bound
+// to a transaction: the wallet account stays locked until it ends, and a
+// posting that would take the balance below zero fails with
+// ErrInsufficientBalance.
+func postToWallet(ctx context.Context, q *ledger.Queries, p walletPosting) (model.WalletEntry, error) {
+	contra, ok := ledgerContraAccounts[p.Kind]
+	if !ok {
+		return model.WalletEntry{}, fmt.Errorf("unknown ledger transaction kind %q", p.Kind)
+	}
+	userID, err := pgUUID(p.UserID)
+	if err != nil {
+		return model.WalletEntry{}, err
+	}
+	createdBy, err := pgUUID(p.CreatedBy)
+	if err != nil {
+		return model.WalletEntry{}, err
+	}
+
+	walletID, err := q.LockWalletAccount(ctx, userID)
+	if err != nil {
+		return model.WalletEntry{}, err
+	}
+	contraID, err := q.GetLedgerAccountIDByCode(ctx, textFromString(contra))
+	if err != nil {
+		return model.WalletEntry{}, fmt.Errorf("load ledger account %s: %w", contra, err)
+	}
+
+	current, err := q.GetLedgerAccountBalance(ctx, walletID)
+	if err != nil {
+		return model.WalletEntry{}, err
+	}
+	balance := cents(floatFromNumeric(current)) + cents(p.Amount)
+	if balance < 0 {
+		return model.WalletEntry{}, ErrInsufficientBalance
+	}
+
+	t, err := q.CreateLedgerTransaction(ctx, ledger.CreateLedgerTransactionParams{
+		Kind:        p.Kind,
+		OrderID:     int4FromPtr(p.OrderID),
+		Description: p.Description,
+		CreatedBy:   createdBy,
+	})
+	if err != nil {
+		return model.WalletEntry{}, err
+	}
+	entry, err := q.CreateLedgerEntry(ctx, ledger.CreateLedgerEntryParams{
+		TransactionID: t.ID,
+		AccountID:     walletID,
+		Amount:        numericFromFloat(p.Amount),
+	})
+	if err != nil {
+		return model.WalletEntry{}, err
+	}
+	_, err = q.CreateLedgerEntry(ctx, ledger.CreateLedgerEntryParams{
+		TransactionID: t.ID,
+		AccountID:     contraID,
+		Amount:        numericFromFloat(-p.Amount),
+	})
+	if err != nil {
+		return model.WalletEntry{}, err
+	}
+
+	return model.WalletEntry{
+		ID:            entry.ID,
+		TransactionID: t.ID,
+		Kind:          t.Kind,
+		OrderID:       int4Ptr(t.OrderID),
+		Description:   t.Description,
+		Amount:        floatFromNumeric(entry.Amount),
+		Balance:       float64(balance) / 100,
+		CreatedAt:     entry.CreatedAt.Time,
+	}, nil
+}
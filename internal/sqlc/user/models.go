@@ -17,12 +17,25 @@ type AuthAuditLog struct {
 }
 
 type AuthRefreshToken struct {
-	ID        pgtype.UUID        `db:"id" json:"id"`
-	UserID    pgtype.UUID        `db:"user_id" json:"user_id"`
-	TokenHash string             `db:"token_hash" json:"token_hash"`
-	ExpiresAt pgtype.Timestamptz `db:"expires_at" json:"expires_at"`
-	CreatedAt pgtype.Timestamptz `db:"created_at" json:"created_at"`
-	Revoked   pgtype.Bool        `db:"revoked" json:"revoked"`
+	ID         pgtype.UUID        `db:"id" json:"id"`
+	UserID     pgtype.UUID        `db:"user_id" json:"user_id"`
+	TokenHash  string             `db:"token_hash" json:"token_hash"`
+	ExpiresAt  pgtype.Timestamptz `db:"expires_at" json:"expires_at"`
+	CreatedAt  pgtype.Timestamptz `db:"created_at" json:"created_at"`
+	Revoked    pgtype.Bool        `db:"revoked" json:"revoked"`
+	FamilyID   pgtype.UUID        `db:"family_id" json:"family_id"`
+	RotatedAt  pgtype.Timestamptz `db:"rotated_at" json:"rotated_at"`
+	ReplacedBy pgtype.UUID        `db:"replaced_by" json:"replaced_by"`
+}
+
+type AuthSession struct {
+	ID         pgtype.UUID        `db:"id" json:"id"`
+	UserID     pgtype.UUID        `db:"user_id" json:"user_id"`
+	DeviceName pgtype.Text        `db:"device_name" json:"device_name"`
+	UserAgent  pgtype.Text        `db:"user_agent" json:"user_agent"`
+	IpAddress  pgtype.Text        `db:"ip_address" json:"ip_address"`
+	CreatedAt  pgtype.Timestamptz `db:"created_at" json:"created_at"`
+	LastUsedAt pgtype.Timestamptz `db:"last_used_at" json:"last_used_at"`
 }
 
 type AuthUser struct {
@@ -33,6 +46,7 @@ type AuthUser struct {
 	UpdatedAt    pgtype.Timestamptz `db:"updated_at" json:"updated_at"`
 	ConfirmedAt  pgtype.Timestamptz `db:"confirmed_at" json:"confirmed_at"`
 	LastSignInAt pgtype.Timestamptz `db:"last_sign_in_at" json:"last_sign_in_at"`
+	SuspendedAt  pgtype.Timestamptz `db:"suspended_at" json:"suspended_at"`
 }
 
 type User struct {
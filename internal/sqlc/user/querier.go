@@ -19,20 +19,35 @@ type Querier interface {
 	CreatePublicUser(ctx context.Context, arg CreatePublicUserParams) (User, error)
 	// Refresh Tokens
 	CreateRefreshToken(ctx context.Context, arg CreateRefreshTokenParams) (AuthRefreshToken, error)
+	// Sessions
+	CreateSession(ctx context.Context, arg CreateSessionParams) (AuthSession, error)
 	DeleteAuthUser(ctx context.Context, id pgtype.UUID) error
 	// Delete Expired Tokens
 	DeleteExpiredTokens(ctx context.Context) error
 	GetAuthUserByEmail(ctx context.Context, email string) (AuthUser, error)
+	GetAuthUserByID(ctx context.Context, id pgtype.UUID) (AuthUser, error)
 	GetPublicUserByEmail(ctx context.Context, email string) (User, error)
 	// Get Refresh Token by Hash
 	GetRefreshTokenByHash(ctx context.Context, tokenHash string) (AuthRefreshToken, error)
+	GetRefreshTokenByHashForUpdate(ctx context.Context, tokenHash string) (AuthRefreshToken, error)
+	GetRefreshTokenByIDForUpdate(ctx context.Context, id pgtype.UUID) (AuthRefreshToken, error)
 	// Get User by ID
 	GetUserByID(ctx context.Context, id pgtype.UUID) (User, error)
+	// Get Profile by ID, with the login email
+	GetUserProfileByID(ctx context.Context, id pgtype.UUID) (GetUserProfileByIDRow, error)
+	// A session is active while its family still holds a usable refresh token.
+	ListActiveSessions(ctx context.Context, userID pgtype.UUID) ([]AuthSession, error)
 	ListAuditLogs(ctx context.Context, actorID pgtype.UUID) ([]AuthAuditLog, error)
+	MarkRefreshTokenRotated(ctx context.Context, arg MarkRefreshTokenRotatedParams) error
 	// Revoke All Tokens for User
 	RevokeAllTokensForUser(ctx context.Context, userID pgtype.UUID) error
+	RevokeOtherRefreshTokenFamilies(ctx context.Context, arg RevokeOtherRefreshTokenFamiliesParams) ([]pgtype.UUID, error)
 	RevokeRefreshToken(ctx context.Context, id pgtype.UUID) error
+	RevokeRefreshTokenFamily(ctx context.Context, familyID pgtype.UUID) error
+	RevokeUserRefreshTokenFamily(ctx context.Context, arg RevokeUserRefreshTokenFamilyParams) (int64, error)
+	TouchSession(ctx context.Context, arg TouchSessionParams) error
 	UpdateAuthUserLastLogin(ctx context.Context, id pgtype.UUID) error
+	UpdateAuthUserPassword(ctx context.Context, arg UpdateAuthUserPasswordParams) error
 	UpdatePublicUser(ctx context.Context, arg UpdatePublicUserParams) (User, error)
 	// Update User Role (admin only)
 	UpdateUserRole(ctx context.Context, arg UpdateUserRoleParams) error
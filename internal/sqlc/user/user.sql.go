@@ -40,7 +40,7 @@ func (q *Queries) CreateAuditLog(ctx context.Context, arg CreateAuditLogParams)
 const createAuthUser = `-- name: CreateAuthUser :one
 INSERT INTO auth.users (email, password_hash)
 VALUES ($1, $2)
-RETURNING id, email, password_hash, created_at, updated_at, confirmed_at, last_sign_in_at
+RETURNING id, email, password_hash, created_at, updated_at, confirmed_at, last_sign_in_at, suspended_at
 `
 
 type CreateAuthUserParams struct {
@@ -60,6 +60,7 @@ func (q *Queries) CreateAuthUser(ctx context.Context, arg CreateAuthUserParams)
 		&i.UpdatedAt,
 		&i.ConfirmedAt,
 		&i.LastSignInAt,
+		&i.SuspendedAt,
 	)
 	return i, err
 }
@@ -100,20 +101,26 @@ func (q *Queries) CreatePublicUser(ctx context.Context, arg CreatePublicUserPara
 }
 
 const createRefreshToken = `-- name: CreateRefreshToken :one
-INSERT INTO auth.refresh_tokens (user_id, token_hash, expires_at)
-VALUES ($1, $2, $3)
-RETURNING id, user_id, token_hash, expires_at, created_at, revoked
+INSERT INTO auth.refresh_tokens (user_id, token_hash, expires_at, family_id)
+VALUES ($1, $2, $3, $4)
+RETURNING id, user_id, token_hash, expires_at, created_at, revoked, family_id, rotated_at, replaced_by
 `
 
 type CreateRefreshTokenParams struct {
 	UserID    pgtype.UUID        `db:"user_id" json:"user_id"`
 	TokenHash string             `db:"token_hash" json:"token_hash"`
 	ExpiresAt pgtype.Timestamptz `db:"expires_at" json:"expires_at"`
+	FamilyID  pgtype.UUID        `db:"family_id" json:"family_id"`
 }
 
 // Refresh Tokens
 func (q *Queries) CreateRefreshToken(ctx context.Context, arg CreateRefreshTokenParams) (AuthRefreshToken, error) {
-	row := q.db.QueryRow(ctx, createRefreshToken, arg.UserID, arg.TokenHash, arg.ExpiresAt)
+	row := q.db.QueryRow(ctx, createRefreshToken,
+		arg.UserID,
+		arg.TokenHash,
+		arg.ExpiresAt,
+		arg.FamilyID,
+	)
 	var i AuthRefreshToken
 	err := row.Scan(
 		&i.ID,
@@ -122,6 +129,45 @@ func (q *Queries) CreateRefreshToken(ctx context.Context, arg CreateRefreshToken
 		&i.ExpiresAt,
 		&i.CreatedAt,
 		&i.Revoked,
+		&i.FamilyID,
+		&i.RotatedAt,
+		&i.ReplacedBy,
+	)
+	return i, err
+}
+
+const createSession = `-- name: CreateSession :one
+INSERT INTO auth.sessions (id, user_id, device_name, user_agent, ip_address)
+VALUES ($1, $2, $3, $4, $5)
+RETURNING id, user_id, device_name, user_agent, ip_address, created_at, last_used_at
+`
+
+type CreateSessionParams struct {
+	ID         pgtype.UUID `db:"id" json:"id"`
+	UserID     pgtype.UUID `db:"user_id" json:"user_id"`
+	DeviceName pgtype.Text `db:"device_name" json:"device_name"`
+	UserAgent  pgtype.Text `db:"user_agent" json:"user_agent"`
+	IpAddress  pgtype.Text `db:"ip_address" json:"ip_address"`
+}
+
+// Sessions
+func (q *Queries) CreateSession(ctx context.Context, arg CreateSessionParams) (AuthSession, error) {
+	row := q.db.QueryRow(ctx, createSession,
+		arg.ID,
+		arg.UserID,
+		arg.DeviceName,
+		arg.UserAgent,
+		arg.IpAddress,
+	)
+	var i AuthSession
+	err := row.Scan(
+		&i.ID,
+		&i.UserID,
+		&i.DeviceName,
+		&i.UserAgent,
+		&i.IpAddress,
+		&i.CreatedAt,
+		&i.LastUsedAt,
 	)
 	return i, err
 }
@@ -147,7 +193,7 @@ func (q *Queries) DeleteExpiredTokens(ctx context.Context) error {
 }
 
 const getAuthUserByEmail = `-- name: GetAuthUserByEmail :one
-SELECT id, email, password_hash, created_at, updated_at, confirmed_at, last_sign_in_at FROM auth.users WHERE email = $1 LIMIT 1
+SELECT id, email, password_hash, created_at, updated_at, confirmed_at, last_sign_in_at, suspended_at FROM auth.users WHERE email = $1 LIMIT 1
 `
 
 func (q *Queries) GetAuthUserByEmail(ctx context.Context, email string) (AuthUser, error) {
@@ -161,6 +207,27 @@ func (q *Queries) GetAuthUserByEmail(ctx context.Context, email string) (AuthUse
 		&i.UpdatedAt,
 		&i.ConfirmedAt,
 		&i.LastSignInAt,
+		&i.SuspendedAt,
+	)
+	return i, err
+}
+
+const getAuthUserByID = `-- name: GetAuthUserByID :one
+SELECT id, email, password_hash, created_at, updated_at, confirmed_at, last_sign_in_at, suspended_at FROM auth.users WHERE id = $1 LIMIT 1
+`
+
+func (q *Queries) GetAuthUserByID(ctx context.Context, id pgtype.UUID) (AuthUser, error) {
+	row := q.db.QueryRow(ctx, getAuthUserByID, id)
+	var i AuthUser
+	err := row.Scan(
+		&i.ID,
+		&i.Email,
+		&i.PasswordHash,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.ConfirmedAt,
+		&i.LastSignInAt,
+		&i.SuspendedAt,
 	)
 	return i, err
 }
@@ -192,7 +259,7 @@ func (q *Queries) GetPublicUserByEmail(ctx context.Context, email string) (User,
 }
 
 const getRefreshTokenByHash = `-- name: GetRefreshTokenByHash :one
-SELECT id, user_id, token_hash, expires_at, created_at, revoked FROM auth.refresh_tokens 
+SELECT id, user_id, token_hash, expires_at, created_at, revoked, family_id, rotated_at, replaced_by FROM auth.refresh_tokens 
 WHERE token_hash = $1 AND revoked = false
 `
 
@@ -207,6 +274,51 @@ func (q *Queries) GetRefreshTokenByHash(ctx context.Context, tokenHash string) (
 		&i.ExpiresAt,
 		&i.CreatedAt,
 		&i.Revoked,
+		&i.FamilyID,
+		&i.RotatedAt,
+		&i.ReplacedBy,
+	)
+	return i, err
+}
+
+const getRefreshTokenByHashForUpdate = `-- name: GetRefreshTokenByHashForUpdate :one
+SELECT id, user_id, token_hash, expires_at, created_at, revoked, family_id, rotated_at, replaced_by FROM auth.refresh_tokens WHERE token_hash = $1 FOR UPDATE
+`
+
+func (q *Queries) GetRefreshTokenByHashForUpdate(ctx context.Context, tokenHash string) (AuthRefreshToken, error) {
+	row := q.db.QueryRow(ctx, getRefreshTokenByHashForUpdate, tokenHash)
+	var i AuthRefreshToken
+	err := row.Scan(
+		&i.ID,
+		&i.UserID,
+		&i.TokenHash,
+		&i.ExpiresAt,
+		&i.CreatedAt,
+		&i.Revoked,
+		&i.FamilyID,
+		&i.RotatedAt,
+		&i.ReplacedBy,
+	)
+	return i, err
+}
+
+const getRefreshTokenByIDForUpdate = `-- name: GetRefreshTokenByIDForUpdate :one
+SELECT id, user_id, token_hash, expires_at, created_at, revoked, family_id, rotated_at, replaced_by FROM auth.refresh_tokens WHERE id = $1 FOR UPDATE
+`
+
+func (q *Queries) GetRefreshTokenByIDForUpdate(ctx context.Context, id pgtype.UUID) (AuthRefreshToken, error) {
+	row := q.db.QueryRow(ctx, getRefreshTokenByIDForUpdate, id)
+	var i AuthRefreshToken
+	err := row.Scan(
+		&i.ID,
+		&i.UserID,
+		&i.TokenHash,
+		&i.ExpiresAt,
+		&i.CreatedAt,
+		&i.Revoked,
+		&i.FamilyID,
+		&i.RotatedAt,
+		&i.ReplacedBy,
 	)
 	return i, err
 }
@@ -232,6 +344,89 @@ func (q *Queries) GetUserByID(ctx context.Context, id pgtype.UUID) (User, error)
 	return i, err
 }
 
+const getUserProfileByID = `-- name: GetUserProfileByID :one
+SELECT
+  pu.id, pu.full_name, pu.phone_number, pu.provider, pu.provider_id, pu.role,
+  pu.created_at, pu.updated_at, au.email
+FROM public.users pu
+JOIN auth.users au ON pu.id = au.id
+WHERE pu.id = $1
+LIMIT 1
+`
+
+type GetUserProfileByIDRow struct {
+	ID          pgtype.UUID        `db:"id" json:"id"`
+	FullName    string             `db:"full_name" json:"full_name"`
+	PhoneNumber pgtype.Text        `db:"phone_number" json:"phone_number"`
+	Provider    pgtype.Text        `db:"provider" json:"provider"`
+	ProviderID  pgtype.Text        `db:"provider_id" json:"provider_id"`
+	Role        string             `db:"role" json:"role"`
+	CreatedAt   pgtype.Timestamptz `db:"created_at" json:"created_at"`
+	UpdatedAt   pgtype.Timestamptz `db:"updated_at" json:"updated_at"`
+	Email       string             `db:"email" json:"email"`
+}
+
+// Get Profile by ID, with the login email
+func (q *Queries) GetUserProfileByID(ctx context.Context, id pgtype.UUID) (GetUserProfileByIDRow, error) {
+	row := q.db.QueryRow(ctx, getUserProfileByID, id)
+	var i GetUserProfileByIDRow
+	err := row.Scan(
+		&i.ID,
+		&i.FullName,
+		&i.PhoneNumber,
+		&i.Provider,
+		&i.ProviderID,
+		&i.Role,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.Email,
+	)
+	return i, err
+}
+
+const listActiveSessions = `-- name: ListActiveSessions :many
+SELECT s.id, s.user_id, s.device_name, s.user_agent, s.ip_address, s.created_at, s.last_used_at
+FROM auth.sessions s
+WHERE s.user_id = $1
+  AND EXISTS (
+    SELECT 1 FROM auth.refresh_tokens rt
+    WHERE rt.family_id = s.id
+      AND rt.revoked = false
+      AND rt.rotated_at IS NULL
+      AND rt.expires_at > NOW()
+  )
+ORDER BY s.last_used_at DESC
+`
+
+// A session is active while its family still holds a usable refresh token.
+func (q *Queries) ListActiveSessions(ctx context.Context, userID pgtype.UUID) ([]AuthSession, error) {
+	rows, err := q.db.Query(ctx, listActiveSessions, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []AuthSession
+	for rows.Next() {
+		var i AuthSession
+		if err := rows.Scan(
+			&i.ID,
+			&i.UserID,
+			&i.DeviceName,
+			&i.UserAgent,
+			&i.IpAddress,
+			&i.CreatedAt,
+			&i.LastUsedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const listAuditLogs = `-- name: ListAuditLogs :many
 SELECT id, actor_id, action, details, created_at FROM auth.audit_log WHERE actor_id = $1 ORDER BY created_at DESC
 `
@@ -262,6 +457,20 @@ func (q *Queries) ListAuditLogs(ctx context.Context, actorID pgtype.UUID) ([]Aut
 	return items, nil
 }
 
+const markRefreshTokenRotated = `-- name: MarkRefreshTokenRotated :exec
+UPDATE auth.refresh_tokens SET rotated_at = NOW(), replaced_by = $2 WHERE id = $1
+`
+
+type MarkRefreshTokenRotatedParams struct {
+	ID         pgtype.UUID `db:"id" json:"id"`
+	ReplacedBy pgtype.UUID `db:"replaced_by" json:"replaced_by"`
+}
+
+func (q *Queries) MarkRefreshTokenRotated(ctx context.Context, arg MarkRefreshTokenRotatedParams) error {
+	_, err := q.db.Exec(ctx, markRefreshTokenRotated, arg.ID, arg.ReplacedBy)
+	return err
+}
+
 const revokeAllTokensForUser = `-- name: RevokeAllTokensForUser :exec
 UPDATE auth.refresh_tokens 
 SET revoked = true 
@@ -274,6 +483,37 @@ func (q *Queries) RevokeAllTokensForUser(ctx context.Context, userID pgtype.UUID
 	return err
 }
 
+const revokeOtherRefreshTokenFamilies = `-- name: RevokeOtherRefreshTokenFamilies :many
+UPDATE auth.refresh_tokens SET revoked = true
+WHERE user_id = $1 AND family_id <> $2 AND revoked = false
+RETURNING family_id
+`
+
+type RevokeOtherRefreshTokenFamiliesParams struct {
+	UserID   pgtype.UUID `db:"user_id" json:"user_id"`
+	FamilyID pgtype.UUID `db:"family_id" json:"family_id"`
+}
+
+func (q *Queries) RevokeOtherRefreshTokenFamilies(ctx context.Context, arg RevokeOtherRefreshTokenFamiliesParams) ([]pgtype.UUID, error) {
+	rows, err := q.db.Query(ctx, revokeOtherRefreshTokenFamilies, arg.UserID, arg.FamilyID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []pgtype.UUID
+	for rows.Next() {
+		var family_id pgtype.UUID
+		if err := rows.Scan(&family_id); err != nil {
+			return nil, err
+		}
+		items = append(items, family_id)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const revokeRefreshToken = `-- name: RevokeRefreshToken :exec
 UPDATE auth.refresh_tokens SET revoked = true WHERE id = $1
 `
@@ -283,6 +523,50 @@ func (q *Queries) RevokeRefreshToken(ctx context.Context, id pgtype.UUID) error
 	return err
 }
 
+const revokeRefreshTokenFamily = `-- name: RevokeRefreshTokenFamily :exec
+UPDATE auth.refresh_tokens SET revoked = true WHERE family_id = $1
+`
+
+func (q *Queries) RevokeRefreshTokenFamily(ctx context.Context, familyID pgtype.UUID) error {
+	_, err := q.db.Exec(ctx, revokeRefreshTokenFamily, familyID)
+	return err
+}
+
+const revokeUserRefreshTokenFamily = `-- name: RevokeUserRefreshTokenFamily :execrows
+UPDATE auth.refresh_tokens SET revoked = true
+WHERE user_id = $1 AND family_id = $2 AND revoked = false
+`
+
+type RevokeUserRefreshTokenFamilyParams struct {
+	UserID   pgtype.UUID `db:"user_id" json:"user_id"`
+	FamilyID pgtype.UUID `db:"family_id" json:"family_id"`
+}
+
+func (q *Queries) RevokeUserRefreshTokenFamily(ctx context.Context, arg RevokeUserRefreshTokenFamilyParams) (int64, error) {
+	result, err := q.db.Exec(ctx, revokeUserRefreshTokenFamily, arg.UserID, arg.FamilyID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const touchSession = `-- name: TouchSession :exec
+UPDATE auth.sessions
+SET last_used_at = NOW(), user_agent = $2, ip_address = $3
+WHERE id = $1
+`
+
+type TouchSessionParams struct {
+	ID        pgtype.UUID `db:"id" json:"id"`
+	UserAgent pgtype.Text `db:"user_agent" json:"user_agent"`
+	IpAddress pgtype.Text `db:"ip_address" json:"ip_address"`
+}
+
+func (q *Queries) TouchSession(ctx context.Context, arg TouchSessionParams) error {
+	_, err := q.db.Exec(ctx, touchSession, arg.ID, arg.UserAgent, arg.IpAddress)
+	return err
+}
+
 const updateAuthUserLastLogin = `-- name: UpdateAuthUserLastLogin :exec
 UPDATE auth.users SET last_sign_in_at = NOW() WHERE id = $1
 `
@@ -292,6 +576,20 @@ func (q *Queries) UpdateAuthUserLastLogin(ctx context.Context, id pgtype.UUID) e
 	return err
 }
 
+const updateAuthUserPassword = `-- name: UpdateAuthUserPassword :exec
+UPDATE auth.users SET password_hash = $2, updated_at = NOW() WHERE id = $1
+`
+
+type UpdateAuthUserPasswordParams struct {
+	ID           pgtype.UUID `db:"id" json:"id"`
+	PasswordHash pgtype.Text `db:"password_hash" json:"password_hash"`
+}
+
+func (q *Queries) UpdateAuthUserPassword(ctx context.Context, arg UpdateAuthUserPasswordParams) error {
+	_, err := q.db.Exec(ctx, updateAuthUserPassword, arg.ID, arg.PasswordHash)
+	return err
+}
+
 const updatePublicUser = `-- name: UpdatePublicUser :one
 UPDATE public.users
 SET full_name    = $2,
@@ -0,0 +1,18 @@
+// Package storage provides blob storage for uploaded files.
+package storage
+
+import (
+	"context"
+	"errors"
+	"io"
+)
+
+var ErrBlobNotFound = errors.New("blob not found")
+
+// BlobStore stores opaque blobs under slash separated keys such as
+// "orders/12/photo.jpg".
+type BlobStore interface {
+	Put(ctx context.Context, key string, r io.Reader) error
+	Get(ctx context.Context, key string) (io.ReadCloser, error)
+	Delete(ctx context.Context, key string) error
+}
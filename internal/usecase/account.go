@@ -0,0 +1,188 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/AndikaPrasetia/wash-shoe/internal/dto"
+	"github.com/AndikaPrasetia/wash-shoe/internal/model"
+	"github.com/AndikaPrasetia/wash-shoe/internal/redis"
+	"github.com/AndikaPrasetia/wash-shoe/internal/repository"
+)
+
+var (
+	ErrAccountSuspended = errors.New("account is suspended")
+	ErrChangeOwnAccount = errors.New("you can't change the role or suspension of your own account")
+)
+
+const (
+	defaultAccountLimit = 20
+	accountAuditLimit   = 50
+	accountStatusTTL    = time.Minute
+)
+
+// Cached account statuses.
+const (
+	accountActive    = "active"
+	accountSuspended = "suspended"
+)
+
+// accountStatusKey caches whether a user is suspended, so authenticated
+// requests don't all hit the database. Suspending and reinstating through
+// AccountUsecase update it right away; the TTL covers changes made
+// elsewhere.
+func accountStatusKey(userID string) string {
+	return "users:status:" + userID
+}
+
+// AccountUsecase defines business logic for administering user accounts
+type AccountUsecase interface {
+	List(ctx context.Context, query dto.ListUsersQuery) (*model.AccountPage, error)
+	// Get returns the account with its orders and latest audit log entries.
+	Get(ctx context.Context, id string) (*model.AccountDetail, error)
+	UpdateRole(ctx context.Context, actor model.User, id string, req dto.UpdateUserRoleRequest) (model.Account, error)
+	Suspend(ctx context.Context, actor model.User, id string, req dto.SuspendUserRequest) (model.Account, error)
+	Unsuspend(ctx context.Context, actor model.User, id string) (model.Account, error)
+	// CheckActive returns ErrAccountSuspended when the user is suspended and
+	// ErrUserNotFound when the account no longer exists.
+	CheckActive(ctx context.Context, userID string) error
+}
+
+type accountUsecase struct {
+	repo        repository.AccountRepo
+	orderRepo   repository.OrderRepo
+	revocations TokenRevocationUsecase
+	redisCli    *redis.RedisClient
+}
+
+// NewAccountUsecase creates a new AccountUsecase
+func NewAccountUsecase(repo repository.AccountRepo, orderRepo repository.OrderRepo, revocations TokenRevocationUsecase, redisCli *redis.RedisClient) AccountUsecase {
+	return &accountUsecase{repo: repo, orderRepo: orderRepo, revocations: revocations, redisCli: redisCli}
+}
+
+// List returns users newest first, 20 per page unless query sets a limit.
+func (uc *accountUsecase) List(ctx context.Context, query dto.ListUsersQuery) (*model.AccountPage, error) {
+	limit := query.Limit
+	if limit == 0 {
+		limit = defaultAccountLimit
+	}
+	users, total, err := uc.repo.List(ctx, model.AccountFilter{
+		Search:    strings.TrimSpace(query.Q),
+		Role:      query.Role,
+		Provider:  strings.TrimSpace(query.Provider),
+		Suspended: query.Suspended,
+	}, limit, query.Offset)
+	if err != nil {
+		return nil, err
+	}
+	return &model.AccountPage{Users: users, Total: total, Limit: limit, Offset: query.Offset}, nil
+}
+
+func (uc *accountUsecase) Get(ctx context.Context, id string) (*model.AccountDetail, error) {
+	a, err := uc.repo.FindByID(ctx, id)
+	if err != nil {
+		return nil, mapAccountError(err)
+	}
+	orders, err := uc.orderRepo.ListByUser(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("load orders: %w", err)
+	}
+	logs, err := uc.repo.ListAuditLogs(ctx, id, accountAuditLimit)
+	if err != nil {
+		return nil, fmt.Errorf("load audit log: %w", err)
+	}
+	return &model.AccountDetail{Account: *a, Orders: orders, AuditLog: logs}, nil
+}
+
+// UpdateRole changes a user's role. Admins can't change their own role, so
+// the last admin can't lock everyone out by accident. The role is carried in
+// the user's tokens, so they are all revoked and the user logs in again with
+// the new one.
+func (uc *accountUsecase) UpdateRole(ctx context.Context, actor model.User, id string, req dto.UpdateUserRoleRequest) (model.Account, error) {
+	if id == actor.ID {
+		return model.Account{}, ErrChangeOwnAccount
+	}
+	a, err := uc.repo.UpdateRole(ctx, id, req.Role, actor.ID)
+	if err != nil {
+		return model.Account{}, mapAccountError(err)
+	}
+	if err := uc.revocations.RevokeUser(ctx, id); err != nil {
+		return model.Account{}, fmt.Errorf("revoke tokens: %w", err)
+	}
+	return a, nil
+}
+
+// Suspend blocks the user from logging in and makes the API reject their
+// tokens. Suspending a suspended account changes nothing.
+func (uc *accountUsecase) Suspend(ctx context.Context, actor model.User, id string, req dto.SuspendUserRequest) (model.Account, error) {
+	return uc.setSuspended(ctx, actor, id, true, strings.TrimSpace(req.Reason))
+}
+
+func (uc *accountUsecase) Unsuspend(ctx context.Context, actor model.User, id string) (model.Account, error) {
+	return uc.setSuspended(ctx, actor, id, false, "")
+}
+
+func (uc *accountUsecase) setSuspended(ctx context.Context, actor model.User, id string, suspended bool, reason string) (model.Account, error) {
+	if id == actor.ID {
+		return model.Account{}, ErrChangeOwnAccount
+	}
+	a, _, err := uc.repo.SetSuspended(ctx, id, suspended, reason, actor.ID)
+	if err != nil {
+		return model.Account{}, mapAccountError(err)
+	}
+
+	status := accountActive
+	if suspended {
+		status = accountSuspended
+	}
+	// a cached "active" left behind would let a suspended user in until it
+	// expires, so a failed write has to at least drop the key
+	key := accountStatusKey(id)
+	if err := uc.redisCli.GetClient().Set(ctx, key, status, accountStatusTTL).Err(); err != nil {
+		if err := uc.redisCli.GetClient().Del(ctx, key).Err(); err != nil {
+			return model.Account{}, fmt.Errorf("cache account status: %w", err)
+		}
+	}
+
+	// tokens from before the suspension must not work again once the
+	// account is reinstated
+	if suspended {
+		if err := uc.revocations.RevokeUser(ctx, id); err != nil {
+			return model.Account{}, fmt.Errorf("revoke tokens: %w", err)
+		}
+	}
+	return a, nil
+}
+
+// CheckActive serves the status from Redis when cached and falls back to
+// the database otherwise, including when Redis is unavailable.
+func (uc *accountUsecase) CheckActive(ctx context.Context, userID string) error {
+	key := accountStatusKey(userID)
+	status, err := uc.redisCli.GetClient().Get(ctx, key).Result()
+	if err != nil {
+		suspendedAt, err := uc.repo.SuspendedAt(ctx, userID)
+		if err != nil {
+			return mapAccountError(err)
+		}
+		status = accountActive
+		if suspendedAt != nil {
+			status = accountSuspended
+		}
+		_ = uc.redisCli.GetClient().Set(ctx, key, status, accountStatusTTL).Err()
+	}
+
+	if status == accountSuspended {
+		return ErrAccountSuspended
+	}
+	return nil
+}
+
+func mapAccountError(err error) error {
+	if errors.Is(err, repository.ErrUserNotFound) {
+		return ErrUserNotFound
+	}
+	return err
+}
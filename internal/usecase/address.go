@@ -0,0 +1,93 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+
+	"github.com/AndikaPrasetia/wash-shoe/internal/dto"
+	"github.com/AndikaPrasetia/wash-shoe/internal/model"
+	"github.com/AndikaPrasetia/wash-shoe/internal/repository"
+)
+
+var (
+	ErrAddressNotFound = errors.New("address not found")
+	ErrAddressInUse    = errors.New("address is still used by an open order")
+)
+
+// AddressUsecase defines business logic for a user's address book
+type AddressUsecase interface {
+	List(ctx context.Context, userID string) ([]model.Address, error)
+	GetByID(ctx context.Context, userID string, id int32) (*model.Address, error)
+	Create(ctx context.Context, userID string, req dto.AddressRequest) (model.Address, error)
+	Update(ctx context.Context, userID string, id int32, req dto.AddressRequest) (model.Address, error)
+	SetPrimary(ctx context.Context, userID string, id int32) error
+	Delete(ctx context.Context, userID string, id int32) error
+}
+
+type addressUsecase struct {
+	repo repository.AddressRepo
+}
+
+// NewAddressUsecase creates a new AddressUsecase
+func NewAddressUsecase(repo repository.AddressRepo) AddressUsecase {
+	return &addressUsecase{repo: repo}
+}
+
+func (uc *addressUsecase) List(ctx context.Context, userID string) ([]model.Address, error) {
+	return uc.repo.ListByUser(ctx, userID)
+}
+
+func (uc *addressUsecase) GetByID(ctx context.Context, userID string, id int32) (*model.Address, error) {
+	a, err := uc.repo.FindByID(ctx, userID, id)
+	if err != nil {
+		return nil, mapAddressError(err)
+	}
+	return a, nil
+}
+
+func (uc *addressUsecase) Create(ctx context.Context, userID string, req dto.AddressRequest) (model.Address, error) {
+	a := addressFromRequest(req)
+	a.UserID = userID
+	return uc.repo.Create(ctx, a)
+}
+
+func (uc *addressUsecase) Update(ctx context.Context, userID string, id int32, req dto.AddressRequest) (model.Address, error) {
+	a := addressFromRequest(req)
+	a.ID = id
+	a.UserID = userID
+	updated, err := uc.repo.Update(ctx, a)
+	if err != nil {
+		return model.Address{}, mapAddressError(err)
+	}
+	return updated, nil
+}
+
+func (uc *addressUsecase) SetPrimary(ctx context.Context, userID string, id int32) error {
+	return mapAddressError(uc.repo.SetPrimary(ctx, userID, id))
+}
+
+// Delete removes an address unless an open order still references it.
+func (uc *addressUsecase) Delete(ctx context.Context, userID string, id int32) error {
+	return mapAddressError(uc.repo.Delete(ctx, userID, id))
+}
+
+func addressFromRequest(req dto.AddressRequest) model.Address {
+	return model.Address{
+		Street:     req.Street,
+		City:       req.City,
+		Province:   req.Province,
+		PostalCode: req.PostalCode,
+		Notes:      req.Notes,
+		IsPrimary:  req.IsPrimary,
+	}
+}
+
+func mapAddressError(err error) error {
+	switch {
+	case errors.Is(err, repository.ErrAddressNotFound):
+		return ErrAddressNotFound
+	case errors.Is(err, repository.ErrAddressInUse):
+		return ErrAddressInUse
+	}
+	return err
+}
@@ -3,15 +3,18 @@ package usecase
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"time"
 
+	"github.com/AndikaPrasetia/wash-shoe/internal/config"
 	"github.com/AndikaPrasetia/wash-shoe/internal/dto"
 	"github.com/AndikaPrasetia/wash-shoe/internal/model"
 	"github.com/AndikaPrasetia/wash-shoe/internal/redis"
 	"github.com/AndikaPrasetia/wash-shoe/internal/repository"
 	"github.com/AndikaPrasetia/wash-shoe/internal/sqlc/user"
+	modelUtils "github.com/AndikaPrasetia/wash-shoe/internal/utils/model-utils"
 	utils "github.com/AndikaPrasetia/wash-shoe/internal/utils/services"
 	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5/pgtype"
@@ -25,31 +28,60 @@ var (
 	ErrTokenNotFound      = errors.New("token not found")
 	ErrInvalidToken       = errors.New("invalid token")
 	ErrTokenRevoked       = errors.New("token has been revoked")
+	ErrWrongPassword      = errors.New("current password is incorrect")
+	ErrTokenReused        = errors.New("refresh token was already used, its session has been revoked")
+	ErrSessionNotFound    = errors.New("session not found")
 )
 
 // AuthUserUsecase defines business logic for auth users
 type AuthUserUsecase interface {
-	Signup(ctx context.Context, req dto.SignupRequest) (model.AuthUser, string, string, error)
+	// Signup and Login start a new session on device.
+	Signup(ctx context.Context, req dto.SignupRequest, device model.Device) (model.AuthUser, string, string, error)
 	GetByEmail(ctx context.Context, email string) (*model.AuthUser, error)
-	Login(ctx context.Context, req dto.LoginRequest) (string, string, error)
-	Logout(ctx context.Context, userID string) error
-	RefreshToken(ctx context.Context, refreshToken string) (string, string, error)
+	Login(ctx context.Context, req dto.LoginRequest, device model.Device) (string, string, error)
+	// Logout ends the session of the access token used for the request and
+	// revokes that access token.
+	Logout(ctx context.Context, accessToken modelUtils.JwtPayloadClaim) error
+	RefreshToken(ctx context.Context, refreshToken string, device model.Device) (string, string, error)
+	// ChangePassword sets a new password and revokes every token issued to
+	// the user, so all sessions have to log in again.
+	ChangePassword(ctx context.Context, userID string, req dto.ChangePasswordRequest) error
+	// ListSessions returns the active sessions of the token's user, marking
+	// the token's own session as current.
+	ListSessions(ctx context.Context, accessToken modelUtils.JwtPayloadClaim) ([]model.Session, error)
+	// RevokeSession logs one of the user's sessions out.
+	RevokeSession(ctx context.Context, accessToken modelUtils.JwtPayloadClaim, sessionID string) error
+	// RevokeOtherSessions logs out every session of the user but the
+	// token's own and returns how many it ended.
+	RevokeOtherSessions(ctx context.Context, accessToken modelUtils.JwtPayloadClaim) (int, error)
 }
 
 type authUserUsecase struct {
-	authRepo repository.AuthUserRepo
-	userRepo repository.UserRepo
-	redisCli *redis.RedisClient
+	authRepo    repository.AuthUserRepo
+	userRepo    repository.UserRepo
+	referralUC  ReferralUsecase
+	jwtSvc      utils.JwtService
+	revocations TokenRevocationUsecase
+	refreshRepo repository.RefreshTokenRepo
+	redisCli    *redis.RedisClient
+	cfg         config.TokenConfig
 }
 
 // NewAuthUserUsecase creates a new AuthUserUsecase
-func NewAuthUserUsecase(authRepo repository.AuthUserRepo, userRepo repository.UserRepo, redisCli *redis.RedisClient) AuthUserUsecase {
-	return &authUserUsecase{authRepo: authRepo, userRepo: userRepo, redisCli: redisCli}
+func NewAuthUserUsecase(authRepo repository.AuthUserRepo, userRepo repository.UserRepo, referralUC ReferralUsecase, jwtSvc utils.JwtService, revocations TokenRevocationUsecase, refreshRepo repository.RefreshTokenRepo, redisCli *redis.RedisClient, cfg config.TokenConfig) AuthUserUsecase {
+	return &authUserUsecase{authRepo: authRepo, userRepo: userRepo, referralUC: referralUC, jwtSvc: jwtSvc, revocations: revocations, refreshRepo: refreshRepo, redisCli: redisCli, cfg: cfg}
+}
+
+// refreshSuccessorKey holds a refresh token issued by a refresh, under its
+// hash, for repository.RefreshRetryGrace.
+func refreshSuccessorKey(tokenHash string) string {
+	return "tokens:successor:" + tokenHash
 }
 
 // Signup handles user signup: validates input, creates auth+public user,
-// issues tokens, stores refresh token, and logs the action.
-func (uc *authUserUsecase) Signup(ctx context.Context, req dto.SignupRequest) (model.AuthUser, string, string, error) {
+// links the referral when a referral code is given, issues tokens, stores
+// refresh token, and logs the action.
+func (uc *authUserUsecase) Signup(ctx context.Context, req dto.SignupRequest, device model.Device) (model.AuthUser, string, string, error) {
 	// 1. Validate passwords
 	if req.Password != req.ConfirmPassword {
 		return model.AuthUser{}, "", "", ErrPasswordMismatch
@@ -69,12 +101,20 @@ func (uc *authUserUsecase) Signup(ctx context.Context, req dto.SignupRequest) (m
 	if existing != nil {
 		return model.AuthUser{}, "", "", ErrEmailAlreadyExists
 	}
-	// 3. Hash password
+	// 3. Resolve referral code before anything is created
+	var referrerID string
+	if req.ReferralCode != "" {
+		referrerID, err = uc.referralUC.Referrer(ctx, req.ReferralCode)
+		if err != nil {
+			return model.AuthUser{}, "", "", err
+		}
+	}
+	// 4. Hash password
 	hash, err := utils.HashPassword(req.Password)
 	if err != nil {
 		return model.AuthUser{}, "", "", fmt.Errorf("hash password: %w", err)
 	}
-	// 4. Create auth user record (PasswordHash as pgtype.Text)
+	// 5. Create auth user record (PasswordHash as pgtype.Text)
 	authParams := user.CreateAuthUserParams{
 		Email:        req.Email,
 		PasswordHash: pgtype.Text{String: hash, Valid: true},
@@ -83,8 +123,8 @@ func (uc *authUserUsecase) Signup(ctx context.Context, req dto.SignupRequest) (m
 	if err != nil {
 		return model.AuthUser{}, "", "", fmt.Errorf("signup auth user: %w", err)
 	}
-	// 5. Create public user profile
-	_, err = uc.userRepo.Create(ctx, user.CreatePublicUserParams{
+	// 6. Create public user profile
+	publicUser, err := uc.userRepo.Create(ctx, user.CreatePublicUserParams{
 		ID:          pgtype.UUID{Bytes: uuidFromString(authUser.ID), Valid: true},
 		FullName:    req.Username,
 		PhoneNumber: pgtype.Text{String: "", Valid: false},
@@ -93,25 +133,40 @@ func (uc *authUserUsecase) Signup(ctx context.Context, req dto.SignupRequest) (m
 	if err != nil {
 		return model.AuthUser{}, "", "", fmt.Errorf("create public user: %w", err)
 	}
-	// 6. Generate tokens
-	accessToken, refreshToken, err := utils.GenerateTokenPair(authUser.ID)
+	// 7. Link referral. The account exists already, so a failed link must
+	//    not fail the signup; it is left in the audit log to be fixed by hand
+	if referrerID != "" {
+		if err := uc.referralUC.Link(ctx, referrerID, authUser.ID, authUser.Email); err != nil {
+			details, _ := json.Marshal(map[string]any{
+				"user_id":     authUser.ID,
+				"referrer_id": referrerID,
+				"error":       err.Error(),
+			})
+			_, _ = uc.authRepo.CreateAuditLog(ctx, user.CreateAuditLogParams{
+				ActorID: pgtype.UUID{Bytes: uuidFromString(authUser.ID), Valid: true},
+				Action:  "referral_link_failed",
+				Details: details,
+			})
+		}
+	}
+	// 8. Generate tokens
+	publicUser.Email = authUser.Email
+	sessionID := uuid.NewString()
+	accessToken, refreshToken, err := uc.jwtSvc.CreateTokenPair(publicUser, sessionID)
 	if err != nil {
 		return model.AuthUser{}, "", "", fmt.Errorf("generate tokens: %w", err)
 	}
-	// 7. Store refresh token hash in Redis with expiration
-	rtHash := utils.HashToken(refreshToken)
-	// Set TTL to refresh token lifetime
-	err = uc.redisCli.GetClient().Set(ctx, rtHash, "valid", 7*24*time.Hour).Err() // 7 days
-	if err != nil {
-		return model.AuthUser{}, "", "", fmt.Errorf("store refresh token in Redis: %w", err)
+	// 9. Store refresh token hash as the start of a new session
+	if err := uc.startSession(ctx, authUser.ID, sessionID, refreshToken, device); err != nil {
+		return model.AuthUser{}, "", "", fmt.Errorf("store refresh token: %w", err)
 	}
-	// 8. Audit log
+	// 10. Audit log
 	_, _ = uc.authRepo.CreateAuditLog(ctx, user.CreateAuditLogParams{
 		ActorID: pgtype.UUID{Bytes: uuidFromString(authUser.ID), Valid: true},
 		Action:  "user_register",
 		Details: fmt.Appendf(nil, "user %s registered", authUser.Email),
 	})
-	// 9. Return result
+	// 11. Return result
 	return authUser, accessToken, refreshToken, nil
 }
 
@@ -125,7 +180,7 @@ func uuidFromString(s string) [16]byte {
 	return u
 }
 
-func (uc *authUserUsecase) Login(ctx context.Context, req dto.LoginRequest) (string, string, error) {
+func (uc *authUserUsecase) Login(ctx context.Context, req dto.LoginRequest, device model.Device) (string, string, error) {
 	// get auth user
 	authUser, err := uc.authRepo.GetAuthUserByEmail(ctx, req.Email)
 	if err != nil {
@@ -148,6 +203,11 @@ func (uc *authUserUsecase) Login(ctx context.Context, req dto.LoginRequest) (str
 		return "", "", ErrInvalidCredentials
 	}
 
+	// suspended accounts can't log in
+	if authUser.SuspendedAt != nil {
+		return "", "", ErrAccountSuspended
+	}
+
 	// get public user data for role
 	publicUser, err := uc.userRepo.FindByEmail(ctx, req.Email)
 	if err != nil {
@@ -155,17 +215,16 @@ func (uc *authUserUsecase) Login(ctx context.Context, req dto.LoginRequest) (str
 	}
 
 	// generate tokens
-	accessToken, refreshToken, err := utils.GenerateTokenPair(publicUser.ID)
+	publicUser.Email = authUser.Email
+	sessionID := uuid.NewString()
+	accessToken, refreshToken, err := uc.jwtSvc.CreateTokenPair(*publicUser, sessionID)
 	if err != nil {
 		return "", "", err
 	}
 
-	// Store refresh token hash in Redis with expiration
-	rtHash := utils.HashToken(refreshToken)
-	// Set TTL to refresh token lifetime
-	err = uc.redisCli.GetClient().Set(ctx, rtHash, "valid", 7*24*time.Hour).Err() // 7 days
-	if err != nil {
-		return "", "", fmt.Errorf("store refresh token in Redis: %w", err)
+	// Store refresh token hash as the start of a new session
+	if err := uc.startSession(ctx, authUser.ID, sessionID, refreshToken, device); err != nil {
+		return "", "", fmt.Errorf("store refresh token: %w", err)
 	}
 
 	// update last login
@@ -177,23 +236,29 @@ func (uc *authUserUsecase) Login(ctx context.Context, req dto.LoginRequest) (str
 	return accessToken, refreshToken, nil
 }
 
-func (uc *authUserUsecase) Logout(ctx context.Context, userID string) error {
+func (uc *authUserUsecase) Logout(ctx context.Context, accessToken modelUtils.JwtPayloadClaim) error {
 	// 1. Parse user ID
-	userUUID, err := uuid.Parse(userID)
+	userUUID, err := uuid.Parse(accessToken.Subject)
 	if err != nil {
 		return fmt.Errorf("invalid user ID: %w", err)
 	}
 	pgUUID := pgtype.UUID{Bytes: userUUID, Valid: true}
 
-	// 2. Revoke semua refresh token user
-	err = uc.authRepo.RevokeAllTokens(ctx, pgUUID)
-	if err != nil {
+	// 2. Revoke refresh token session ini; token tanpa sid (sebelum ada
+	//    session) me-revoke semua refresh token user
+	if accessToken.SessionID != "" {
+		err = uc.refreshRepo.RevokeSession(ctx, accessToken.Subject, accessToken.SessionID)
+		if err != nil && !errors.Is(err, repository.ErrSessionNotFound) {
+			return fmt.Errorf("failed to revoke tokens: %w", err)
+		}
+	} else if err := uc.authRepo.RevokeAllTokens(ctx, pgUUID); err != nil {
 		return fmt.Errorf("failed to revoke tokens: %w", err)
 	}
 
-	// 3. [OPTIONAL] Tambahkan access token ke cache revoked
-	// (Jika Anda mengimplementasikan cache token yang di-revoke)
-	// uc.tokenCache.Add(accessToken, uc.cfg.AccessTokenLifeTime)
+	// 3. Revoke the access token itself until it expires
+	if err := uc.revocations.Revoke(ctx, accessToken); err != nil {
+		return fmt.Errorf("failed to revoke access token: %w", err)
+	}
 
 	// 4. Audit log (PERBAIKAN DI SINI)
 	detailsJSON := []byte(`"user logged out and tokens revoked"`) // String JSON valid
@@ -208,52 +273,203 @@ func (uc *authUserUsecase) Logout(ctx context.Context, userID string) error {
 }
 
 // RefreshToken generates new access and refresh tokens using a valid refresh token
-func (uc *authUserUsecase) RefreshToken(ctx context.Context, refreshToken string) (string, string, error) {
-	// Parse refresh token
-	claims, err := utils.ParseToken(refreshToken)
+func (uc *authUserUsecase) RefreshToken(ctx context.Context, refreshToken string, device model.Device) (string, string, error) {
+	// Verify refresh token (signature, issuer, audience and expiry)
+	claims, err := uc.jwtSvc.VerifyToken(refreshToken)
 	if err != nil {
 		return "", "", ErrInvalidToken
 	}
 
-	// Check if token is a refresh token
-	if claims.Type != "refresh" {
+	// Check if token is a refresh token of a session
+	if claims.Type != utils.TokenTypeRefresh || claims.SessionID == "" {
 		return "", "", ErrInvalidToken
 	}
 
-	// Check if token is expired
-	if claims.ExpiresAt.Before(time.Now()) {
-		return "", "", ErrInvalidToken
+	// Tokens issued before a logout, password change or suspension are
+	// revoked
+	if err := uc.revocations.CheckRevoked(ctx, claims); err != nil {
+		if errors.Is(err, ErrTokenRevoked) {
+			return "", "", ErrTokenRevoked
+		}
+		return "", "", err
 	}
 
-	// Check if refresh token is blacklisted in Redis
-	rtHash := utils.HashToken(refreshToken)
-	val, err := uc.redisCli.GetClient().Get(ctx, rtHash).Result()
-	if err != nil || val != "valid" {
-		return "", "", ErrTokenRevoked
+	// Reload the user, so the new tokens carry their current role
+	userID, err := uuid.Parse(claims.Subject)
+	if err != nil {
+		return "", "", ErrInvalidToken
+	}
+	currentUser, err := uc.userRepo.FindByID(ctx, pgtype.UUID{Bytes: userID, Valid: true})
+	if err != nil {
+		if errors.Is(err, repository.ErrUserNotFound) {
+			return "", "", ErrInvalidToken
+		}
+		return "", "", fmt.Errorf("load user: %w", err)
 	}
 
 	// Generate new tokens
-	accessToken, newRefreshToken, err := utils.GenerateTokenPair(claims.Subject)
+	accessToken, newRefreshToken, err := uc.jwtSvc.CreateTokenPair(*currentUser, claims.SessionID)
 	if err != nil {
 		return "", "", fmt.Errorf("generate tokens: %w", err)
 	}
 
-	// Blacklist the old refresh token
-	err = uc.redisCli.GetClient().Del(ctx, rtHash).Err()
+	// Keep the new refresh token around, so a retry of this refresh gets
+	// the same one. Without it a retry is refused and the client logs in
+	// again
+	newHash := utils.HashToken(newRefreshToken)
+	_ = uc.redisCli.GetClient().Set(ctx, refreshSuccessorKey(newHash), newRefreshToken, repository.RefreshRetryGrace).Err()
+
+	// Rotate the presented token into the new one. Presenting a token that
+	// was rotated already revokes its whole family, unless it is a retry
+	successor, err := uc.refreshRepo.Rotate(ctx, utils.HashToken(refreshToken), model.RefreshToken{
+		UserID:    currentUser.ID,
+		FamilyID:  claims.SessionID,
+		TokenHash: newHash,
+		ExpiresAt: time.Now().Add(uc.cfg.RefreshTokenLifeTime),
+	}, device)
+	if err != nil {
+		switch {
+		case errors.Is(err, repository.ErrRefreshTokenRetried):
+			retried, err := uc.redisCli.GetClient().Get(ctx, refreshSuccessorKey(successor.TokenHash)).Result()
+			if err != nil {
+				return "", "", ErrInvalidToken
+			}
+			return accessToken, retried, nil
+		case errors.Is(err, repository.ErrTokenNotFound):
+			return "", "", ErrInvalidToken
+		case errors.Is(err, repository.ErrRefreshTokenRevoked):
+			return "", "", ErrTokenRevoked
+		case errors.Is(err, repository.ErrRefreshTokenReused):
+			// access tokens of the session may be in the wrong hands too
+			_ = uc.revocations.RevokeSessions(ctx, claims.SessionID)
+			return "", "", ErrTokenReused
+		}
+		return "", "", fmt.Errorf("rotate refresh token: %w", err)
+	}
+
+	return accessToken, newRefreshToken, nil
+}
+
+// startSession stores a refresh token issued at signup or login as the
+// first of the family of a new session.
+func (uc *authUserUsecase) startSession(ctx context.Context, userID, sessionID, refreshToken string, device model.Device) error {
+	_, err := uc.refreshRepo.StartSession(ctx, model.Session{
+		ID:     sessionID,
+		UserID: userID,
+		Device: device,
+	}, model.RefreshToken{
+		UserID:    userID,
+		TokenHash: utils.HashToken(refreshToken),
+		ExpiresAt: time.Now().Add(uc.cfg.RefreshTokenLifeTime),
+	})
+	return err
+}
+
+func (uc *authUserUsecase) ListSessions(ctx context.Context, accessToken modelUtils.JwtPayloadClaim) ([]model.Session, error) {
+	sessions, err := uc.refreshRepo.ListSessions(ctx, accessToken.Subject)
 	if err != nil {
-		// Log error but don't fail the operation
-		fmt.Printf("Failed to blacklist old refresh token: %v\n", err)
+		return nil, err
+	}
+	for i := range sessions {
+		sessions[i].Current = sessions[i].ID == accessToken.SessionID
 	}
+	return sessions, nil
+}
 
-	// Store new refresh token hash in Redis with expiration
-	newRtHash := utils.HashToken(newRefreshToken)
-	// Set TTL to refresh token lifetime
-	err = uc.redisCli.GetClient().Set(ctx, newRtHash, "valid", 7*24*time.Hour).Err() // 7 days
+func (uc *authUserUsecase) RevokeSession(ctx context.Context, accessToken modelUtils.JwtPayloadClaim, sessionID string) error {
+	err := uc.refreshRepo.RevokeSession(ctx, accessToken.Subject, sessionID)
 	if err != nil {
-		return "", "", fmt.Errorf("store new refresh token in Redis: %w", err)
+		if errors.Is(err, repository.ErrSessionNotFound) {
+			return ErrSessionNotFound
+		}
+		return fmt.Errorf("failed to revoke session: %w", err)
+	}
+	// its access tokens stop working right away as well
+	if err := uc.revocations.RevokeSessions(ctx, sessionID); err != nil {
+		return fmt.Errorf("failed to revoke session: %w", err)
 	}
 
-	return accessToken, newRefreshToken, nil
+	uc.recordSessionChange(ctx, accessToken.Subject, "session_revoke", map[string]any{
+		"user_id":    accessToken.Subject,
+		"session_id": sessionID,
+	})
+	return nil
+}
+
+func (uc *authUserUsecase) RevokeOtherSessions(ctx context.Context, accessToken modelUtils.JwtPayloadClaim) (int, error) {
+	// without a session of its own, every session would be "other"
+	if accessToken.SessionID == "" {
+		return 0, ErrInvalidToken
+	}
+	revoked, err := uc.refreshRepo.RevokeOtherSessions(ctx, accessToken.Subject, accessToken.SessionID)
+	if err != nil {
+		return 0, fmt.Errorf("failed to revoke sessions: %w", err)
+	}
+	if err := uc.revocations.RevokeSessions(ctx, revoked...); err != nil {
+		return 0, fmt.Errorf("failed to revoke sessions: %w", err)
+	}
+
+	uc.recordSessionChange(ctx, accessToken.Subject, "session_revoke_others", map[string]any{
+		"user_id":     accessToken.Subject,
+		"session_id":  accessToken.SessionID,
+		"revoked_ids": revoked,
+	})
+	return len(revoked), nil
+}
+
+// recordSessionChange writes a best-effort audit log entry.
+func (uc *authUserUsecase) recordSessionChange(ctx context.Context, userID, action string, details map[string]any) {
+	b, err := json.Marshal(details)
+	if err != nil {
+		return
+	}
+	_, _ = uc.authRepo.CreateAuditLog(ctx, user.CreateAuditLogParams{
+		ActorID: pgtype.UUID{Bytes: uuidFromString(userID), Valid: true},
+		Action:  action,
+		Details: b,
+	})
+}
+
+func (uc *authUserUsecase) ChangePassword(ctx context.Context, userID string, req dto.ChangePasswordRequest) error {
+	userUUID, err := uuid.Parse(userID)
+	if err != nil {
+		return fmt.Errorf("invalid user ID: %w", err)
+	}
+	pgUUID := pgtype.UUID{Bytes: userUUID, Valid: true}
+
+	authUser, err := uc.authRepo.GetAuthUserByID(ctx, pgUUID)
+	if err != nil {
+		if errors.Is(err, repository.ErrUserNotFound) {
+			return ErrUserNotFound
+		}
+		return fmt.Errorf("load user: %w", err)
+	}
+	if !utils.CheckPasswordHash(req.CurrentPassword, authUser.PasswordHash) {
+		return ErrWrongPassword
+	}
+
+	hash, err := utils.HashPassword(req.NewPassword)
+	if err != nil {
+		return fmt.Errorf("hash password: %w", err)
+	}
+	if err := uc.authRepo.UpdatePassword(ctx, pgUUID, hash); err != nil {
+		return fmt.Errorf("update password: %w", err)
+	}
+
+	// Sessions that knew the old password have to log in again
+	if err := uc.authRepo.RevokeAllTokens(ctx, pgUUID); err != nil {
+		return fmt.Errorf("failed to revoke tokens: %w", err)
+	}
+	if err := uc.revocations.RevokeUser(ctx, userID); err != nil {
+		return fmt.Errorf("failed to revoke tokens: %w", err)
+	}
+
+	_, _ = uc.authRepo.CreateAuditLog(ctx, user.CreateAuditLogParams{
+		ActorID: pgUUID,
+		Action:  "password_change",
+		Details: []byte(`"password changed and tokens revoked"`),
+	})
+	return nil
 }
 
 func (uc *authUserUsecase) Delete(ctx context.Context, id pgtype.UUID) error {
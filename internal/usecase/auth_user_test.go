@@ -0,0 +1,108 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/AndikaPrasetia/wash-shoe/internal/config"
+	"github.com/AndikaPrasetia/wash-shoe/internal/model"
+	"github.com/AndikaPrasetia/wash-shoe/internal/repository"
+	utils "github.com/AndikaPrasetia/wash-shoe/internal/utils/services"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+type knownUser struct {
+	repository.UserRepo
+	user model.User
+}
+
+func (r knownUser) FindByID(ctx context.Context, id pgtype.UUID) (*model.User, error) {
+	u := r.user
+	return &u, nil
+}
+
+// rotatingRepo rotates tokens like refreshTokenRepo, with every presented
+// token within its retry grace.
+type rotatingRepo struct {
+	repository.RefreshTokenRepo
+	// successors maps a rotated token's hash to its successor
+	successors map[string]model.RefreshToken
+	revoked    bool
+}
+
+func (r *rotatingRepo) Rotate(ctx context.Context, tokenHash string, next model.RefreshToken, device model.Device) (model.RefreshToken, error) {
+	if r.revoked {
+		return model.RefreshToken{}, repository.ErrRefreshTokenRevoked
+	}
+	if successor, ok := r.successors[tokenHash]; ok {
+		if _, rotated := r.successors[successor.TokenHash]; !rotated {
+			return successor, repository.ErrRefreshTokenRetried
+		}
+		r.revoked = true
+		return model.RefreshToken{}, repository.ErrRefreshTokenReused
+	}
+	r.successors[tokenHash] = next
+	return next, nil
+}
+
+func TestRefreshTokenRetry(t *testing.T) {
+	ctx := context.Background()
+	cfg := config.TokenConfig{
+		AppName:              "wash-shoe",
+		Audience:             "wash-shoe-api",
+		JwtSecretKey:         []byte("0123456789abcdef0123456789abcdef"),
+		JwtSigningMethod:     jwt.SigningMethodHS256,
+		AccessTokenLifeTime:  15 * time.Minute,
+		RefreshTokenLifeTime: 24 * time.Hour,
+	}
+	u := model.User{ID: "6f1c7a52-3f5e-4a8e-9d1b-0c2f4e6a8b10", Role: "user"}
+	jwtSvc := utils.NewJwtService(cfg)
+	cli, mr := testRedis(t)
+	revocations := NewTokenRevocationUsecase(cli, cfg)
+	repo := &rotatingRepo{successors: map[string]model.RefreshToken{}}
+	uc := NewAuthUserUsecase(nil, knownUser{user: u}, nil, jwtSvc, revocations, repo, cli, cfg)
+
+	first, err := jwtSvc.CreateRefreshToken(u, "session-1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, second, err := uc.RefreshToken(ctx, first, model.Device{})
+	if err != nil {
+		t.Fatalf("RefreshToken() error = %v", err)
+	}
+
+	// the client lost the response and tries again
+	access, retried, err := uc.RefreshToken(ctx, first, model.Device{})
+	if err != nil {
+		t.Fatalf("retried RefreshToken() error = %v", err)
+	}
+	if retried != second {
+		t.Error("a retry got another refresh token than the refresh it repeats")
+	}
+	if claims, err := jwtSvc.VerifyToken(access); err != nil || claims.SessionID != "session-1" {
+		t.Errorf("retry access token = %+v, %v, want one of session-1", claims, err)
+	}
+
+	// without the kept token the retry is refused, but nothing is revoked
+	mr.Del(refreshSuccessorKey(utils.HashToken(second)))
+	if _, _, err := uc.RefreshToken(ctx, first, model.Device{}); !errors.Is(err, ErrInvalidToken) {
+		t.Fatalf("retry without the kept token: error = %v, want %v", err, ErrInvalidToken)
+	}
+	if repo.revoked {
+		t.Fatal("a retry revoked the session")
+	}
+
+	// once the successor was used, presenting the first token is reuse
+	if _, _, err := uc.RefreshToken(ctx, second, model.Device{}); err != nil {
+		t.Fatalf("RefreshToken(second) error = %v", err)
+	}
+	if _, _, err := uc.RefreshToken(ctx, first, model.Device{}); !errors.Is(err, ErrTokenReused) {
+		t.Fatalf("reused RefreshToken() error = %v, want %v", err, ErrTokenReused)
+	}
+	if !repo.revoked {
+		t.Error("reuse left the session active")
+	}
+}
@@ -0,0 +1,254 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/AndikaPrasetia/wash-shoe/internal/dto"
+	"github.com/AndikaPrasetia/wash-shoe/internal/markdown"
+	"github.com/AndikaPrasetia/wash-shoe/internal/model"
+	"github.com/AndikaPrasetia/wash-shoe/internal/repository"
+)
+
+var (
+	ErrBlogPostNotFound = errors.New("blog post not found")
+	ErrBlogSlugTaken    = errors.New("blog post slug already exists")
+	ErrInvalidBlogSlug  = errors.New("slug may only contain lowercase letters, digits and single dashes")
+)
+
+const (
+	defaultBlogPageLimit = 20
+	// maxSlugLength caps slugs derived from titles.
+	maxSlugLength = 80
+	// slugAttempts is how many numbered variants of a derived slug are tried
+	// before giving up on a collision.
+	slugAttempts = 5
+)
+
+var slugPattern = regexp.MustCompile(`^[a-z0-9]+(?:-[a-z0-9]+)*$`)
+
+// BlogUsecase defines business logic for the blog. Posts are written in
+// Markdown and stored as such; the sanitized HTML is rendered every time a
+// post is returned, so renderer fixes apply to old posts too.
+type BlogUsecase interface {
+	Create(ctx context.Context, author model.User, req dto.BlogPostRequest) (model.BlogPost, error)
+	Update(ctx context.Context, id int32, req dto.BlogPostRequest) (model.BlogPost, error)
+	Publish(ctx context.Context, id int32, req dto.PublishBlogPostRequest) (model.BlogPost, error)
+	Unpublish(ctx context.Context, id int32) (model.BlogPost, error)
+	GetByID(ctx context.Context, id int32) (*model.BlogPost, error)
+	List(ctx context.Context, query dto.ListBlogPostsQuery) (*model.BlogPostPage, error)
+	// ListPublished and GetPublished only see posts whose published_at has
+	// passed.
+	ListPublished(ctx context.Context, query dto.BlogPostsQuery) (*model.BlogPostPage, error)
+	GetPublished(ctx context.Context, slug string) (*model.BlogPost, error)
+}
+
+type blogUsecase struct {
+	repo repository.BlogRepo
+}
+
+// NewBlogUsecase creates a new BlogUsecase
+func NewBlogUsecase(repo repository.BlogRepo) BlogUsecase {
+	return &blogUsecase{repo: repo}
+}
+
+// Create stores a new draft. When the slug is derived from the title and
+// already used, -2, -3 and so on are appended until a free one is found.
+func (uc *blogUsecase) Create(ctx context.Context, author model.User, req dto.BlogPostRequest) (model.BlogPost, error) {
+	p := model.BlogPost{
+		Title:       strings.TrimSpace(req.Title),
+		Excerpt:     strings.TrimSpace(req.Excerpt),
+		Content:     req.Content,
+		AuthorID:    author.ID,
+	}
+
+	if req.Slug != "" {
+		if !slugPattern.MatchString(req.Slug) {
+			return model.BlogPost{}, ErrInvalidBlogSlug
+		}
+		p.Slug = req.Slug
+		created, err := uc.repo.Create(ctx, p)
+		if err != nil {
+			return model.BlogPost{}, mapBlogError(err)
+		}
+		return withHTML(created), nil
+	}
+
+	base := slugify(p.Title)
+	for i := 1; i <= slugAttempts; i++ {
+		p.Slug = base
+		if i > 1 {
+			p.Slug = fmt.Sprintf("%s-%d", base, i)
+		}
+		created, err := uc.repo.Create(ctx, p)
+		if errors.Is(err, repository.ErrBlogSlugTaken) {
+			continue
+		}
+		if err != nil {
+			return model.BlogPost{}, fmt.Errorf("create blog post: %w", err)
+		}
+		return withHTML(created), nil
+	}
+	return model.BlogPost{}, ErrBlogSlugTaken
+}
+
+// Update replaces title, excerpt and content. The slug only changes when
+// one is given, so editing a title doesn't break links to the post.
+func (uc *blogUsecase) Update(ctx context.Context, id int32, req dto.BlogPostRequest) (model.BlogPost, error) {
+	p, err := uc.repo.FindByID(ctx, id)
+	if err != nil {
+		return model.BlogPost{}, mapBlogError(err)
+	}
+	if req.Slug != "" {
+		if !slugPattern.MatchString(req.Slug) {
+			return model.BlogPost{}, ErrInvalidBlogSlug
+		}
+		p.Slug = req.Slug
+	}
+	p.Title = strings.TrimSpace(req.Title)
+	p.Excerpt = strings.TrimSpace(req.Excerpt)
+	p.Content = req.Content
+
+	updated, err := uc.repo.Update(ctx, *p)
+	if err != nil {
+		return model.BlogPost{}, mapBlogError(err)
+	}
+	return withHTML(updated), nil
+}
+
+// Publish makes a post live at req.PublishedAt, or now when it is empty.
+// Publishing a post that is already live without a time keeps its original
+// publication date.
+func (uc *blogUsecase) Publish(ctx context.Context, id int32, req dto.PublishBlogPostRequest) (model.BlogPost, error) {
+	at := time.Now()
+	if req.PublishedAt != nil {
+		at = *req.PublishedAt
+	} else {
+		p, err := uc.repo.FindByID(ctx, id)
+		if err != nil {
+			return model.BlogPost{}, mapBlogError(err)
+		}
+		if p.Status == "published" {
+			return withHTML(*p), nil
+		}
+	}
+
+	p, err := uc.repo.Publish(ctx, id, at)
+	if err != nil {
+		return model.BlogPost{}, mapBlogError(err)
+	}
+	return withHTML(p), nil
+}
+
+// Unpublish turns a published or scheduled post back into a draft.
+func (uc *blogUsecase) Unpublish(ctx context.Context, id int32) (model.BlogPost, error) {
+	p, err := uc.repo.Unpublish(ctx, id)
+	if err != nil {
+		return model.BlogPost{}, mapBlogError(err)
+	}
+	return withHTML(p), nil
+}
+
+func (uc *blogUsecase) GetByID(ctx context.Context, id int32) (*model.BlogPost, error) {
+	p, err := uc.repo.FindByID(ctx, id)
+	if err != nil {
+		return nil, mapBlogError(err)
+	}
+	res := withHTML(*p)
+	return &res, nil
+}
+
+// List returns posts of every status for the admin, most recently
+// published or edited first, without their bodies.
+func (uc *blogUsecase) List(ctx context.Context, query dto.ListBlogPostsQuery) (*model.BlogPostPage, error) {
+	limit := blogPageLimit(query.Limit)
+	posts, total, err := uc.repo.List(ctx, query.Status, limit, query.Offset)
+	if err != nil {
+		return nil, err
+	}
+	return blogPage(posts, total, limit, query.Offset), nil
+}
+
+// ListPublished returns live posts newest first, without their bodies.
+func (uc *blogUsecase) ListPublished(ctx context.Context, query dto.BlogPostsQuery) (*model.BlogPostPage, error) {
+	limit := blogPageLimit(query.Limit)
+	posts, total, err := uc.repo.ListPublished(ctx, limit, query.Offset)
+	if err != nil {
+		return nil, err
+	}
+	return blogPage(posts, total, limit, query.Offset), nil
+}
+
+// GetPublished returns a live post by slug with its rendered HTML. The
+// Markdown source is left out.
+func (uc *blogUsecase) GetPublished(ctx context.Context, slug string) (*model.BlogPost, error) {
+	p, err := uc.repo.FindPublishedBySlug(ctx, slug)
+	if err != nil {
+		return nil, mapBlogError(err)
+	}
+	res := withHTML(*p)
+	res.Content = ""
+	return &res, nil
+}
+
+// withHTML renders the Markdown source of p.
+func withHTML(p model.BlogPost) model.BlogPost {
+	p.ContentHTML = markdown.Render(p.Content)
+	return p
+}
+
+func blogPageLimit(limit int32) int32 {
+	if limit == 0 {
+		return defaultBlogPageLimit
+	}
+	return limit
+}
+
+// blogPage strips post bodies, listings only need titles and excerpts.
+func blogPage(posts []model.BlogPost, total int64, limit, offset int32) *model.BlogPostPage {
+	for i := range posts {
+		posts[i].Content = ""
+	}
+	return &model.BlogPostPage{Posts: posts, Total: total, Limit: limit, Offset: offset}
+}
+
+// slugify derives a URL slug from a title: ASCII letters and digits are
+// kept in lowercase and every other run of characters becomes one dash.
+func slugify(title string) string {
+	var b strings.Builder
+	dash := false
+	for _, r := range strings.ToLower(title) {
+		switch {
+		case r >= 'a' && r <= 'z', r >= '0' && r <= '9':
+			if dash && b.Len() > 0 {
+				b.WriteByte('-')
+			}
+			b.WriteRune(r)
+			dash = false
+		default:
+			dash = true
+		}
+		if b.Len() >= maxSlugLength {
+			break
+		}
+	}
+	slug := strings.TrimRight(b.String(), "-")
+	if slug == "" {
+		return "post"
+	}
+	return slug
+}
+
+func mapBlogError(err error) error {
+	switch {
+	case errors.Is(err, repository.ErrBlogPostNotFound):
+		return ErrBlogPostNotFound
+	case errors.Is(err, repository.ErrBlogSlugTaken):
+		return ErrBlogSlugTaken
+	}
+	return err
+}
@@ -0,0 +1,168 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/AndikaPrasetia/wash-shoe/internal/dto"
+	"github.com/AndikaPrasetia/wash-shoe/internal/model"
+	"github.com/AndikaPrasetia/wash-shoe/internal/repository"
+)
+
+var (
+	ErrComplaintNotFound       = errors.New("complaint not found")
+	ErrComplaintForbidden      = errors.New("you don't have access to this complaint")
+	ErrComplaintClosed         = errors.New("complaint is already closed")
+	ErrComplaintNotOpen        = errors.New("only open complaints can be resolved")
+	ErrComplaintStatusConflict = errors.New("complaint status was changed by someone else, please retry")
+)
+
+// complaintTransitions lists, for every complaint status, the statuses it
+// may move to. closed is terminal.
+var complaintTransitions = map[string][]string{
+	"open":     {"resolved", "closed"},
+	"resolved": {"closed"},
+	"closed":   {},
+}
+
+// ComplaintUsecase defines business logic for complaints about orders
+type ComplaintUsecase interface {
+	Create(ctx context.Context, requester model.User, orderID int32, req dto.CreateComplaintRequest) (model.Complaint, error)
+	List(ctx context.Context, requester model.User) ([]model.Complaint, error)
+	GetByID(ctx context.Context, requester model.User, id int32) (*model.Complaint, error)
+	ListAll(ctx context.Context, query dto.ListComplaintsQuery) ([]model.Complaint, error)
+	AddNote(ctx context.Context, actor model.User, id int32, req dto.ComplaintNoteRequest) (model.ComplaintNote, error)
+	Resolve(ctx context.Context, actor model.User, id int32, req dto.ComplaintStatusRequest) (model.Complaint, error)
+	Close(ctx context.Context, actor model.User, id int32, req dto.ComplaintStatusRequest) (model.Complaint, error)
+}
+
+type complaintUsecase struct {
+	repo    repository.ComplaintRepo
+	orderUC OrderUsecase
+}
+
+// NewComplaintUsecase creates a new ComplaintUsecase
+func NewComplaintUsecase(repo repository.ComplaintRepo, orderUC OrderUsecase) ComplaintUsecase {
+	return &complaintUsecase{repo: repo, orderUC: orderUC}
+}
+
+// Create files a complaint against one of the requester's own orders.
+func (uc *complaintUsecase) Create(ctx context.Context, requester model.User, orderID int32, req dto.CreateComplaintRequest) (model.Complaint, error) {
+	o, err := uc.orderUC.GetByID(ctx, requester, orderID)
+	if err != nil {
+		return model.Complaint{}, err
+	}
+	// admins can see the order, but only its owner can complain about it
+	if o.UserID != requester.ID {
+		return model.Complaint{}, ErrOrderForbidden
+	}
+
+	c, err := uc.repo.Create(ctx, model.Complaint{
+		UserID:      requester.ID,
+		OrderID:     o.ID,
+		Description: req.Description,
+	}, fmt.Sprintf("We received your complaint about order #%d and will look into it.", o.ID))
+	if err != nil {
+		return model.Complaint{}, fmt.Errorf("create complaint: %w", err)
+	}
+	return c, nil
+}
+
+func (uc *complaintUsecase) List(ctx context.Context, requester model.User) ([]model.Complaint, error) {
+	return uc.repo.ListByUser(ctx, requester.ID)
+}
+
+// GetByID returns the complaint with its notes when requester filed it or
+// is an admin.
+func (uc *complaintUsecase) GetByID(ctx context.Context, requester model.User, id int32) (*model.Complaint, error) {
+	c, err := uc.repo.FindByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, repository.ErrComplaintNotFound) {
+			return nil, ErrComplaintNotFound
+		}
+		return nil, err
+	}
+	if c.UserID != requester.ID && requester.Role != "admin" {
+		return nil, ErrComplaintForbidden
+	}
+	return c, nil
+}
+
+// ListAll returns complaints of every customer, oldest first, filtered by
+// status and by how many hours ago they were filed.
+func (uc *complaintUsecase) ListAll(ctx context.Context, query dto.ListComplaintsQuery) ([]model.Complaint, error) {
+	now := time.Now()
+	var createdBefore, createdAfter *time.Time
+	if query.MinAgeHours > 0 {
+		t := now.Add(-time.Duration(query.MinAgeHours) * time.Hour)
+		createdBefore = &t
+	}
+	if query.MaxAgeHours > 0 {
+		t := now.Add(-time.Duration(query.MaxAgeHours) * time.Hour)
+		createdAfter = &t
+	}
+	return uc.repo.List(ctx, query.Status, createdBefore, createdAfter)
+}
+
+// AddNote adds a resolution note to a complaint that isn't closed yet.
+func (uc *complaintUsecase) AddNote(ctx context.Context, actor model.User, id int32, req dto.ComplaintNoteRequest) (model.ComplaintNote, error) {
+	c, err := uc.GetByID(ctx, actor, id)
+	if err != nil {
+		return model.ComplaintNote{}, err
+	}
+	if c.Status == "closed" {
+		return model.ComplaintNote{}, ErrComplaintClosed
+	}
+
+	notice := fmt.Sprintf("There is an update on your complaint about order #%d: %s", c.OrderID, req.Note)
+	n, err := uc.repo.AddNote(ctx, c.ID, actor.ID, req.Note, notice)
+	if err != nil {
+		if errors.Is(err, repository.ErrComplaintNotFound) {
+			return model.ComplaintNote{}, ErrComplaintNotFound
+		}
+		return model.ComplaintNote{}, fmt.Errorf("add complaint note: %w", err)
+	}
+	return n, nil
+}
+
+func (uc *complaintUsecase) Resolve(ctx context.Context, actor model.User, id int32, req dto.ComplaintStatusRequest) (model.Complaint, error) {
+	return uc.transition(ctx, actor, id, "resolved", req.Note)
+}
+
+func (uc *complaintUsecase) Close(ctx context.Context, actor model.User, id int32, req dto.ComplaintStatusRequest) (model.Complaint, error) {
+	return uc.transition(ctx, actor, id, "closed", req.Note)
+}
+
+// transition validates a status change against complaintTransitions and
+// persists it together with the optional note.
+func (uc *complaintUsecase) transition(ctx context.Context, actor model.User, id int32, to, note string) (model.Complaint, error) {
+	c, err := uc.GetByID(ctx, actor, id)
+	if err != nil {
+		return model.Complaint{}, err
+	}
+	if !slices.Contains(complaintTransitions[c.Status], to) {
+		if c.Status == "closed" {
+			return model.Complaint{}, ErrComplaintClosed
+		}
+		return model.Complaint{}, ErrComplaintNotOpen
+	}
+
+	notice := fmt.Sprintf("Your complaint about order #%d has been %s.", c.OrderID, to)
+	if note != "" {
+		notice += " " + note
+	}
+	updated, err := uc.repo.UpdateStatus(ctx, c.ID, c.Status, to, actor.ID, note, notice)
+	if err != nil {
+		switch {
+		case errors.Is(err, repository.ErrComplaintNotFound):
+			return model.Complaint{}, ErrComplaintNotFound
+		case errors.Is(err, repository.ErrComplaintStatusChanged):
+			return model.Complaint{}, ErrComplaintStatusConflict
+		}
+		return model.Complaint{}, fmt.Errorf("update complaint status: %w", err)
+	}
+	return updated, nil
+}
@@ -0,0 +1,230 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/AndikaPrasetia/wash-shoe/internal/config"
+	"github.com/AndikaPrasetia/wash-shoe/internal/dto"
+	"github.com/AndikaPrasetia/wash-shoe/internal/model"
+	"github.com/AndikaPrasetia/wash-shoe/internal/notification"
+	"github.com/AndikaPrasetia/wash-shoe/internal/redis"
+	"github.com/AndikaPrasetia/wash-shoe/internal/repository"
+)
+
+var ErrNotificationNotFound = errors.New("notification not found")
+
+const (
+	notificationBatchSize = 50
+	// notificationLease bounds how long a claimed notification may stay in
+	// flight before a later round picks it up again.
+	notificationLease       = 5 * time.Minute
+	notificationSendTimeout = 30 * time.Second
+	maxNotificationBackoff  = 6 * time.Hour
+
+	defaultInboxLimit = 20
+	unreadCountTTL    = time.Hour
+)
+
+// unreadCountKey holds the number of unread in-app notifications of a user.
+// It is rebuilt from the database when missing and adjusted in place while
+// it exists; the TTL bounds any drift.
+func unreadCountKey(userID string) string {
+	return "notifications:unread:" + userID
+}
+
+// NotificationUsecase delivers pending notifications through the notifier
+// of their channel and serves the customer's in-app inbox.
+type NotificationUsecase interface {
+	// Run dispatches due notifications every poll interval until ctx is done.
+	Run(ctx context.Context)
+	// Dispatch delivers one batch of due notifications and returns its size.
+	Dispatch(ctx context.Context) (int, error)
+
+	Inbox(ctx context.Context, userID string, query dto.ListNotificationsQuery) (*model.NotificationPage, error)
+	MarkRead(ctx context.Context, userID string, id int32) (model.Notification, error)
+	MarkAllRead(ctx context.Context, userID string) (int64, error)
+	UnreadCount(ctx context.Context, userID string) (int64, error)
+}
+
+type notificationUsecase struct {
+	repo      repository.NotificationRepo
+	notifiers *notification.Registry
+	redisCli  *redis.RedisClient
+	cfg       config.NotificationConfig
+}
+
+// NewNotificationUsecase creates a new NotificationUsecase
+func NewNotificationUsecase(repo repository.NotificationRepo, notifiers *notification.Registry, redisCli *redis.RedisClient, cfg config.NotificationConfig) NotificationUsecase {
+	return &notificationUsecase{repo: repo, notifiers: notifiers, redisCli: redisCli, cfg: cfg}
+}
+
+// Run drains the due notifications on every tick. A round that fails is
+// logged and tried again on the next tick; notifications it had claimed
+// come back once their lease runs out.
+func (uc *notificationUsecase) Run(ctx context.Context) {
+	ticker := time.NewTicker(uc.cfg.PollInterval)
+	defer ticker.Stop()
+	for {
+		for {
+			n, err := uc.Dispatch(ctx)
+			if err != nil {
+				log.Printf("dispatch notifications: %v", err)
+				break
+			}
+			if n < notificationBatchSize {
+				break
+			}
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Dispatch delivers the whole batch it claimed. A notification whose
+// outcome can't be recorded is logged with its ID and channel and comes
+// back once its lease runs out; the rest of the batch goes on.
+func (uc *notificationUsecase) Dispatch(ctx context.Context) (int, error) {
+	pending, err := uc.repo.ClaimPending(ctx, notificationBatchSize, notificationLease)
+	if err != nil {
+		return 0, fmt.Errorf("claim notifications: %w", err)
+	}
+	for _, n := range pending {
+		if err := uc.deliver(ctx, n); err != nil {
+			log.Printf("deliver notification %d on %s: %v", n.ID, n.Channel, err)
+		}
+	}
+	return len(pending), nil
+}
+
+// deliver sends n once and records the outcome. Failures are retried with
+// exponential backoff until MaxAttempts, except those the notifier reports
+// as permanent.
+func (uc *notificationUsecase) deliver(ctx context.Context, n model.PendingNotification) error {
+	notifier, ok := uc.notifiers.Get(n.Channel)
+	if !ok {
+		if err := uc.repo.MarkFailed(ctx, n.ID, "channel "+n.Channel+" is not configured"); err != nil {
+			return fmt.Errorf("mark failed: %w", err)
+		}
+		return nil
+	}
+
+	m := notification.Message{
+		ID:      n.ID,
+		UserID:  n.UserID,
+		Subject: "WashShoe notification",
+		Body:    n.Message,
+	}
+	if n.OrderID != nil {
+		m.Subject = fmt.Sprintf("Update on your order #%d", *n.OrderID)
+	}
+	switch n.Channel {
+	case notification.ChannelEmail:
+		m.To = n.Email
+	case notification.ChannelWhatsApp:
+		m.To = n.PhoneNumber
+	}
+
+	sendCtx, cancel := context.WithTimeout(ctx, notificationSendTimeout)
+	err := notifier.Send(sendCtx, m)
+	cancel()
+	if err == nil {
+		if err := uc.repo.MarkSent(ctx, n.ID); err != nil {
+			return fmt.Errorf("sent, but mark sent: %w", err)
+		}
+		if n.Channel == notification.ChannelInApp {
+			_ = uc.redisCli.IncrIfExists(ctx, unreadCountKey(n.UserID), 1)
+		}
+		return nil
+	}
+	if notification.IsPermanent(err) || int(n.Attempts) >= uc.cfg.MaxAttempts {
+		if markErr := uc.repo.MarkFailed(ctx, n.ID, err.Error()); markErr != nil {
+			return fmt.Errorf("send: %v, mark failed: %w", err, markErr)
+		}
+		return nil
+	}
+	if markErr := uc.repo.MarkRetry(ctx, n.ID, time.Now().Add(uc.backoff(n.Attempts)), err.Error()); markErr != nil {
+		return fmt.Errorf("send: %v, mark retry: %w", err, markErr)
+	}
+	return nil
+}
+
+// backoff is the wait after the given number of failed attempts.
+func (uc *notificationUsecase) backoff(attempts int32) time.Duration {
+	d := uc.cfg.RetryBackoff
+	for i := int32(1); i < attempts && d < maxNotificationBackoff; i++ {
+		d *= 2
+	}
+	return min(d, maxNotificationBackoff)
+}
+
+// Inbox returns a page of the user's in-app notifications, newest first, 20
+// per page unless query sets a limit.
+func (uc *notificationUsecase) Inbox(ctx context.Context, userID string, query dto.ListNotificationsQuery) (*model.NotificationPage, error) {
+	limit := query.Limit
+	if limit == 0 {
+		limit = defaultInboxLimit
+	}
+	// one extra row tells whether there is a next page
+	ns, err := uc.repo.ListInbox(ctx, userID, query.Cursor, limit+1)
+	if err != nil {
+		return nil, err
+	}
+	page := &model.NotificationPage{Notifications: ns}
+	if len(ns) > int(limit) {
+		page.Notifications = ns[:limit]
+		page.NextCursor = &ns[limit-1].ID
+	}
+	return page, nil
+}
+
+// MarkRead marks one of the user's notifications read. Marking a read
+// notification again changes nothing.
+func (uc *notificationUsecase) MarkRead(ctx context.Context, userID string, id int32) (model.Notification, error) {
+	n, changed, err := uc.repo.MarkRead(ctx, userID, id)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotificationNotFound) {
+			return model.Notification{}, ErrNotificationNotFound
+		}
+		return model.Notification{}, fmt.Errorf("mark notification read: %w", err)
+	}
+	if changed {
+		_ = uc.redisCli.IncrIfExists(ctx, unreadCountKey(userID), -1)
+	}
+	return n, nil
+}
+
+// MarkAllRead marks every unread notification of the user read and returns
+// how many there were.
+func (uc *notificationUsecase) MarkAllRead(ctx context.Context, userID string) (int64, error) {
+	n, err := uc.repo.MarkAllRead(ctx, userID)
+	if err != nil {
+		return 0, fmt.Errorf("mark notifications read: %w", err)
+	}
+	// dropped rather than zeroed, a notification delivered meanwhile would
+	// be lost from the count
+	_ = uc.redisCli.GetClient().Del(ctx, unreadCountKey(userID)).Err()
+	return n, nil
+}
+
+// UnreadCount serves the unread count from Redis, counting in the database
+// only when the counter is missing.
+func (uc *notificationUsecase) UnreadCount(ctx context.Context, userID string) (int64, error) {
+	key := unreadCountKey(userID)
+	if n, err := uc.redisCli.GetClient().Get(ctx, key).Int64(); err == nil {
+		return n, nil
+	}
+
+	n, err := uc.repo.CountUnread(ctx, userID)
+	if err != nil {
+		return 0, err
+	}
+	_ = uc.redisCli.GetClient().SetNX(ctx, key, n, unreadCountTTL).Err()
+	return n, nil
+}
@@ -0,0 +1,74 @@
+package usecase
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"log"
+	"os"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/AndikaPrasetia/wash-shoe/internal/config"
+	"github.com/AndikaPrasetia/wash-shoe/internal/model"
+	"github.com/AndikaPrasetia/wash-shoe/internal/notification"
+	"github.com/AndikaPrasetia/wash-shoe/internal/repository"
+)
+
+// batchNotificationRepo hands out one batch and fails to record the IDs in
+// brokenIDs.
+type batchNotificationRepo struct {
+	repository.NotificationRepo
+	batch     []model.PendingNotification
+	brokenIDs []int32
+	sent      []int32
+}
+
+func (r *batchNotificationRepo) ClaimPending(ctx context.Context, limit int32, lease time.Duration) ([]model.PendingNotification, error) {
+	return r.batch, nil
+}
+
+func (r *batchNotificationRepo) MarkSent(ctx context.Context, id int32) error {
+	if slices.Contains(r.brokenIDs, id) {
+		return errors.New("connection reset")
+	}
+	r.sent = append(r.sent, id)
+	return nil
+}
+
+type okNotifier struct{}
+
+func (okNotifier) Channel() string                                        { return notification.ChannelEmail }
+func (okNotifier) Send(ctx context.Context, m notification.Message) error { return nil }
+
+func TestDispatchLogsFailuresAndGoesOn(t *testing.T) {
+	var logs bytes.Buffer
+	log.SetOutput(&logs)
+	t.Cleanup(func() { log.SetOutput(os.Stderr) })
+
+	repo := &batchNotificationRepo{
+		batch: []model.PendingNotification{
+			{ID: 1, Channel: notification.ChannelEmail},
+			{ID: 2, Channel: notification.ChannelEmail},
+			{ID: 3, Channel: notification.ChannelEmail},
+		},
+		brokenIDs: []int32{2},
+	}
+	uc := NewNotificationUsecase(repo, notification.NewRegistry(okNotifier{}), nil, config.NotificationConfig{MaxAttempts: 3})
+
+	n, err := uc.Dispatch(context.Background())
+	if err != nil {
+		t.Fatalf("Dispatch() error = %v", err)
+	}
+	if n != 3 {
+		t.Errorf("Dispatch() = %d, want 3", n)
+	}
+	if !slices.Equal(repo.sent, []int32{1, 3}) {
+		t.Errorf("marked sent %v, want [1 3]", repo.sent)
+	}
+	if got := logs.String(); !strings.Contains(got, "notification 2 on email") || !strings.Contains(got, "connection reset") {
+		t.Errorf("log = %q, want the ID, channel and error of notification 2", got)
+	}
+}
@@ -0,0 +1,292 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/AndikaPrasetia/wash-shoe/internal/config"
+	"github.com/AndikaPrasetia/wash-shoe/internal/dto"
+	"github.com/AndikaPrasetia/wash-shoe/internal/model"
+	"github.com/AndikaPrasetia/wash-shoe/internal/repository"
+)
+
+var (
+	ErrOrderNotFound       = errors.New("order not found")
+	ErrOrderForbidden      = errors.New("you don't have access to this order")
+	ErrOrderNotCancellable = errors.New("order can no longer be cancelled")
+	ErrServiceTypeNotFound = errors.New("service type not found")
+	ErrPriceMismatch       = errors.New("service price does not match the current price")
+	ErrInvalidOrderStatus  = errors.New("unknown order status")
+	ErrOrderStatusConflict = errors.New("order status was changed by someone else, please retry")
+)
+
+// slaStageDeadline gives, for every open order_status, the share of the SLA
+// window (created_at to promised_by) by which an order should have moved
+// past that status. Orders still in it later are at risk of breaching.
+var slaStageDeadline = map[string]float64{
+	"pending":            0.25,
+	"processing":         0.4,
+	"cleaning":           0.8,
+	"ready_for_delivery": 0.9,
+}
+
+// InvalidTransitionError is returned when an order status change is not
+// allowed by model.OrderTransitions.
+type InvalidTransitionError struct {
+	From string
+	To   string
+}
+
+func (e *InvalidTransitionError) Error() string {
+	return fmt.Sprintf("cannot change order status from %s to %s", e.From, e.To)
+}
+
+// checkTransition validates a status change against model.OrderTransitions.
+func checkTransition(from, to string) error {
+	if _, ok := model.OrderTransitions[to]; !ok {
+		return ErrInvalidOrderStatus
+	}
+	if !slices.Contains(model.OrderTransitions[from], to) {
+		return &InvalidTransitionError{From: from, To: to}
+	}
+	return nil
+}
+
+// OrderUsecase defines business logic for customer orders
+type OrderUsecase interface {
+	Create(ctx context.Context, userID string, req dto.CreateOrderRequest) (*model.Order, error)
+	Quote(ctx context.Context, req dto.CreateOrderRequest) (*model.OrderQuote, error)
+	List(ctx context.Context, userID string) ([]model.Order, error)
+	GetByID(ctx context.Context, requester model.User, id int32) (*model.Order, error)
+	Cancel(ctx context.Context, requester model.User, id int32) (*model.Order, error)
+	UpdateStatus(ctx context.Context, actor model.User, id int32, status string) (*model.Order, error)
+	History(ctx context.Context, requester model.User, id int32) ([]model.OrderStatusHistory, error)
+	AtRisk(ctx context.Context) ([]model.AtRiskOrder, error)
+}
+
+type orderUsecase struct {
+	repo        repository.OrderRepo
+	addressRepo repository.AddressRepo
+	promoUC     PromoUsecase
+	events      OrderEventUsecase
+	cfg         config.OrderConfig
+}
+
+// NewOrderUsecase creates a new OrderUsecase
+func NewOrderUsecase(repo repository.OrderRepo, addressRepo repository.AddressRepo, promoUC PromoUsecase, events OrderEventUsecase, cfg config.OrderConfig) OrderUsecase {
+	return &orderUsecase{repo: repo, addressRepo: addressRepo, promoUC: promoUC, events: events, cfg: cfg}
+}
+
+// Create prices the order like Quote and stores it with its lines through
+// create_order_with_services, which also redeems the promo.
+func (uc *orderUsecase) Create(ctx context.Context, userID string, req dto.CreateOrderRequest) (*model.Order, error) {
+	// pickup address must come from the customer's own address book
+	if req.AddressID != nil {
+		if _, err := uc.addressRepo.FindByID(ctx, userID, *req.AddressID); err != nil {
+			if errors.Is(err, repository.ErrAddressNotFound) {
+				return nil, ErrAddressNotFound
+			}
+			return nil, fmt.Errorf("load address: %w", err)
+		}
+	}
+
+	o, _, err := uc.price(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	o.UserID = userID
+	o.AddressID = req.AddressID
+
+	id, err := uc.repo.Create(ctx, o)
+	if err != nil {
+		if errors.Is(err, repository.ErrPromoNotRedeemable) {
+			// lost the race for the last redemption
+			return nil, ErrPromoUsedUp
+		}
+		return nil, fmt.Errorf("create order: %w", err)
+	}
+	return uc.repo.FindByID(ctx, id)
+}
+
+// Quote returns the price breakdown Create would charge for req.
+func (uc *orderUsecase) Quote(ctx context.Context, req dto.CreateOrderRequest) (*model.OrderQuote, error) {
+	_, quote, err := uc.price(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	return &quote, nil
+}
+
+// price checks every requested service against service_types.base_price,
+// applies the promo discount to subtotal plus express fee and sets the
+// promised-by deadline.
+func (uc *orderUsecase) price(ctx context.Context, req dto.CreateOrderRequest) (model.Order, model.OrderQuote, error) {
+	ids := make([]int32, len(req.Services))
+	for i, s := range req.Services {
+		ids[i] = s.ServiceTypeID
+	}
+	serviceTypes, err := uc.repo.ListServiceTypes(ctx, ids)
+	if err != nil {
+		return model.Order{}, model.OrderQuote{}, fmt.Errorf("load service types: %w", err)
+	}
+	catalog := make(map[int32]model.ServiceType, len(serviceTypes))
+	for _, st := range serviceTypes {
+		catalog[st.ID] = st
+	}
+
+	o := model.Order{
+		IsExpress: req.IsExpress,
+		Services:  make([]model.OrderService, len(req.Services)),
+	}
+	var quote model.OrderQuote
+	var durationHours int32
+	for i, s := range req.Services {
+		st, ok := catalog[s.ServiceTypeID]
+		if !ok {
+			return model.Order{}, model.OrderQuote{}, ErrServiceTypeNotFound
+		}
+		// client may send the price it displayed; it must still be current
+		if s.Price != nil && roundMoney(*s.Price) != roundMoney(st.BasePrice) {
+			return model.Order{}, model.OrderQuote{}, ErrPriceMismatch
+		}
+		o.Services[i] = model.OrderService{
+			ServiceTypeID: st.ID,
+			ServiceName:   st.Name,
+			Quantity:      s.Quantity,
+			Price:         st.BasePrice,
+		}
+		quote.Subtotal += st.BasePrice * float64(s.Quantity)
+
+		// services are worked on side by side, the slowest one sets the pace
+		hours := int32(uc.cfg.DefaultDurationHours)
+		if st.EstimatedDurationHours != nil {
+			hours = *st.EstimatedDurationHours
+		}
+		durationHours = max(durationHours, hours)
+	}
+	quote.Subtotal = roundMoney(quote.Subtotal)
+
+	if req.IsExpress {
+		o.ExpressFee = roundMoney(uc.cfg.ExpressFee)
+		percent := int32(uc.cfg.ExpressDurationPercent)
+		durationHours = max((durationHours*percent+99)/100, 1)
+	}
+	quote.ExpressFee = o.ExpressFee
+	quote.PromisedBy = time.Now().Add(time.Duration(durationHours) * time.Hour)
+
+	if code := strings.TrimSpace(req.PromoCode); code != "" {
+		discount, err := uc.promoUC.Discount(ctx, code, quote.Subtotal+quote.ExpressFee)
+		if err != nil {
+			return model.Order{}, model.OrderQuote{}, err
+		}
+		quote.PromoCode = code
+		quote.Discount = discount
+	}
+	quote.Total = roundMoney(quote.Subtotal + quote.ExpressFee - quote.Discount)
+
+	o.PromoCode = quote.PromoCode
+	o.TotalPrice = quote.Total
+	o.PromisedBy = &quote.PromisedBy
+	return o, quote, nil
+}
+
+func (uc *orderUsecase) List(ctx context.Context, userID string) ([]model.Order, error) {
+	return uc.repo.ListByUser(ctx, userID)
+}
+
+// GetByID returns the order when requester owns it or is an admin.
+func (uc *orderUsecase) GetByID(ctx context.Context, requester model.User, id int32) (*model.Order, error) {
+	o, err := uc.repo.FindByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, repository.ErrOrderNotFound) {
+			return nil, ErrOrderNotFound
+		}
+		return nil, err
+	}
+	if o.UserID != requester.ID && requester.Role != "admin" {
+		return nil, ErrOrderForbidden
+	}
+	return o, nil
+}
+
+// Cancel cancels an order. Customers may only cancel orders that have not
+// been picked up for processing yet; admins follow model.OrderTransitions.
+func (uc *orderUsecase) Cancel(ctx context.Context, requester model.User, id int32) (*model.Order, error) {
+	o, err := uc.GetByID(ctx, requester, id)
+	if err != nil {
+		return nil, err
+	}
+	if requester.Role != "admin" && o.Status != "pending" {
+		return nil, ErrOrderNotCancellable
+	}
+	if err := checkTransition(o.Status, "cancelled"); err != nil {
+		return nil, ErrOrderNotCancellable
+	}
+	return uc.transition(ctx, o, "cancelled", requester.ID)
+}
+
+// UpdateStatus moves an order to the given status on behalf of an admin.
+func (uc *orderUsecase) UpdateStatus(ctx context.Context, actor model.User, id int32, status string) (*model.Order, error) {
+	o, err := uc.GetByID(ctx, actor, id)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkTransition(o.Status, status); err != nil {
+		return nil, err
+	}
+	return uc.transition(ctx, o, status, actor.ID)
+}
+
+// History returns the status changes recorded by update_order_status.
+func (uc *orderUsecase) History(ctx context.Context, requester model.User, id int32) ([]model.OrderStatusHistory, error) {
+	if _, err := uc.GetByID(ctx, requester, id); err != nil {
+		return nil, err
+	}
+	return uc.repo.ListStatusHistory(ctx, id)
+}
+
+// AtRisk lists open orders that are behind schedule according to
+// slaStageDeadline, as well as those already past promised_by.
+func (uc *orderUsecase) AtRisk(ctx context.Context) ([]model.AtRiskOrder, error) {
+	orders, err := uc.repo.ListOpenWithDeadline(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	res := make([]model.AtRiskOrder, 0, len(orders))
+	for _, o := range orders {
+		window := o.PromisedBy.Sub(o.CreatedAt)
+		elapsed := now.Sub(o.CreatedAt)
+		breached := now.After(*o.PromisedBy)
+		if !breached && window > 0 && elapsed.Seconds() <= window.Seconds()*slaStageDeadline[o.Status] {
+			continue
+		}
+		res = append(res, model.AtRiskOrder{Order: o, Breached: breached})
+	}
+	return res, nil
+}
+
+// transition persists an already validated status change and announces it
+// to tracking streams.
+func (uc *orderUsecase) transition(ctx context.Context, o *model.Order, to, actorID string) (*model.Order, error) {
+	err := uc.repo.UpdateStatus(ctx, o.ID, o.Status, to, actorID)
+	if err != nil {
+		if errors.Is(err, repository.ErrOrderStatusChanged) {
+			return nil, ErrOrderStatusConflict
+		}
+		return nil, fmt.Errorf("update order status: %w", err)
+	}
+	uc.events.Publish(ctx, o.ID)
+	return uc.repo.FindByID(ctx, o.ID)
+}
+
+// roundMoney rounds an amount to NUMERIC(10,2) precision.
+func roundMoney(v float64) float64 {
+	return math.Round(v*100) / 100
+}
@@ -0,0 +1,152 @@
+package usecase
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/AndikaPrasetia/wash-shoe/internal/model"
+	"github.com/AndikaPrasetia/wash-shoe/internal/redis"
+	"github.com/AndikaPrasetia/wash-shoe/internal/repository"
+)
+
+// orderEventsChannel is the Redis channel every app instance publishes
+// order status changes on and relays them from.
+const orderEventsChannel = "orders:status"
+
+// orderEventBuffer is how many events a slow stream may fall behind before
+// further events are dropped for it. Every event carries the full status,
+// so the stream catches up with the next one.
+const orderEventBuffer = 16
+
+// OrderEventUsecase streams order status changes to subscribers on any app
+// instance through Redis pub/sub.
+type OrderEventUsecase interface {
+	// Publish announces the current status of the order. It is best effort:
+	// the change itself is already stored.
+	Publish(ctx context.Context, orderID int32)
+	// Subscribe returns the status changes of the order, starting with its
+	// current status, and a func that ends the subscription. Access to the
+	// order is checked by the caller.
+	Subscribe(ctx context.Context, orderID int32) (<-chan model.OrderEvent, func(), error)
+	// Run relays published events to local subscribers until ctx is done.
+	Run(ctx context.Context)
+}
+
+type orderEventUsecase struct {
+	repo     repository.OrderRepo
+	redisCli *redis.RedisClient
+
+	mu   sync.Mutex
+	subs map[int32]map[chan model.OrderEvent]struct{}
+}
+
+// NewOrderEventUsecase creates a new OrderEventUsecase
+func NewOrderEventUsecase(repo repository.OrderRepo, redisCli *redis.RedisClient) OrderEventUsecase {
+	return &orderEventUsecase{
+		repo:     repo,
+		redisCli: redisCli,
+		subs:     make(map[int32]map[chan model.OrderEvent]struct{}),
+	}
+}
+
+func (uc *orderEventUsecase) Publish(ctx context.Context, orderID int32) {
+	o, err := uc.repo.FindByID(ctx, orderID)
+	if err != nil {
+		return
+	}
+	ev, err := json.Marshal(orderEvent(*o))
+	if err != nil {
+		return
+	}
+	_ = uc.redisCli.GetClient().Publish(ctx, orderEventsChannel, ev).Err()
+}
+
+// Subscribe registers before reading the current status, so a change made
+// in between is sent rather than missed.
+func (uc *orderEventUsecase) Subscribe(ctx context.Context, orderID int32) (<-chan model.OrderEvent, func(), error) {
+	ch := make(chan model.OrderEvent, orderEventBuffer)
+
+	uc.mu.Lock()
+	if uc.subs[orderID] == nil {
+		uc.subs[orderID] = make(map[chan model.OrderEvent]struct{})
+	}
+	uc.subs[orderID][ch] = struct{}{}
+	uc.mu.Unlock()
+
+	var once sync.Once
+	unsubscribe := func() {
+		once.Do(func() {
+			uc.mu.Lock()
+			delete(uc.subs[orderID], ch)
+			if len(uc.subs[orderID]) == 0 {
+				delete(uc.subs, orderID)
+			}
+			uc.mu.Unlock()
+		})
+	}
+
+	o, err := uc.repo.FindByID(ctx, orderID)
+	if err != nil {
+		unsubscribe()
+		if errors.Is(err, repository.ErrOrderNotFound) {
+			return nil, nil, ErrOrderNotFound
+		}
+		return nil, nil, err
+	}
+	select {
+	case ch <- orderEvent(*o):
+	default:
+	}
+	return ch, unsubscribe, nil
+}
+
+// Run holds one Redis subscription per instance, however many streams are
+// open; go-redis reconnects it when the connection drops.
+func (uc *orderEventUsecase) Run(ctx context.Context) {
+	pubsub := uc.redisCli.GetClient().Subscribe(ctx, orderEventsChannel)
+	defer pubsub.Close()
+
+	msgs := pubsub.Channel()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case msg, ok := <-msgs:
+			if !ok {
+				return
+			}
+			var ev model.OrderEvent
+			if err := json.Unmarshal([]byte(msg.Payload), &ev); err != nil {
+				continue
+			}
+			uc.deliver(ev)
+		}
+	}
+}
+
+// deliver hands ev to the local subscribers of its order without waiting
+// on any of them.
+func (uc *orderEventUsecase) deliver(ev model.OrderEvent) {
+	uc.mu.Lock()
+	defer uc.mu.Unlock()
+	for ch := range uc.subs[ev.OrderID] {
+		select {
+		case ch <- ev:
+		default:
+		}
+	}
+}
+
+// orderEvent describes the current status of o. Final is set for statuses
+// with no way out in model.OrderTransitions.
+func orderEvent(o model.Order) model.OrderEvent {
+	return model.OrderEvent{
+		OrderID: o.ID,
+		Status:  o.Status,
+		Final:   len(model.OrderTransitions[o.Status]) == 0,
+		At:      time.Now(),
+	}
+}
@@ -0,0 +1,82 @@
+package usecase
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/AndikaPrasetia/wash-shoe/internal/model"
+)
+
+func TestCheckTransition(t *testing.T) {
+	tests := []struct {
+		from, to string
+		wantErr  error
+	}{
+		// the happy path
+		{"pending", "processing", nil},
+		{"processing", "cleaning", nil},
+		{"cleaning", "ready_for_delivery", nil},
+		{"ready_for_delivery", "completed", nil},
+		{"completed", "delivered", nil},
+		// cancelling before the shoes are worked on
+		{"pending", "cancelled", nil},
+		{"processing", "cancelled", nil},
+
+		// skipping or going back
+		{"pending", "cleaning", &InvalidTransitionError{}},
+		{"pending", "completed", &InvalidTransitionError{}},
+		{"cleaning", "processing", &InvalidTransitionError{}},
+		{"delivered", "completed", &InvalidTransitionError{}},
+		{"pending", "pending", &InvalidTransitionError{}},
+		// cancelling once work has started
+		{"cleaning", "cancelled", &InvalidTransitionError{}},
+		{"ready_for_delivery", "cancelled", &InvalidTransitionError{}},
+		{"completed", "cancelled", &InvalidTransitionError{}},
+		{"delivered", "cancelled", &InvalidTransitionError{}},
+		// terminal statuses
+		{"cancelled", "pending", &InvalidTransitionError{}},
+		{"cancelled", "processing", &InvalidTransitionError{}},
+		// statuses that don't exist
+		{"pending", "shipped", ErrInvalidOrderStatus},
+		{"pending", "", ErrInvalidOrderStatus},
+		{"unknown", "processing", &InvalidTransitionError{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.from+"->"+tt.to, func(t *testing.T) {
+			err := checkTransition(tt.from, tt.to)
+			switch want := tt.wantErr.(type) {
+			case nil:
+				if err != nil {
+					t.Fatalf("checkTransition() = %v, want nil", err)
+				}
+			case *InvalidTransitionError:
+				var got *InvalidTransitionError
+				if !errors.As(err, &got) {
+					t.Fatalf("checkTransition() = %v, want InvalidTransitionError", err)
+				}
+				if got.From != tt.from || got.To != tt.to {
+					t.Errorf("InvalidTransitionError = %+v, want from %q to %q", got, tt.from, tt.to)
+				}
+			default:
+				if !errors.Is(err, want) {
+					t.Fatalf("checkTransition() = %v, want %v", err, want)
+				}
+			}
+		})
+	}
+}
+
+func TestOrderTransitionsTargetsExist(t *testing.T) {
+	for from, targets := range model.OrderTransitions {
+		for _, to := range targets {
+			if _, ok := model.OrderTransitions[to]; !ok {
+				t.Errorf("%s -> %s: target status is missing from model.OrderTransitions", from, to)
+			}
+		}
+	}
+	for _, terminal := range []string{"delivered", "cancelled"} {
+		if n := len(model.OrderTransitions[terminal]); n != 0 {
+			t.Errorf("%s has %d targets, want none", terminal, n)
+		}
+	}
+}
@@ -0,0 +1,247 @@
+package usecase
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/AndikaPrasetia/wash-shoe/internal/dto"
+	"github.com/AndikaPrasetia/wash-shoe/internal/model"
+	"github.com/AndikaPrasetia/wash-shoe/internal/payment"
+	"github.com/AndikaPrasetia/wash-shoe/internal/repository"
+	"github.com/google/uuid"
+)
+
+var (
+	ErrPaymentMethodUnavailable = errors.New("payment method is not available")
+	ErrOrderNotPayable          = errors.New("only pending orders can be paid")
+	ErrOrderAlreadyPaid         = errors.New("order already has a payment")
+	ErrPaymentDeclined          = errors.New("payment was declined")
+	ErrPaymentGateway           = errors.New("payment provider is unavailable, please retry")
+	ErrUnknownPaymentProvider   = errors.New("unknown payment provider")
+	ErrInvalidWebhookSignature  = errors.New("invalid webhook signature")
+	ErrInvalidWebhookEvent      = errors.New("invalid webhook event")
+	ErrPaymentNotFound          = errors.New("payment not found")
+	ErrPaymentNotRefundable     = errors.New("only successful payments can be refunded")
+	ErrRefundExceedsPayment     = errors.New("refund exceeds the remaining payment amount")
+)
+
+// PaymentUsecase defines business logic for paying orders
+type PaymentUsecase interface {
+	Pay(ctx context.Context, requester model.User, orderID int32, req dto.CreatePaymentRequest) (*model.Payment, *model.Order, error)
+	// HandleWebhook applies a signed provider event. It returns false when
+	// the event had already been applied.
+	HandleWebhook(ctx context.Context, provider string, body []byte, signature string) (bool, error)
+	Refund(ctx context.Context, actor model.User, orderID int32, req dto.RefundRequest) (*model.Refund, *model.Order, error)
+	ListRefunds(ctx context.Context, requester model.User, orderID int32) ([]model.Refund, error)
+}
+
+type paymentUsecase struct {
+	repo           repository.PaymentRepo
+	walletRepo     repository.WalletRepo
+	orderUC        OrderUsecase
+	events         OrderEventUsecase
+	gateways       *payment.Registry
+	webhookSecrets map[string][]byte
+}
+
+// NewPaymentUsecase creates a new PaymentUsecase. webhookSecrets is keyed by
+// payment method.
+func NewPaymentUsecase(repo repository.PaymentRepo, walletRepo repository.WalletRepo, orderUC OrderUsecase, events OrderEventUsecase, gateways *payment.Registry, webhookSecrets map[string][]byte) PaymentUsecase {
+	return &paymentUsecase{repo: repo, walletRepo: walletRepo, orderUC: orderUC, events: events, gateways: gateways, webhookSecrets: webhookSecrets}
+}
+
+// Pay reserves a pending payment for the order, charges it through the
+// gateway of the chosen method and then records the outcome, so a charge
+// always has a payment row to be reconciled against. A successful payment
+// moves the order to processing; a declined one is stored and can be
+// retried.
+// Wallet credit requested by req.WalletAmount covers part of the total and
+// only the rest is charged; when it covers everything the gateway is not
+// called at all.
+func (uc *paymentUsecase) Pay(ctx context.Context, requester model.User, orderID int32, req dto.CreatePaymentRequest) (*model.Payment, *model.Order, error) {
+	o, err := uc.orderUC.GetByID(ctx, requester, orderID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if err := checkTransition(o.Status, "processing"); err != nil {
+		return nil, nil, ErrOrderNotPayable
+	}
+
+	existing, err := uc.repo.FindByOrderID(ctx, o.ID)
+	if err == nil && existing.Status != payment.StatusFailed {
+		return nil, nil, ErrOrderAlreadyPaid
+	}
+	if err != nil && !errors.Is(err, repository.ErrPaymentNotFound) {
+		return nil, nil, fmt.Errorf("load payment: %w", err)
+	}
+
+	gateway, ok := uc.gateways.Get(req.Method)
+	if !ok {
+		return nil, nil, ErrPaymentMethodUnavailable
+	}
+
+	walletAmount := min(roundMoney(req.WalletAmount), o.TotalPrice)
+	if walletAmount > 0 {
+		balance, err := uc.walletRepo.Balance(ctx, o.UserID)
+		if err != nil {
+			return nil, nil, fmt.Errorf("load wallet balance: %w", err)
+		}
+		if roundMoney(balance) < walletAmount {
+			return nil, nil, ErrInsufficientBalance
+		}
+	}
+	charge := roundMoney(o.TotalPrice - walletAmount)
+
+	reserved, err := uc.repo.Reserve(ctx, model.Payment{
+		OrderID:      o.ID,
+		Method:       req.Method,
+		Amount:       charge,
+		WalletAmount: walletAmount,
+	})
+	if err != nil {
+		switch {
+		case errors.Is(err, repository.ErrPaymentExists):
+			return nil, nil, ErrOrderAlreadyPaid
+		case errors.Is(err, repository.ErrOrderStatusChanged):
+			return nil, nil, ErrOrderNotPayable
+		case errors.Is(err, repository.ErrInsufficientBalance):
+			return nil, nil, ErrInsufficientBalance
+		}
+		return nil, nil, fmt.Errorf("reserve payment: %w", err)
+	}
+
+	res := payment.Result{
+		TransactionID: "wallet_" + uuid.NewString(),
+		Status:        payment.StatusSuccess,
+	}
+	if charge > 0 {
+		res, err = gateway.Charge(ctx, payment.Charge{
+			PaymentID: reserved.ID,
+			OrderID:   o.ID,
+			UserID:    o.UserID,
+			Amount:    charge,
+		})
+		if err != nil {
+			// nothing was charged: fail the reservation so the order can be
+			// paid again and the wallet credit is given back
+			if ferr := uc.repo.Complete(ctx, o.ID, "", payment.StatusFailed); ferr != nil {
+				return nil, nil, fmt.Errorf("%w: %v; release payment: %v", ErrPaymentGateway, err, ferr)
+			}
+			return nil, nil, fmt.Errorf("%w: %v", ErrPaymentGateway, err)
+		}
+	}
+
+	// The charge went through. Should this fail, the reservation stays
+	// pending and is found by its ID, which the provider got as reference.
+	err = uc.repo.Complete(ctx, o.ID, res.TransactionID, res.Status)
+	if err != nil {
+		return nil, nil, fmt.Errorf("record payment %d: %w", reserved.ID, err)
+	}
+	if res.Status == payment.StatusFailed {
+		return nil, nil, ErrPaymentDeclined
+	}
+	if res.Status == payment.StatusSuccess {
+		uc.events.Publish(ctx, o.ID)
+	}
+
+	p, err := uc.repo.FindByOrderID(ctx, o.ID)
+	if err != nil {
+		return nil, nil, err
+	}
+	o, err = uc.orderUC.GetByID(ctx, requester, o.ID)
+	if err != nil {
+		return nil, nil, err
+	}
+	return p, o, nil
+}
+
+// HandleWebhook verifies the provider signature over the raw body before
+// decoding it, then settles the pending payment named by the event.
+func (uc *paymentUsecase) HandleWebhook(ctx context.Context, provider string, body []byte, signature string) (bool, error) {
+	method, ok := payment.MethodForProvider(provider)
+	if !ok {
+		return false, ErrUnknownPaymentProvider
+	}
+	secret, ok := uc.webhookSecrets[method]
+	if !ok {
+		return false, ErrUnknownPaymentProvider
+	}
+	if !payment.VerifySignature(secret, body, signature) {
+		return false, ErrInvalidWebhookSignature
+	}
+
+	var event payment.WebhookEvent
+	if err := json.Unmarshal(body, &event); err != nil || event.TransactionID == "" {
+		return false, ErrInvalidWebhookEvent
+	}
+	switch event.Status {
+	case payment.StatusSuccess, payment.StatusFailed:
+	case payment.StatusPending:
+		// nothing settled yet
+		return false, nil
+	default:
+		return false, ErrInvalidWebhookEvent
+	}
+
+	orderID, applied, err := uc.repo.ApplyStatus(ctx, method, event.TransactionID, event.Status)
+	if err != nil {
+		if errors.Is(err, repository.ErrPaymentNotFound) {
+			return false, ErrPaymentNotFound
+		}
+		return false, fmt.Errorf("apply payment status: %w", err)
+	}
+	if applied && event.Status == payment.StatusSuccess {
+		uc.events.Publish(ctx, orderID)
+	}
+	return applied, nil
+}
+
+// Refund refunds an order's payment on behalf of an admin as wallet
+// credit. Partial refunds are stored and leave the order as is; the refund
+// that covers the rest of the payment marks it refunded and cancels the
+// order, so it is refused once the order can no longer be cancelled.
+func (uc *paymentUsecase) Refund(ctx context.Context, actor model.User, orderID int32, req dto.RefundRequest) (*model.Refund, *model.Order, error) {
+	before, err := uc.orderUC.GetByID(ctx, actor, orderID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var amount float64
+	if req.Amount != nil {
+		amount = roundMoney(*req.Amount)
+	}
+	refund, err := uc.repo.Refund(ctx, orderID, amount, req.Reason, actor.ID)
+	if err != nil {
+		switch {
+		case errors.Is(err, repository.ErrPaymentNotFound):
+			return nil, nil, ErrPaymentNotFound
+		case errors.Is(err, repository.ErrPaymentNotRefundable):
+			return nil, nil, ErrPaymentNotRefundable
+		case errors.Is(err, repository.ErrRefundExceedsPayment):
+			return nil, nil, ErrRefundExceedsPayment
+		case errors.Is(err, repository.ErrOrderNotCancellable):
+			return nil, nil, ErrOrderNotCancellable
+		case errors.Is(err, repository.ErrOrderNotFound):
+			return nil, nil, ErrOrderNotFound
+		}
+		return nil, nil, fmt.Errorf("refund payment: %w", err)
+	}
+
+	o, err := uc.orderUC.GetByID(ctx, actor, orderID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if o.Status != before.Status {
+		uc.events.Publish(ctx, orderID)
+	}
+	return &refund, o, nil
+}
+
+func (uc *paymentUsecase) ListRefunds(ctx context.Context, requester model.User, orderID int32) ([]model.Refund, error) {
+	if _, err := uc.orderUC.GetByID(ctx, requester, orderID); err != nil {
+		return nil, err
+	}
+	return uc.repo.ListRefunds(ctx, orderID)
+}
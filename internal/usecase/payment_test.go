@@ -0,0 +1,229 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+
+	"github.com/AndikaPrasetia/wash-shoe/internal/dto"
+	"github.com/AndikaPrasetia/wash-shoe/internal/model"
+	"github.com/AndikaPrasetia/wash-shoe/internal/payment"
+	"github.com/AndikaPrasetia/wash-shoe/internal/repository"
+)
+
+// webhookPaymentRepo answers ApplyStatus with a fixed result and records
+// the calls. The other PaymentRepo methods are not used by webhooks.
+type webhookPaymentRepo struct {
+	repository.PaymentRepo
+	orderID int32
+	applied bool
+	err     error
+	calls   int
+}
+
+func (r *webhookPaymentRepo) ApplyStatus(ctx context.Context, method, transactionID, status string) (int32, bool, error) {
+	r.calls++
+	return r.orderID, r.applied, r.err
+}
+
+type publishedEvents struct {
+	OrderEventUsecase
+	orderIDs []int32
+}
+
+func (e *publishedEvents) Publish(ctx context.Context, orderID int32) {
+	e.orderIDs = append(e.orderIDs, orderID)
+}
+
+func TestHandleWebhook(t *testing.T) {
+	secret := []byte("dana-secret")
+	success := []byte(`{"transaction_id":"trx-1","status":"success"}`)
+
+	tests := []struct {
+		name        string
+		secrets     map[string][]byte
+		provider    string
+		body        []byte
+		signature   string
+		repo        webhookPaymentRepo
+		want        bool
+		wantErr     error
+		wantCalls   int
+		wantPublish int
+	}{
+		{
+			name:        "applied",
+			provider:    "dana",
+			body:        success,
+			signature:   payment.Sign(secret, success),
+			repo:        webhookPaymentRepo{orderID: 7, applied: true},
+			want:        true,
+			wantCalls:   1,
+			wantPublish: 1,
+		},
+		{
+			name:      "redelivered",
+			provider:  "dana",
+			body:      success,
+			signature: payment.Sign(secret, success),
+			repo:      webhookPaymentRepo{orderID: 7},
+			wantCalls: 1,
+		},
+		{
+			name:      "bad signature",
+			provider:  "dana",
+			body:      success,
+			signature: payment.Sign([]byte("guessed"), success),
+			wantErr:   ErrInvalidWebhookSignature,
+		},
+		{
+			name:      "empty secret",
+			secrets:   map[string][]byte{payment.MethodDANA: {}},
+			provider:  "dana",
+			body:      success,
+			signature: payment.Sign(nil, success),
+			wantErr:   ErrInvalidWebhookSignature,
+		},
+		{
+			name:      "provider without secret",
+			provider:  "ovo",
+			body:      success,
+			signature: payment.Sign(secret, success),
+			wantErr:   ErrUnknownPaymentProvider,
+		},
+		{
+			name:      "transaction of another provider",
+			provider:  "dana",
+			body:      success,
+			signature: payment.Sign(secret, success),
+			repo:      webhookPaymentRepo{err: repository.ErrPaymentNotFound},
+			wantErr:   ErrPaymentNotFound,
+			wantCalls: 1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			secrets := tt.secrets
+			if secrets == nil {
+				secrets = map[string][]byte{payment.MethodDANA: secret}
+			}
+			repo := tt.repo
+			events := &publishedEvents{}
+			uc := NewPaymentUsecase(&repo, nil, nil, events, nil, secrets)
+
+			got, err := uc.HandleWebhook(context.Background(), tt.provider, tt.body, tt.signature)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("HandleWebhook() error = %v, want %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("HandleWebhook() = %v, want %v", got, tt.want)
+			}
+			if repo.calls != tt.wantCalls {
+				t.Errorf("ApplyStatus called %d times, want %d", repo.calls, tt.wantCalls)
+			}
+			if len(events.orderIDs) != tt.wantPublish {
+				t.Errorf("published %v, want %d events", events.orderIDs, tt.wantPublish)
+			}
+		})
+	}
+}
+
+// payFlowRepo records the calls Pay makes, in order.
+type payFlowRepo struct {
+	repository.PaymentRepo
+	calls       []string
+	completeErr error
+}
+
+func (r *payFlowRepo) Reserve(ctx context.Context, p model.Payment) (model.Payment, error) {
+	r.calls = append(r.calls, "reserve")
+	p.ID = 41
+	p.Status = payment.StatusPending
+	return p, nil
+}
+
+func (r *payFlowRepo) Complete(ctx context.Context, orderID int32, transactionID, status string) error {
+	r.calls = append(r.calls, "complete "+status)
+	return r.completeErr
+}
+
+// FindByOrderID finds the payment once it was completed.
+func (r *payFlowRepo) FindByOrderID(ctx context.Context, orderID int32) (*model.Payment, error) {
+	if len(r.calls) == 0 || r.completeErr != nil {
+		return nil, repository.ErrPaymentNotFound
+	}
+	return &model.Payment{ID: 41, OrderID: orderID}, nil
+}
+
+type pendingOrders struct {
+	OrderUsecase
+}
+
+func (pendingOrders) GetByID(ctx context.Context, requester model.User, id int32) (*model.Order, error) {
+	return &model.Order{ID: id, UserID: requester.ID, Status: "pending", TotalPrice: 50000}, nil
+}
+
+// recordingGateway charges through a FakeGateway and logs the charge into
+// the repo's call list, so the order of calls can be checked.
+type recordingGateway struct {
+	*payment.FakeGateway
+	repo    *payFlowRepo
+	charged payment.Charge
+}
+
+func (g *recordingGateway) Charge(ctx context.Context, c payment.Charge) (payment.Result, error) {
+	g.repo.calls = append(g.repo.calls, "charge")
+	g.charged = c
+	return g.FakeGateway.Charge(ctx, c)
+}
+
+func TestPayReservesBeforeCharging(t *testing.T) {
+	tests := []struct {
+		name        string
+		gatewayErr  error
+		completeErr error
+		wantErr     error
+		wantCalls   []string
+	}{
+		{
+			name:      "charged",
+			wantCalls: []string{"reserve", "charge", "complete success"},
+		},
+		{
+			name:       "gateway down",
+			gatewayErr: errors.New("connection refused"),
+			wantErr:    ErrPaymentGateway,
+			wantCalls:  []string{"reserve", "charge", "complete failed"},
+		},
+		{
+			name:        "recording fails after the charge",
+			completeErr: errors.New("connection reset"),
+			wantCalls:   []string{"reserve", "charge", "complete success"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &payFlowRepo{completeErr: tt.completeErr}
+			fake := payment.NewFakeGateway(payment.MethodDANA, payment.StatusSuccess)
+			fake.Err = tt.gatewayErr
+			gateway := &recordingGateway{FakeGateway: fake, repo: repo}
+			uc := NewPaymentUsecase(repo, nil, pendingOrders{}, &publishedEvents{}, payment.NewRegistry(gateway), nil)
+
+			_, _, err := uc.Pay(context.Background(), model.User{ID: "u1"}, 7, dto.CreatePaymentRequest{Method: payment.MethodDANA})
+			wantErr := tt.wantErr
+			if tt.completeErr != nil {
+				wantErr = tt.completeErr
+			}
+			if !errors.Is(err, wantErr) {
+				t.Fatalf("Pay() error = %v, want %v", err, wantErr)
+			}
+			if !slices.Equal(repo.calls, tt.wantCalls) {
+				t.Errorf("calls = %v, want %v", repo.calls, tt.wantCalls)
+			}
+			if gateway.charged.PaymentID != 41 {
+				t.Errorf("charge PaymentID = %d, want the reserved payment 41", gateway.charged.PaymentID)
+			}
+		})
+	}
+}
@@ -0,0 +1,150 @@
+package usecase
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"path"
+
+	"github.com/AndikaPrasetia/wash-shoe/internal/model"
+	"github.com/AndikaPrasetia/wash-shoe/internal/repository"
+	"github.com/AndikaPrasetia/wash-shoe/internal/storage"
+	utils "github.com/AndikaPrasetia/wash-shoe/internal/utils/services"
+	"github.com/google/uuid"
+)
+
+var (
+	ErrPhotoNotFound    = errors.New("photo not found")
+	ErrPhotoTooLarge    = errors.New("photo is too large")
+	ErrPhotoUnsupported = errors.New("photo must be a JPEG or PNG image")
+)
+
+// photoExtensions lists the accepted image types and the extension they
+// are stored with.
+var photoExtensions = map[string]string{
+	"image/jpeg": ".jpg",
+	"image/png":  ".png",
+}
+
+// PhotoUsecase defines business logic for before/after photo evidence
+type PhotoUsecase interface {
+	Upload(ctx context.Context, uploader model.User, orderID int32, photoType string, r io.Reader) (model.Photo, error)
+	List(ctx context.Context, requester model.User, orderID int32) ([]model.Photo, error)
+	// Open returns the image of a photo with its content type. The caller
+	// closes the reader.
+	Open(ctx context.Context, requester model.User, orderID, id int32) (io.ReadCloser, string, error)
+}
+
+type photoUsecase struct {
+	repo    repository.PhotoRepo
+	orderUC OrderUsecase
+	blobs   storage.BlobStore
+	maxSize int64
+}
+
+// NewPhotoUsecase creates a new PhotoUsecase. Uploads above maxSize bytes
+// are rejected.
+func NewPhotoUsecase(repo repository.PhotoRepo, orderUC OrderUsecase, blobs storage.BlobStore, maxSize int64) PhotoUsecase {
+	return &photoUsecase{repo: repo, orderUC: orderUC, blobs: blobs, maxSize: maxSize}
+}
+
+// Upload checks the image type from its content rather than the client's
+// header, re-encodes it to drop EXIF data and stores it in the blob store
+// before recording it in photo_evidences.
+func (uc *photoUsecase) Upload(ctx context.Context, uploader model.User, orderID int32, photoType string, r io.Reader) (model.Photo, error) {
+	if _, err := uc.orderUC.GetByID(ctx, uploader, orderID); err != nil {
+		return model.Photo{}, err
+	}
+
+	data, err := io.ReadAll(io.LimitReader(r, uc.maxSize+1))
+	if err != nil {
+		return model.Photo{}, fmt.Errorf("read photo: %w", err)
+	}
+	if int64(len(data)) > uc.maxSize {
+		return model.Photo{}, ErrPhotoTooLarge
+	}
+
+	contentType := http.DetectContentType(data)
+	ext, ok := photoExtensions[contentType]
+	if !ok {
+		return model.Photo{}, ErrPhotoUnsupported
+	}
+	clean, err := utils.StripImageMetadata(data, contentType)
+	if err != nil {
+		if errors.Is(err, utils.ErrImageTooLarge) {
+			return model.Photo{}, ErrPhotoTooLarge
+		}
+		// content sniffing passed but the image itself is broken
+		return model.Photo{}, ErrPhotoUnsupported
+	}
+
+	key := path.Join("orders", fmt.Sprint(orderID), uuid.NewString()+ext)
+	if err := uc.blobs.Put(ctx, key, bytes.NewReader(clean)); err != nil {
+		return model.Photo{}, fmt.Errorf("store photo: %w", err)
+	}
+
+	p, err := uc.repo.Create(ctx, model.Photo{
+		OrderID:    orderID,
+		Type:       photoType,
+		UploadedBy: uploader.ID,
+		Key:        key,
+	})
+	if err != nil {
+		_ = uc.blobs.Delete(ctx, key)
+		return model.Photo{}, fmt.Errorf("record photo: %w", err)
+	}
+	return withPhotoURL(p), nil
+}
+
+func (uc *photoUsecase) List(ctx context.Context, requester model.User, orderID int32) ([]model.Photo, error) {
+	if _, err := uc.orderUC.GetByID(ctx, requester, orderID); err != nil {
+		return nil, err
+	}
+	photos, err := uc.repo.ListByOrder(ctx, orderID)
+	if err != nil {
+		return nil, err
+	}
+	for i := range photos {
+		photos[i] = withPhotoURL(photos[i])
+	}
+	return photos, nil
+}
+
+func (uc *photoUsecase) Open(ctx context.Context, requester model.User, orderID, id int32) (io.ReadCloser, string, error) {
+	if _, err := uc.orderUC.GetByID(ctx, requester, orderID); err != nil {
+		return nil, "", err
+	}
+	p, err := uc.repo.FindByID(ctx, orderID, id)
+	if err != nil {
+		if errors.Is(err, repository.ErrPhotoNotFound) {
+			return nil, "", ErrPhotoNotFound
+		}
+		return nil, "", err
+	}
+
+	rc, err := uc.blobs.Get(ctx, p.Key)
+	if err != nil {
+		if errors.Is(err, storage.ErrBlobNotFound) {
+			return nil, "", ErrPhotoNotFound
+		}
+		return nil, "", err
+	}
+
+	contentType := "application/octet-stream"
+	for ct, ext := range photoExtensions {
+		if path.Ext(p.Key) == ext {
+			contentType = ct
+		}
+	}
+	return rc, contentType, nil
+}
+
+// withPhotoURL points the photo at the endpoint that serves it, so the
+// storage key never leaves the server.
+func withPhotoURL(p model.Photo) model.Photo {
+	p.URL = fmt.Sprintf("/api/v1/orders/%d/photos/%d", p.OrderID, p.ID)
+	return p
+}
@@ -0,0 +1,131 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/AndikaPrasetia/wash-shoe/internal/dto"
+	"github.com/AndikaPrasetia/wash-shoe/internal/model"
+	"github.com/AndikaPrasetia/wash-shoe/internal/repository"
+)
+
+var (
+	ErrPromoNotFound        = errors.New("promo code not found")
+	ErrPromoCodeTaken       = errors.New("promo code already exists")
+	ErrPromoExpired         = errors.New("promo code has expired")
+	ErrPromoUsedUp          = errors.New("promo code has reached its usage limit")
+	ErrInvalidPromoDiscount = errors.New("percentage discount must not exceed 100")
+)
+
+// PromoUsecase defines business logic for promo codes
+type PromoUsecase interface {
+	// Discount validates code and returns the discount it gives on amount,
+	// which is the order subtotal plus express fee.
+	Discount(ctx context.Context, code string, amount float64) (float64, error)
+	List(ctx context.Context) ([]model.Promo, error)
+	GetByCode(ctx context.Context, code string) (*model.Promo, error)
+	Create(ctx context.Context, req dto.CreatePromoRequest) (model.Promo, error)
+	Update(ctx context.Context, code string, req dto.PromoRequest) (model.Promo, error)
+	Delete(ctx context.Context, code string) error
+}
+
+type promoUsecase struct {
+	repo repository.PromoRepo
+}
+
+// NewPromoUsecase creates a new PromoUsecase
+func NewPromoUsecase(repo repository.PromoRepo) PromoUsecase {
+	return &promoUsecase{repo: repo}
+}
+
+// Discount only checks the promo; redemption happens atomically inside
+// create_order_with_services, which re-checks expiry and usage.
+func (uc *promoUsecase) Discount(ctx context.Context, code string, amount float64) (float64, error) {
+	p, err := uc.repo.FindByCode(ctx, strings.TrimSpace(code))
+	if err != nil {
+		return 0, mapPromoError(err)
+	}
+	if p.ValidUntil != nil && !time.Now().Before(*p.ValidUntil) {
+		return 0, ErrPromoExpired
+	}
+	if p.MaxUsage != nil && p.UsedCount >= *p.MaxUsage {
+		return 0, ErrPromoUsedUp
+	}
+
+	var discount float64
+	switch p.DiscountType {
+	case "percentage":
+		discount = amount * p.DiscountValue / 100
+	case "fixed":
+		discount = p.DiscountValue
+	}
+	return roundMoney(math.Min(discount, amount)), nil
+}
+
+func (uc *promoUsecase) List(ctx context.Context) ([]model.Promo, error) {
+	return uc.repo.List(ctx)
+}
+
+func (uc *promoUsecase) GetByCode(ctx context.Context, code string) (*model.Promo, error) {
+	p, err := uc.repo.FindByCode(ctx, code)
+	if err != nil {
+		return nil, mapPromoError(err)
+	}
+	return p, nil
+}
+
+func (uc *promoUsecase) Create(ctx context.Context, req dto.CreatePromoRequest) (model.Promo, error) {
+	p, err := promoFromRequest(req.PromoRequest)
+	if err != nil {
+		return model.Promo{}, err
+	}
+	p.Code = strings.TrimSpace(req.Code)
+	created, err := uc.repo.Create(ctx, p)
+	if err != nil {
+		return model.Promo{}, mapPromoError(err)
+	}
+	return created, nil
+}
+
+func (uc *promoUsecase) Update(ctx context.Context, code string, req dto.PromoRequest) (model.Promo, error) {
+	p, err := promoFromRequest(req)
+	if err != nil {
+		return model.Promo{}, err
+	}
+	p.Code = code
+	updated, err := uc.repo.Update(ctx, p)
+	if err != nil {
+		return model.Promo{}, mapPromoError(err)
+	}
+	return updated, nil
+}
+
+// Delete removes a promo. Orders keep the code they were placed with.
+func (uc *promoUsecase) Delete(ctx context.Context, code string) error {
+	return mapPromoError(uc.repo.Delete(ctx, code))
+}
+
+func promoFromRequest(req dto.PromoRequest) (model.Promo, error) {
+	if req.DiscountType == "percentage" && req.DiscountValue > 100 {
+		return model.Promo{}, ErrInvalidPromoDiscount
+	}
+	return model.Promo{
+		DiscountType:  req.DiscountType,
+		DiscountValue: roundMoney(req.DiscountValue),
+		MaxUsage:      req.MaxUsage,
+		ValidUntil:    req.ValidUntil,
+	}, nil
+}
+
+func mapPromoError(err error) error {
+	switch {
+	case errors.Is(err, repository.ErrPromoNotFound):
+		return ErrPromoNotFound
+	case errors.Is(err, repository.ErrPromoCodeTaken):
+		return ErrPromoCodeTaken
+	}
+	return err
+}
@@ -0,0 +1,143 @@
+package usecase
+
+import (
+	"context"
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/AndikaPrasetia/wash-shoe/internal/config"
+	"github.com/AndikaPrasetia/wash-shoe/internal/model"
+	"github.com/AndikaPrasetia/wash-shoe/internal/repository"
+)
+
+var ErrInvalidReferralCode = errors.New("invalid referral code")
+
+const (
+	referralCodeLength = 8
+	// referralCodeAlphabet leaves out characters that are easy to mix up
+	// when a code is typed in, like 0/O and 1/I.
+	referralCodeAlphabet = "ABCDEFGHJKLMNPQRSTUVWXYZ23456789"
+	referralCodeAttempts = 5
+)
+
+// ReferralUsecase defines business logic for the referral program
+type ReferralUsecase interface {
+	// Summary returns the user's invite code, creating it on first use,
+	// and the referrals made with it.
+	Summary(ctx context.Context, userID string) (*model.ReferralSummary, error)
+	// Referrer returns the ID of the user owning an invite code.
+	Referrer(ctx context.Context, code string) (string, error)
+	// Link records that the user signed up with the referrer's code.
+	Link(ctx context.Context, referrerID, userID, email string) error
+}
+
+type referralUsecase struct {
+	repo repository.ReferralRepo
+	cfg  config.ReferralConfig
+}
+
+// NewReferralUsecase creates a new ReferralUsecase
+func NewReferralUsecase(repo repository.ReferralRepo, cfg config.ReferralConfig) ReferralUsecase {
+	return &referralUsecase{repo: repo, cfg: cfg}
+}
+
+func (uc *referralUsecase) Summary(ctx context.Context, userID string) (*model.ReferralSummary, error) {
+	code, err := uc.code(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	referrals, err := uc.repo.ListByReferrer(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	summary := model.ReferralSummary{
+		Code:      code,
+		Total:     len(referrals),
+		Referrals: referrals,
+	}
+	for _, rf := range referrals {
+		if rf.IsCompleted {
+			summary.Completed++
+			summary.EarnedCashback += rf.CashbackAmount
+		} else {
+			summary.PendingCashback += rf.CashbackAmount
+		}
+	}
+	summary.EarnedCashback = roundMoney(summary.EarnedCashback)
+	summary.PendingCashback = roundMoney(summary.PendingCashback)
+	return &summary, nil
+}
+
+// code returns the user's invite code, generating a new one when they
+// don't have any yet.
+func (uc *referralUsecase) code(ctx context.Context, userID string) (string, error) {
+	code, err := uc.repo.FindCode(ctx, userID)
+	if err == nil {
+		return code, nil
+	}
+	if !errors.Is(err, repository.ErrReferralCodeNotFound) {
+		return "", err
+	}
+
+	for range referralCodeAttempts {
+		candidate, err := newReferralCode()
+		if err != nil {
+			return "", err
+		}
+		code, err = uc.repo.CreateCode(ctx, userID, candidate)
+		if errors.Is(err, repository.ErrReferralCodeTaken) {
+			continue
+		}
+		if err != nil {
+			return "", fmt.Errorf("create referral code: %w", err)
+		}
+		return code, nil
+	}
+	return "", errors.New("create referral code: no free code found")
+}
+
+func (uc *referralUsecase) Referrer(ctx context.Context, code string) (string, error) {
+	referrerID, err := uc.repo.FindReferrer(ctx, normalizeReferralCode(code))
+	if err != nil {
+		if errors.Is(err, repository.ErrReferralCodeNotFound) {
+			return "", ErrInvalidReferralCode
+		}
+		return "", err
+	}
+	return referrerID, nil
+}
+
+func (uc *referralUsecase) Link(ctx context.Context, referrerID, userID, email string) error {
+	err := uc.repo.Create(ctx, model.Referral{
+		ReferrerID:     referrerID,
+		ReferredEmail:  email,
+		ReferredID:     userID,
+		CashbackAmount: roundMoney(uc.cfg.Cashback),
+	})
+	if err != nil {
+		return fmt.Errorf("create referral: %w", err)
+	}
+	return nil
+}
+
+// newReferralCode returns a random code of referralCodeLength characters
+// from referralCodeAlphabet.
+func newReferralCode() (string, error) {
+	b := make([]byte, referralCodeLength)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	// 256 is a multiple of len(referralCodeAlphabet), so every character is
+	// equally likely
+	for i := range b {
+		b[i] = referralCodeAlphabet[int(b[i])%len(referralCodeAlphabet)]
+	}
+	return string(b), nil
+}
+
+func normalizeReferralCode(code string) string {
+	return strings.ToUpper(strings.TrimSpace(code))
+}
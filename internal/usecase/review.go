@@ -0,0 +1,135 @@
+package usecase
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/AndikaPrasetia/wash-shoe/internal/dto"
+	"github.com/AndikaPrasetia/wash-shoe/internal/model"
+	"github.com/AndikaPrasetia/wash-shoe/internal/redis"
+	"github.com/AndikaPrasetia/wash-shoe/internal/repository"
+)
+
+var (
+	ErrOrderNotReviewable   = errors.New("only completed or delivered orders can be reviewed")
+	ErrOrderAlreadyReviewed = errors.New("order has already been reviewed")
+)
+
+const (
+	ratingCacheTTL      = 10 * time.Minute
+	recentCommentsLimit = 5
+)
+
+func ratingCacheKey(serviceTypeID int32) string {
+	return fmt.Sprintf("ratings:service_type:%d", serviceTypeID)
+}
+
+// ReviewUsecase defines business logic for order reviews and ratings
+type ReviewUsecase interface {
+	Create(ctx context.Context, requester model.User, orderID int32, req dto.CreateReviewRequest) (model.Review, error)
+	ServiceTypeRating(ctx context.Context, serviceTypeID int32) (*model.ServiceTypeRating, error)
+}
+
+type reviewUsecase struct {
+	repo        repository.ReviewRepo
+	serviceRepo repository.ServiceTypeRepo
+	orderUC     OrderUsecase
+	redisCli    *redis.RedisClient
+}
+
+// NewReviewUsecase creates a new ReviewUsecase
+func NewReviewUsecase(repo repository.ReviewRepo, serviceRepo repository.ServiceTypeRepo, orderUC OrderUsecase, redisCli *redis.RedisClient) ReviewUsecase {
+	return &reviewUsecase{repo: repo, serviceRepo: serviceRepo, orderUC: orderUC, redisCli: redisCli}
+}
+
+// Create stores the review of the order's owner once the order is done and
+// drops the cached ratings of every service type in it.
+func (uc *reviewUsecase) Create(ctx context.Context, requester model.User, orderID int32, req dto.CreateReviewRequest) (model.Review, error) {
+	o, err := uc.orderUC.GetByID(ctx, requester, orderID)
+	if err != nil {
+		return model.Review{}, err
+	}
+	// admins can see the order, but only its owner can review it
+	if o.UserID != requester.ID {
+		return model.Review{}, ErrOrderForbidden
+	}
+	if o.Status != "completed" && o.Status != "delivered" {
+		return model.Review{}, ErrOrderNotReviewable
+	}
+
+	rv, err := uc.repo.Create(ctx, model.Review{
+		UserID:  requester.ID,
+		OrderID: o.ID,
+		Rating:  req.Rating,
+		Comment: req.Comment,
+	})
+	if err != nil {
+		if errors.Is(err, repository.ErrOrderAlreadyReviewed) {
+			return model.Review{}, ErrOrderAlreadyReviewed
+		}
+		return model.Review{}, fmt.Errorf("create review: %w", err)
+	}
+
+	keys := make([]string, len(o.Services))
+	for i, s := range o.Services {
+		keys[i] = ratingCacheKey(s.ServiceTypeID)
+	}
+	if len(keys) > 0 {
+		// stale ratings expire with ratingCacheTTL anyway
+		_ = uc.redisCli.GetClient().Del(ctx, keys...).Err()
+	}
+	return rv, nil
+}
+
+// ServiceTypeRating returns the rating summary of a service type, served
+// from Redis when cached. Cache failures fall back to the database.
+func (uc *reviewUsecase) ServiceTypeRating(ctx context.Context, serviceTypeID int32) (*model.ServiceTypeRating, error) {
+	key := ratingCacheKey(serviceTypeID)
+	if cached, err := uc.redisCli.GetClient().Get(ctx, key).Bytes(); err == nil {
+		var rating model.ServiceTypeRating
+		if json.Unmarshal(cached, &rating) == nil {
+			return &rating, nil
+		}
+	}
+
+	if _, err := uc.serviceRepo.FindByID(ctx, serviceTypeID); err != nil {
+		if errors.Is(err, repository.ErrServiceTypeNotFound) {
+			return nil, ErrServiceTypeNotFound
+		}
+		return nil, err
+	}
+
+	counts, err := uc.repo.RatingCounts(ctx, serviceTypeID)
+	if err != nil {
+		return nil, err
+	}
+	comments, err := uc.repo.RecentComments(ctx, serviceTypeID, recentCommentsLimit)
+	if err != nil {
+		return nil, err
+	}
+
+	rating := model.ServiceTypeRating{
+		ServiceTypeID:  serviceTypeID,
+		Distribution:   make(map[int32]int32, 5),
+		RecentComments: comments,
+	}
+	var sum int32
+	for stars := int32(1); stars <= 5; stars++ {
+		n := counts[stars]
+		rating.Distribution[stars] = n
+		rating.Count += n
+		sum += stars * n
+	}
+	if rating.Count > 0 {
+		rating.Average = math.Round(float64(sum)/float64(rating.Count)*100) / 100
+	}
+
+	if data, err := json.Marshal(rating); err == nil {
+		_ = uc.redisCli.GetClient().Set(ctx, key, data, ratingCacheTTL).Err()
+	}
+	return &rating, nil
+}
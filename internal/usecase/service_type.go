@@ -0,0 +1,85 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+
+	"github.com/AndikaPrasetia/wash-shoe/internal/dto"
+	"github.com/AndikaPrasetia/wash-shoe/internal/model"
+	"github.com/AndikaPrasetia/wash-shoe/internal/repository"
+)
+
+var ErrServiceTypeNameTaken = errors.New("service type name already exists")
+
+// ServiceTypeUsecase defines business logic for the service catalog
+type ServiceTypeUsecase interface {
+	List(ctx context.Context, query dto.ListServiceTypesQuery) ([]model.ServiceType, error)
+	GetByID(ctx context.Context, id int32) (*model.ServiceType, error)
+	Create(ctx context.Context, req dto.ServiceTypeRequest) (model.ServiceType, error)
+	Update(ctx context.Context, id int32, req dto.ServiceTypeRequest) (model.ServiceType, error)
+	Archive(ctx context.Context, id int32) error
+}
+
+type serviceTypeUsecase struct {
+	repo repository.ServiceTypeRepo
+}
+
+// NewServiceTypeUsecase creates a new ServiceTypeUsecase
+func NewServiceTypeUsecase(repo repository.ServiceTypeRepo) ServiceTypeUsecase {
+	return &serviceTypeUsecase{repo: repo}
+}
+
+func (uc *serviceTypeUsecase) List(ctx context.Context, query dto.ListServiceTypesQuery) ([]model.ServiceType, error) {
+	return uc.repo.List(ctx, query.EcoFriendly, query.Sort)
+}
+
+func (uc *serviceTypeUsecase) GetByID(ctx context.Context, id int32) (*model.ServiceType, error) {
+	st, err := uc.repo.FindByID(ctx, id)
+	if err != nil {
+		return nil, mapServiceTypeError(err)
+	}
+	return st, nil
+}
+
+func (uc *serviceTypeUsecase) Create(ctx context.Context, req dto.ServiceTypeRequest) (model.ServiceType, error) {
+	st, err := uc.repo.Create(ctx, serviceTypeFromRequest(req))
+	if err != nil {
+		return model.ServiceType{}, mapServiceTypeError(err)
+	}
+	return st, nil
+}
+
+func (uc *serviceTypeUsecase) Update(ctx context.Context, id int32, req dto.ServiceTypeRequest) (model.ServiceType, error) {
+	st := serviceTypeFromRequest(req)
+	st.ID = id
+	updated, err := uc.repo.Update(ctx, st)
+	if err != nil {
+		return model.ServiceType{}, mapServiceTypeError(err)
+	}
+	return updated, nil
+}
+
+// Archive hides a service type from the catalog and from new orders.
+func (uc *serviceTypeUsecase) Archive(ctx context.Context, id int32) error {
+	return mapServiceTypeError(uc.repo.Archive(ctx, id))
+}
+
+func serviceTypeFromRequest(req dto.ServiceTypeRequest) model.ServiceType {
+	return model.ServiceType{
+		Name:                   req.Name,
+		Description:            req.Description,
+		BasePrice:              roundMoney(req.BasePrice),
+		EstimatedDurationHours: req.EstimatedDurationHours,
+		IsEcoFriendly:          req.IsEcoFriendly,
+	}
+}
+
+func mapServiceTypeError(err error) error {
+	switch {
+	case errors.Is(err, repository.ErrServiceTypeNotFound):
+		return ErrServiceTypeNotFound
+	case errors.Is(err, repository.ErrServiceTypeNameTaken):
+		return ErrServiceTypeNameTaken
+	}
+	return err
+}
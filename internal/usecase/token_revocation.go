@@ -0,0 +1,118 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/AndikaPrasetia/wash-shoe/internal/config"
+	"github.com/AndikaPrasetia/wash-shoe/internal/redis"
+	modelUtils "github.com/AndikaPrasetia/wash-shoe/internal/utils/model-utils"
+)
+
+// revokedTokenKey marks a single token, by jti, as revoked until it expires.
+func revokedTokenKey(jti string) string {
+	return "tokens:revoked:" + jti
+}
+
+// revokedBeforeKey holds the unix time in milliseconds up to which every
+// token issued to the user is revoked. It lives as long as the longest token lifetime, as
+// older tokens have expired by then anyway.
+func revokedBeforeKey(userID string) string {
+	return "tokens:revoked_before:" + userID
+}
+
+// revokedSessionKey marks every token of a session (refresh token family)
+// as revoked. Like revokedBeforeKey it outlives all tokens of the session.
+func revokedSessionKey(sessionID string) string {
+	return "tokens:revoked_session:" + sessionID
+}
+
+// TokenRevocationUsecase keeps revoked tokens from being used before they
+// expire.
+type TokenRevocationUsecase interface {
+	// Revoke revokes a single token by its jti.
+	Revoke(ctx context.Context, token modelUtils.JwtPayloadClaim) error
+	// RevokeUser revokes every token issued to the user so far. Tokens
+	// issued afterwards, even within the same second, stay valid.
+	RevokeUser(ctx context.Context, userID string) error
+	// RevokeSessions revokes every token issued to the given sessions.
+	RevokeSessions(ctx context.Context, sessionIDs ...string) error
+	// CheckRevoked returns ErrTokenRevoked for a revoked token, and an error
+	// when revocations can't be looked up.
+	CheckRevoked(ctx context.Context, token modelUtils.JwtPayloadClaim) error
+}
+
+type tokenRevocationUsecase struct {
+	redisCli *redis.RedisClient
+	cfg      config.TokenConfig
+}
+
+// NewTokenRevocationUsecase creates a new TokenRevocationUsecase
+func NewTokenRevocationUsecase(redisCli *redis.RedisClient, cfg config.TokenConfig) TokenRevocationUsecase {
+	return &tokenRevocationUsecase{redisCli: redisCli, cfg: cfg}
+}
+
+func (uc *tokenRevocationUsecase) Revoke(ctx context.Context, token modelUtils.JwtPayloadClaim) error {
+	if token.ID == "" || token.ExpiresAt == nil {
+		return ErrInvalidToken
+	}
+	ttl := time.Until(token.ExpiresAt.Time)
+	if ttl <= 0 {
+		return nil
+	}
+	return uc.redisCli.GetClient().Set(ctx, revokedTokenKey(token.ID), "1", ttl).Err()
+}
+
+func (uc *tokenRevocationUsecase) RevokeUser(ctx context.Context, userID string) error {
+	return uc.redisCli.GetClient().Set(ctx, revokedBeforeKey(userID), time.Now().UnixMilli(), uc.maxLifetime()).Err()
+}
+
+func (uc *tokenRevocationUsecase) RevokeSessions(ctx context.Context, sessionIDs ...string) error {
+	if len(sessionIDs) == 0 {
+		return nil
+	}
+	pipe := uc.redisCli.GetClient().Pipeline()
+	for _, id := range sessionIDs {
+		pipe.Set(ctx, revokedSessionKey(id), "1", uc.maxLifetime())
+	}
+	_, err := pipe.Exec(ctx)
+	return err
+}
+
+// maxLifetime is how long any token issued now can stay valid.
+func (uc *tokenRevocationUsecase) maxLifetime() time.Duration {
+	return max(uc.cfg.AccessTokenLifeTime, uc.cfg.RefreshTokenLifeTime)
+}
+
+// CheckRevoked looks all keys up in a single round trip. Tokens without a
+// jti or iat predate revocation support and are refused.
+func (uc *tokenRevocationUsecase) CheckRevoked(ctx context.Context, token modelUtils.JwtPayloadClaim) error {
+	if token.ID == "" || token.IssuedAt == nil {
+		return ErrTokenRevoked
+	}
+	keys := []string{revokedBeforeKey(token.Subject), revokedTokenKey(token.ID)}
+	if token.SessionID != "" {
+		keys = append(keys, revokedSessionKey(token.SessionID))
+	}
+	vals, err := uc.redisCli.GetClient().MGet(ctx, keys...).Result()
+	if err != nil {
+		return fmt.Errorf("check token revocation: %w", err)
+	}
+	for _, v := range vals[1:] {
+		if v != nil {
+			return ErrTokenRevoked
+		}
+	}
+	if before, ok := vals[0].(string); ok {
+		cutoff, err := strconv.ParseInt(before, 10, 64)
+		if err != nil {
+			return fmt.Errorf("check token revocation: %w", err)
+		}
+		if token.IssuedAtMilli() <= cutoff {
+			return ErrTokenRevoked
+		}
+	}
+	return nil
+}
@@ -0,0 +1,190 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/AndikaPrasetia/wash-shoe/internal/config"
+	"github.com/AndikaPrasetia/wash-shoe/internal/redis"
+	modelUtils "github.com/AndikaPrasetia/wash-shoe/internal/utils/model-utils"
+	"github.com/alicebob/miniredis/v2"
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// testRedis starts an in-memory Redis for the test.
+func testRedis(t *testing.T) (*redis.RedisClient, *miniredis.Miniredis) {
+	t.Helper()
+	mr := miniredis.RunT(t)
+	cli, err := redis.NewRedisClient(config.RedisConfig{Addr: mr.Addr()})
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { cli.Close() })
+	return cli, mr
+}
+
+// claimsAt returns an access token's claims issued at the given unix
+// millisecond.
+func claimsAt(jti, userID, sessionID string, issuedAtMs int64) modelUtils.JwtPayloadClaim {
+	issued := time.UnixMilli(issuedAtMs)
+	return modelUtils.JwtPayloadClaim{
+		RegisteredClaims: jwt.RegisteredClaims{
+			ID:        jti,
+			Subject:   userID,
+			IssuedAt:  jwt.NewNumericDate(issued),
+			ExpiresAt: jwt.NewNumericDate(issued.Add(15 * time.Minute)),
+		},
+		UserID:     userID,
+		SessionID:  sessionID,
+		IssuedAtMs: issuedAtMs,
+	}
+}
+
+func TestTokenRevocation(t *testing.T) {
+	ctx := context.Background()
+	cfg := config.TokenConfig{AccessTokenLifeTime: 15 * time.Minute, RefreshTokenLifeTime: 24 * time.Hour}
+	now := time.Now().UnixMilli()
+
+	tests := []struct {
+		name    string
+		revoke  func(uc TokenRevocationUsecase) error
+		token   modelUtils.JwtPayloadClaim
+		wantErr error
+	}{
+		{
+			name:  "nothing revoked",
+			token: claimsAt("jti-1", "u1", "s1", now),
+		},
+		{
+			name:    "revoked jti",
+			revoke:  func(uc TokenRevocationUsecase) error { return uc.Revoke(ctx, claimsAt("jti-1", "u1", "s1", now)) },
+			token:   claimsAt("jti-1", "u1", "s1", now),
+			wantErr: ErrTokenRevoked,
+		},
+		{
+			name:   "other jti of the same session",
+			revoke: func(uc TokenRevocationUsecase) error { return uc.Revoke(ctx, claimsAt("jti-1", "u1", "s1", now)) },
+			token:  claimsAt("jti-2", "u1", "s1", now),
+		},
+		{
+			name:    "revoked session",
+			revoke:  func(uc TokenRevocationUsecase) error { return uc.RevokeSessions(ctx, "s0", "s1") },
+			token:   claimsAt("jti-1", "u1", "s1", now),
+			wantErr: ErrTokenRevoked,
+		},
+		{
+			name:   "other session",
+			revoke: func(uc TokenRevocationUsecase) error { return uc.RevokeSessions(ctx, "s0", "s1") },
+			token:  claimsAt("jti-1", "u1", "s2", now),
+		},
+		{
+			name:   "token without session",
+			revoke: func(uc TokenRevocationUsecase) error { return uc.RevokeSessions(ctx, "s1") },
+			token:  claimsAt("jti-1", "u1", "", now),
+		},
+		{
+			name:    "user revoked after the token was issued",
+			revoke:  func(uc TokenRevocationUsecase) error { return uc.RevokeUser(ctx, "u1") },
+			token:   claimsAt("jti-1", "u1", "s1", now-1),
+			wantErr: ErrTokenRevoked,
+		},
+		{
+			name:   "token issued after the user was revoked",
+			revoke: func(uc TokenRevocationUsecase) error { return uc.RevokeUser(ctx, "u1") },
+			token:  claimsAt("jti-1", "u1", "s1", time.Now().Add(time.Second).UnixMilli()),
+		},
+		{
+			name:   "other user revoked",
+			revoke: func(uc TokenRevocationUsecase) error { return uc.RevokeUser(ctx, "u2") },
+			token:  claimsAt("jti-1", "u1", "s1", now-1),
+		},
+		{
+			name:    "token without jti",
+			token:   claimsAt("", "u1", "s1", now),
+			wantErr: ErrTokenRevoked,
+		},
+		{
+			name:    "token without iat",
+			token:   modelUtils.JwtPayloadClaim{RegisteredClaims: jwt.RegisteredClaims{ID: "jti-1", Subject: "u1"}},
+			wantErr: ErrTokenRevoked,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cli, _ := testRedis(t)
+			uc := NewTokenRevocationUsecase(cli, cfg)
+			if tt.revoke != nil {
+				if err := tt.revoke(uc); err != nil {
+					t.Fatal(err)
+				}
+			}
+			if err := uc.CheckRevoked(ctx, tt.token); !errors.Is(err, tt.wantErr) {
+				t.Fatalf("CheckRevoked() = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestTokenRevocationKeysExpire(t *testing.T) {
+	ctx := context.Background()
+	cli, mr := testRedis(t)
+	uc := NewTokenRevocationUsecase(cli, config.TokenConfig{AccessTokenLifeTime: 15 * time.Minute, RefreshTokenLifeTime: 24 * time.Hour})
+
+	// a jti is kept until the token expires
+	token := claimsAt("jti-1", "u1", "s1", time.Now().UnixMilli())
+	if err := uc.Revoke(ctx, token); err != nil {
+		t.Fatal(err)
+	}
+	if ttl := mr.TTL(revokedTokenKey("jti-1")); ttl <= 14*time.Minute || ttl > 15*time.Minute {
+		t.Errorf("revoked jti TTL = %v, want the 15 minutes the token has left", ttl)
+	}
+
+	// user and session revocations outlive the longest token
+	if err := uc.RevokeUser(ctx, "u1"); err != nil {
+		t.Fatal(err)
+	}
+	if err := uc.RevokeSessions(ctx, "s1"); err != nil {
+		t.Fatal(err)
+	}
+	for _, key := range []string{revokedBeforeKey("u1"), revokedSessionKey("s1")} {
+		if ttl := mr.TTL(key); ttl != 24*time.Hour {
+			t.Errorf("%s TTL = %v, want 24h", key, ttl)
+		}
+	}
+	cutoff, err := strconv.ParseInt(mustGet(t, mr, revokedBeforeKey("u1")), 10, 64)
+	if err != nil || time.Since(time.UnixMilli(cutoff)) > time.Minute {
+		t.Errorf("revoked_before = %d, %v, want now in unix milliseconds", cutoff, err)
+	}
+
+	// an expired token needs no entry
+	expired := claimsAt("jti-2", "u1", "s1", time.Now().Add(-time.Hour).UnixMilli())
+	if err := uc.Revoke(ctx, expired); err != nil {
+		t.Fatal(err)
+	}
+	if mr.Exists(revokedTokenKey("jti-2")) {
+		t.Error("an expired token was stored as revoked")
+	}
+}
+
+func TestCheckRevokedWithoutRedis(t *testing.T) {
+	cli, mr := testRedis(t)
+	uc := NewTokenRevocationUsecase(cli, config.TokenConfig{})
+	mr.Close()
+
+	err := uc.CheckRevoked(context.Background(), claimsAt("jti-1", "u1", "s1", time.Now().UnixMilli()))
+	if err == nil || errors.Is(err, ErrTokenRevoked) {
+		t.Fatalf("CheckRevoked() = %v, want a lookup error", err)
+	}
+}
+
+func mustGet(t *testing.T, mr *miniredis.Miniredis, key string) string {
+	t.Helper()
+	v, err := mr.Get(key)
+	if err != nil {
+		t.Fatalf("get %s: %v", key, err)
+	}
+	return v
+}
@@ -2,29 +2,44 @@ package usecase
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"strings"
 
 	"github.com/AndikaPrasetia/wash-shoe/internal/sqlc/user"
+	"github.com/AndikaPrasetia/wash-shoe/internal/dto"
 	"github.com/AndikaPrasetia/wash-shoe/internal/model"
+	"github.com/AndikaPrasetia/wash-shoe/internal/redis"
 	"github.com/AndikaPrasetia/wash-shoe/internal/repository"
+	utils "github.com/AndikaPrasetia/wash-shoe/internal/utils/services"
 	"github.com/jackc/pgx/v5/pgtype"
 )
 
+var (
+	ErrEmptyFullName      = errors.New("full name must not be empty")
+	ErrInvalidPhoneNumber = utils.ErrInvalidPhoneNumber
+)
+
 // UserUsecase defines business logic for public user profiles
 type UserUsecase interface {
 	Create(ctx context.Context, params user.CreatePublicUserParams) (model.User, error)
 	GetByID(ctx context.Context, id pgtype.UUID) (*model.User, error)
 	GetByEmail(ctx context.Context, email string) (*model.User, error)
 	Update(ctx context.Context, params user.UpdatePublicUserParams) (model.User, error)
+	// UpdateProfile applies a self-service profile change. Phone numbers
+	// are stored in E.164.
+	UpdateProfile(ctx context.Context, id pgtype.UUID, req dto.UpdateProfileRequest) (model.User, error)
 	Delete(ctx context.Context, id pgtype.UUID) error
 }
 
 type userUsecase struct {
-	repo repository.UserRepo
+	repo     repository.UserRepo
+	redisCli *redis.RedisClient
 }
 
 // NewUserUsecase creates a new UserUsecase
-func NewUserUsecase(repo repository.UserRepo) UserUsecase {
-	return &userUsecase{repo: repo}
+func NewUserUsecase(repo repository.UserRepo, redisCli *redis.RedisClient) UserUsecase {
+	return &userUsecase{repo: repo, redisCli: redisCli}
 }
 
 func (uc *userUsecase) Create(ctx context.Context, params user.CreatePublicUserParams) (model.User, error) {
@@ -32,7 +47,11 @@ func (uc *userUsecase) Create(ctx context.Context, params user.CreatePublicUserP
 }
 
 func (uc *userUsecase) GetByID(ctx context.Context, id pgtype.UUID) (*model.User, error) {
-	return uc.repo.FindByID(ctx, id)
+	u, err := uc.repo.FindByID(ctx, id)
+	if errors.Is(err, repository.ErrUserNotFound) {
+		return nil, ErrUserNotFound
+	}
+	return u, err
 }
 
 func (uc *userUsecase) GetByEmail(ctx context.Context, email string) (*model.User, error) {
@@ -43,6 +62,54 @@ func (uc *userUsecase) Update(ctx context.Context, params user.UpdatePublicUserP
 	return uc.repo.Update(ctx, params)
 }
 
+func (uc *userUsecase) UpdateProfile(ctx context.Context, id pgtype.UUID, req dto.UpdateProfileRequest) (model.User, error) {
+	current, err := uc.GetByID(ctx, id)
+	if err != nil {
+		return model.User{}, err
+	}
+
+	params := user.UpdatePublicUserParams{
+		ID:          id,
+		FullName:    current.FullName,
+		PhoneNumber: pgtype.Text{String: current.PhoneNumber, Valid: current.PhoneNumber != ""},
+	}
+	if req.FullName != nil {
+		params.FullName = strings.TrimSpace(*req.FullName)
+		if params.FullName == "" {
+			return model.User{}, ErrEmptyFullName
+		}
+	}
+	if req.PhoneNumber != nil {
+		params.PhoneNumber = pgtype.Text{}
+		if strings.TrimSpace(*req.PhoneNumber) != "" {
+			phone, err := utils.NormalizeIndonesianPhone(*req.PhoneNumber)
+			if err != nil {
+				return model.User{}, err
+			}
+			params.PhoneNumber = pgtype.Text{String: phone, Valid: true}
+		}
+	}
+
+	updated, err := uc.Update(ctx, params)
+	if err != nil {
+		if errors.Is(err, repository.ErrUserNotFound) {
+			return model.User{}, ErrUserNotFound
+		}
+		return model.User{}, err
+	}
+	// UpdatePublicUser doesn't return the login email
+	updated.Email = current.Email
+	return updated, nil
+}
+
+// Delete removes the account and its cached status, so CheckActive stops
+// letting its tokens through right away.
 func (uc *userUsecase) Delete(ctx context.Context, id pgtype.UUID) error {
-	return uc.repo.Delete(ctx, id)
+	if err := uc.repo.Delete(ctx, id); err != nil {
+		return err
+	}
+	if err := uc.redisCli.GetClient().Del(ctx, accountStatusKey(id.String())).Err(); err != nil {
+		return fmt.Errorf("clear account status: %w", err)
+	}
+	return nil
 }
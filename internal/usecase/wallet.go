@@ -0,0 +1,75 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/AndikaPrasetia/wash-shoe/internal/dto"
+	"github.com/AndikaPrasetia/wash-shoe/internal/model"
+	"github.com/AndikaPrasetia/wash-shoe/internal/repository"
+)
+
+var (
+	ErrInsufficientBalance = errors.New("insufficient wallet balance")
+	ErrInvalidWalletAmount = errors.New("amount must not be zero")
+)
+
+const defaultStatementLimit = 20
+
+// WalletUsecase defines business logic for customer wallets. Credit comes
+// from referral cashback, refunds and admin adjustments and can be spent
+// when paying orders.
+type WalletUsecase interface {
+	Balance(ctx context.Context, userID string) (*model.Wallet, error)
+	Statement(ctx context.Context, userID string, query dto.WalletStatementQuery) ([]model.WalletEntry, error)
+	Adjust(ctx context.Context, actor model.User, userID string, req dto.WalletAdjustmentRequest) (model.WalletEntry, error)
+}
+
+type walletUsecase struct {
+	repo repository.WalletRepo
+}
+
+// NewWalletUsecase creates a new WalletUsecase
+func NewWalletUsecase(repo repository.WalletRepo) WalletUsecase {
+	return &walletUsecase{repo: repo}
+}
+
+func (uc *walletUsecase) Balance(ctx context.Context, userID string) (*model.Wallet, error) {
+	balance, err := uc.repo.Balance(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	return &model.Wallet{UserID: userID, Balance: balance}, nil
+}
+
+// Statement returns the wallet entries newest first, 20 per page unless
+// query sets a limit.
+func (uc *walletUsecase) Statement(ctx context.Context, userID string, query dto.WalletStatementQuery) ([]model.WalletEntry, error) {
+	limit := query.Limit
+	if limit == 0 {
+		limit = defaultStatementLimit
+	}
+	return uc.repo.Statement(ctx, userID, limit, query.Offset)
+}
+
+// Adjust corrects a wallet on behalf of an admin. A debit can't take the
+// balance below zero.
+func (uc *walletUsecase) Adjust(ctx context.Context, actor model.User, userID string, req dto.WalletAdjustmentRequest) (model.WalletEntry, error) {
+	amount := roundMoney(req.Amount)
+	if amount == 0 {
+		return model.WalletEntry{}, ErrInvalidWalletAmount
+	}
+
+	entry, err := uc.repo.Adjust(ctx, userID, amount, req.Reason, actor.ID)
+	if err != nil {
+		switch {
+		case errors.Is(err, repository.ErrUserNotFound):
+			return model.WalletEntry{}, ErrUserNotFound
+		case errors.Is(err, repository.ErrInsufficientBalance):
+			return model.WalletEntry{}, ErrInsufficientBalance
+		}
+		return model.WalletEntry{}, fmt.Errorf("adjust wallet: %w", err)
+	}
+	return entry, nil
+}
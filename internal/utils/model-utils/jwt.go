@@ -5,8 +5,24 @@ import "github.com/golang-jwt/jwt/v5"
 
 type JwtPayloadClaim struct {
 	jwt.RegisteredClaims
-	UserID string `json:"user_id"`
-	Role   string `json:"role"` // user, admin
-	Type   string `json:"type"` // access, refresh
-	Email  string `json:"email"`
+	UserID    string `json:"user_id"`
+	Role      string `json:"role"` // user, admin
+	Type      string `json:"type"` // access, refresh
+	Email     string `json:"email"`
+	SessionID string `json:"sid,omitempty"`
+	// IssuedAtMs is iat in milliseconds, so a revocation can tell apart
+	// tokens issued in the same second before and after it.
+	IssuedAtMs int64 `json:"iat_ms,omitempty"`
+}
+
+// IssuedAtMilli returns the issue time in unix milliseconds, falling back to
+// the start of the iat second for tokens without iat_ms.
+func (c JwtPayloadClaim) IssuedAtMilli() int64 {
+	if c.IssuedAtMs != 0 {
+		return c.IssuedAtMs
+	}
+	if c.IssuedAt == nil {
+		return 0
+	}
+	return c.IssuedAt.UnixMilli()
 }
@@ -0,0 +1,151 @@
+package utils
+
+import (
+	"bytes"
+	"encoding/binary"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"testing"
+)
+
+// exifSegment builds an APP1 segment holding only an orientation tag.
+func exifSegment(order binary.ByteOrder, orientation uint16) []byte {
+	tiff := make([]byte, 26)
+	if order == binary.LittleEndian {
+		copy(tiff, "II")
+	} else {
+		copy(tiff, "MM")
+	}
+	order.PutUint16(tiff[2:], 42)
+	order.PutUint32(tiff[4:], 8)
+	order.PutUint16(tiff[8:], 1)
+	order.PutUint16(tiff[10:], 0x0112)
+	order.PutUint16(tiff[12:], 3)
+	order.PutUint32(tiff[14:], 1)
+	order.PutUint16(tiff[18:], orientation)
+
+	payload := append([]byte("Exif\x00\x00"), tiff...)
+	segment := []byte{0xFF, 0xE1, 0, 0}
+	binary.BigEndian.PutUint16(segment[2:], uint16(len(payload)+2))
+	return append(segment, payload...)
+}
+
+// withSegment inserts segment right after the start of image marker.
+func withSegment(jpg, segment []byte) []byte {
+	out := append([]byte{}, jpg[:2]...)
+	out = append(out, segment...)
+	return append(out, jpg[2:]...)
+}
+
+// halvesJPEG encodes a 16x8 JPEG, red on the left and blue on the right.
+func halvesJPEG(t *testing.T) []byte {
+	t.Helper()
+	img := image.NewRGBA(image.Rect(0, 0, 16, 8))
+	for y := 0; y < 8; y++ {
+		for x := 0; x < 16; x++ {
+			c := color.RGBA{R: 255, A: 255}
+			if x >= 8 {
+				c = color.RGBA{B: 255, A: 255}
+			}
+			img.Set(x, y, c)
+		}
+	}
+	var buf bytes.Buffer
+	if err := jpeg.Encode(&buf, img, nil); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+func TestJPEGOrientation(t *testing.T) {
+	jpg := halvesJPEG(t)
+	tests := []struct {
+		name string
+		data []byte
+		want int
+	}{
+		{"no exif", jpg, 1},
+		{"little endian", withSegment(jpg, exifSegment(binary.LittleEndian, 6)), 6},
+		{"big endian", withSegment(jpg, exifSegment(binary.BigEndian, 8)), 8},
+		{"out of range", withSegment(jpg, exifSegment(binary.LittleEndian, 9)), 1},
+		{"truncated", withSegment(jpg, exifSegment(binary.LittleEndian, 6))[:20], 1},
+		{"not a jpeg", []byte("\x89PNG\r\n\x1a\n"), 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := jpegOrientation(tt.data); got != tt.want {
+				t.Errorf("jpegOrientation() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestApplyOrientation(t *testing.T) {
+	// abc
+	// def
+	src := image.NewGray(image.Rect(0, 0, 3, 2))
+	copy(src.Pix, "abcdef")
+
+	tests := []struct {
+		orientation int
+		want        []string
+	}{
+		{1, []string{"abc", "def"}},
+		{2, []string{"cba", "fed"}},
+		{3, []string{"fed", "cba"}},
+		{4, []string{"def", "abc"}},
+		{5, []string{"ad", "be", "cf"}},
+		{6, []string{"da", "eb", "fc"}},
+		{7, []string{"fc", "eb", "da"}},
+		{8, []string{"cf", "be", "ad"}},
+	}
+	for _, tt := range tests {
+		img := applyOrientation(src, tt.orientation)
+		b := img.Bounds()
+		var got []string
+		for y := b.Min.Y; y < b.Max.Y; y++ {
+			var row []byte
+			for x := b.Min.X; x < b.Max.X; x++ {
+				row = append(row, color.GrayModel.Convert(img.At(x, y)).(color.Gray).Y)
+			}
+			got = append(got, string(row))
+		}
+		if len(got) != len(tt.want) {
+			t.Errorf("orientation %d: got %q, want %q", tt.orientation, got, tt.want)
+			continue
+		}
+		for i := range got {
+			if got[i] != tt.want[i] {
+				t.Errorf("orientation %d: got %q, want %q", tt.orientation, got, tt.want)
+				break
+			}
+		}
+	}
+}
+
+func TestStripImageMetadataAppliesOrientation(t *testing.T) {
+	data := withSegment(halvesJPEG(t), exifSegment(binary.BigEndian, 6))
+
+	clean, err := StripImageMetadata(data, "image/jpeg")
+	if err != nil {
+		t.Fatalf("StripImageMetadata() error = %v", err)
+	}
+	if bytes.Contains(clean, []byte("Exif")) {
+		t.Error("EXIF data was kept")
+	}
+	img, err := jpeg.Decode(bytes.NewReader(clean))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := img.Bounds().Size(); got != image.Pt(8, 16) {
+		t.Fatalf("size = %v, want 8x16", got)
+	}
+	// turned clockwise, the red left half ends up on top
+	if r, _, b, _ := img.At(4, 3).RGBA(); r < b {
+		t.Errorf("top is not red")
+	}
+	if r, _, b, _ := img.At(4, 12).RGBA(); b < r {
+		t.Errorf("bottom is not blue")
+	}
+}
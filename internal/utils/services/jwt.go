@@ -1,20 +1,44 @@
 package utils
 
 import (
+	"crypto/ed25519"
+	"crypto/rsa"
+	"encoding/base64"
 	"errors"
 	"fmt"
+	"math/big"
+	"slices"
 	"time"
 
 	"github.com/AndikaPrasetia/wash-shoe/internal/config"
 	"github.com/AndikaPrasetia/wash-shoe/internal/model"
 	modelUtils "github.com/AndikaPrasetia/wash-shoe/internal/utils/model-utils"
 	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
 )
 
+// Token types, carried in the "type" claim.
+const (
+	TokenTypeAccess  = "access"
+	TokenTypeRefresh = "refresh"
+)
+
+// JwtService issues and verifies every token of the API. Tokens carry the
+// user's ID, role and email and the ID of the session (refresh token
+// family) they belong to in the sid claim, are issued by TokenConfig.AppName for
+// TokenConfig.Audience and have a unique jti. With TokenConfig.SigningKey
+// they are signed with that key and name it in the kid header, otherwise
+// with the shared JwtSecretKey.
 type JwtService interface {
-	CreateAccessToken(user model.User) (string, error)
-	CreateRefreshToken(user model.User) (string, error)
+	CreateAccessToken(user model.User, sessionID string) (string, error)
+	CreateRefreshToken(user model.User, sessionID string) (string, error)
+	// CreateTokenPair returns a new access token and refresh token for user.
+	CreateTokenPair(user model.User, sessionID string) (string, string, error)
+	// VerifyToken checks signature, issuer, audience and expiry. Callers
+	// check the token type they expect.
 	VerifyToken(tokenString string) (modelUtils.JwtPayloadClaim, error)
+	// JWKS returns the public keys tokens are currently verified with.
+	JWKS() model.JSONWebKeySet
 }
 
 type jwtService struct {
@@ -25,76 +49,78 @@ func NewJwtService(cfg config.TokenConfig) JwtService {
 	return &jwtService{cfg: cfg}
 }
 
-func (j *jwtService) CreateAccessToken(user model.User) (string, error) {
-	tokenKey := j.cfg.JwtSecretKey
+func (j *jwtService) CreateAccessToken(user model.User, sessionID string) (string, error) {
+	return j.createToken(user, sessionID, TokenTypeAccess, j.cfg.AccessTokenLifeTime)
+}
 
-	claims := modelUtils.JwtPayloadClaim{
-		RegisteredClaims: jwt.RegisteredClaims{
-			Issuer:    j.cfg.AppName,
-			Subject:   user.ID,
-			ExpiresAt: jwt.NewNumericDate(time.Now().Add(j.cfg.AccessTokenLifeTime)),
-		},
-		UserID: user.ID,
-		Role:   user.Role,
-		Type:   "access",
-	}
+func (j *jwtService) CreateRefreshToken(user model.User, sessionID string) (string, error) {
+	return j.createToken(user, sessionID, TokenTypeRefresh, j.cfg.RefreshTokenLifeTime)
+}
 
-	jwtNewClaim := jwt.NewWithClaims(j.cfg.JwtSigningMethod, claims)
-	token, err := jwtNewClaim.SignedString(tokenKey)
+func (j *jwtService) CreateTokenPair(user model.User, sessionID string) (string, string, error) {
+	accessToken, err := j.CreateAccessToken(user, sessionID)
 	if err != nil {
-		return "", err
+		return "", "", fmt.Errorf("sign access token: %w", err)
 	}
-
-	return token, nil
+	refreshToken, err := j.CreateRefreshToken(user, sessionID)
+	if err != nil {
+		return "", "", fmt.Errorf("sign refresh token: %w", err)
+	}
+	return accessToken, refreshToken, nil
 }
 
-func (j *jwtService) CreateRefreshToken(user model.User) (string, error) {
-	tokenKey := j.cfg.JwtSecretKey
-
+func (j *jwtService) createToken(user model.User, sessionID, tokenType string, lifetime time.Duration) (string, error) {
+	now := time.Now()
 	claims := modelUtils.JwtPayloadClaim{
 		RegisteredClaims: jwt.RegisteredClaims{
+			ID:        uuid.NewString(),
 			Issuer:    j.cfg.AppName,
 			Subject:   user.ID,
-			ExpiresAt: jwt.NewNumericDate(time.Now().Add(j.cfg.RefreshTokenLifeTime)),
+			Audience:  jwt.ClaimStrings{j.cfg.Audience},
+			IssuedAt:  jwt.NewNumericDate(now),
+			ExpiresAt: jwt.NewNumericDate(now.Add(lifetime)),
 		},
-		UserID: user.ID,
-		Role:   user.Role,
-		Type:   "refresh",
+		UserID:     user.ID,
+		Role:       user.Role,
+		Type:       tokenType,
+		Email:      user.Email,
+		SessionID:  sessionID,
+		IssuedAtMs: now.UnixMilli(),
 	}
 
-	jwtNewClaim := jwt.NewWithClaims(j.cfg.JwtSigningMethod, claims)
-	token, err := jwtNewClaim.SignedString(tokenKey)
-	if err != nil {
-		return "", err
+	if key := j.cfg.SigningKey; key != nil {
+		jwtNewClaim := jwt.NewWithClaims(key.Method, claims)
+		jwtNewClaim.Header["kid"] = key.ID
+		return jwtNewClaim.SignedString(key.Private)
 	}
-
-	return token, nil
+	jwtNewClaim := jwt.NewWithClaims(j.cfg.JwtSigningMethod, claims)
+	return jwtNewClaim.SignedString(j.cfg.JwtSecretKey)
 }
+
 func (j *jwtService) VerifyToken(tokenString string) (modelUtils.JwtPayloadClaim, error) {
-	// 1. Parse token dengan claims
+	// 1. Parse token dengan claims; hanya metode signing dari key kita,
+	//    issuer dan audience kita yang diterima
 	token, err := jwt.ParseWithClaims(
 		tokenString,
 		&modelUtils.JwtPayloadClaim{},
-		func(token *jwt.Token) (any, error) {
-			// 2. Validasi metode signing
-			if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
-				return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
-			}
-			return j.cfg.JwtSecretKey, nil
-		},
+		j.verificationKey,
+		jwt.WithValidMethods(j.validMethods()),
+		jwt.WithIssuer(j.cfg.AppName),
+		jwt.WithAudience(j.cfg.Audience),
+		jwt.WithExpirationRequired(),
 	)
 
-	// 3. Tangani error parsing
+	// 2. Tangani error parsing
 	if err != nil {
 		return modelUtils.JwtPayloadClaim{}, fmt.Errorf("token parsing failed: %w", err)
 	}
 
-	// 4. Validasi token
+	// 3. Validasi token
 	if !token.Valid {
 		return modelUtils.JwtPayloadClaim{}, errors.New("invalid token")
 	}
 
-	// 5. Ekstrak claims
+	// 4. Ekstrak claims
 	claims, ok := token.Claims.(*modelUtils.JwtPayloadClaim)
 	if !ok {
 		return modelUtils.JwtPayloadClaim{}, errors.New("invalid token claims")
@@ -102,3 +128,72 @@ func (j *jwtService) VerifyToken(tokenString string) (modelUtils.JwtPayloadClaim
 
 	return *claims, nil
 }
+
+// verificationKey picks the key a token was signed with. Asymmetric tokens
+// name it in their kid header; it must be the signing key or a previous key
+// still in its grace window.
+func (j *jwtService) verificationKey(token *jwt.Token) (any, error) {
+	if j.cfg.SigningKey == nil {
+		return j.cfg.JwtSecretKey, nil
+	}
+	kid, _ := token.Header["kid"].(string)
+	for _, key := range j.activeKeys() {
+		if key.ID != kid {
+			continue
+		}
+		if key.Method.Alg() != token.Method.Alg() {
+			return nil, fmt.Errorf("key %q does not sign with %s", kid, token.Method.Alg())
+		}
+		return key.Public, nil
+	}
+	return nil, fmt.Errorf("unknown signing key %q", kid)
+}
+
+// activeKeys returns the signing key and the previous keys until
+// PreviousKeysUntil has passed.
+func (j *jwtService) activeKeys() []config.SigningKey {
+	if j.cfg.SigningKey == nil {
+		return nil
+	}
+	keys := []config.SigningKey{*j.cfg.SigningKey}
+	if time.Now().Before(j.cfg.PreviousKeysUntil) {
+		keys = append(keys, j.cfg.PreviousKeys...)
+	}
+	return keys
+}
+
+func (j *jwtService) validMethods() []string {
+	if j.cfg.SigningKey == nil {
+		return []string{j.cfg.JwtSigningMethod.Alg()}
+	}
+	var methods []string
+	for _, key := range j.activeKeys() {
+		if !slices.Contains(methods, key.Method.Alg()) {
+			methods = append(methods, key.Method.Alg())
+		}
+	}
+	return methods
+}
+
+// JWKS never includes the shared secret, so it is empty without a
+// SigningKey.
+func (j *jwtService) JWKS() model.JSONWebKeySet {
+	set := model.JSONWebKeySet{Keys: []model.JSONWebKey{}}
+	for _, key := range j.activeKeys() {
+		jwk := model.JSONWebKey{Kid: key.ID, Use: "sig", Alg: key.Method.Alg()}
+		switch pub := key.Public.(type) {
+		case *rsa.PublicKey:
+			jwk.Kty = "RSA"
+			jwk.N = base64.RawURLEncoding.EncodeToString(pub.N.Bytes())
+			jwk.E = base64.RawURLEncoding.EncodeToString(big.NewInt(int64(pub.E)).Bytes())
+		case ed25519.PublicKey:
+			jwk.Kty = "OKP"
+			jwk.Crv = "Ed25519"
+			jwk.X = base64.RawURLEncoding.EncodeToString(pub)
+		default:
+			continue
+		}
+		set.Keys = append(set.Keys, jwk)
+	}
+	return set
+}
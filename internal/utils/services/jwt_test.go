@@ -0,0 +1,303 @@
+package utils
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"errors"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/AndikaPrasetia/wash-shoe/internal/config"
+	"github.com/AndikaPrasetia/wash-shoe/internal/model"
+	modelUtils "github.com/AndikaPrasetia/wash-shoe/internal/utils/model-utils"
+	"github.com/golang-jwt/jwt/v5"
+)
+
+var testUser = model.User{ID: "6f1c7a52-3f5e-4a8e-9d1b-0c2f4e6a8b10", Role: "user", Email: "budi@example.com"}
+
+func secretConfig() config.TokenConfig {
+	return config.TokenConfig{
+		AppName:              "wash-shoe",
+		Audience:             "wash-shoe-api",
+		JwtSecretKey:         []byte("0123456789abcdef0123456789abcdef"),
+		JwtSigningMethod:     jwt.SigningMethodHS256,
+		AccessTokenLifeTime:  15 * time.Minute,
+		RefreshTokenLifeTime: 24 * time.Hour,
+	}
+}
+
+// signClaims signs claims for testUser the way createToken does, but with
+// whatever method, key and issuer the test needs.
+func signClaims(t *testing.T, cfg config.TokenConfig, method jwt.SigningMethod, key any, mutate func(*modelUtils.JwtPayloadClaim)) string {
+	t.Helper()
+	return signWithKid(t, cfg, method, "", key, mutate)
+}
+
+// signWithKid is signClaims with a kid header, left out when kid is empty.
+func signWithKid(t *testing.T, cfg config.TokenConfig, method jwt.SigningMethod, kid string, key any, mutate func(*modelUtils.JwtPayloadClaim)) string {
+	t.Helper()
+	now := time.Now()
+	claims := modelUtils.JwtPayloadClaim{
+		RegisteredClaims: jwt.RegisteredClaims{
+			ID:        "jti-1",
+			Issuer:    cfg.AppName,
+			Subject:   testUser.ID,
+			Audience:  jwt.ClaimStrings{cfg.Audience},
+			IssuedAt:  jwt.NewNumericDate(now),
+			ExpiresAt: jwt.NewNumericDate(now.Add(time.Minute)),
+		},
+		UserID: testUser.ID,
+		Type:   TokenTypeAccess,
+	}
+	if mutate != nil {
+		mutate(&claims)
+	}
+	token := jwt.NewWithClaims(method, claims)
+	if kid != "" {
+		token.Header["kid"] = kid
+	}
+	signed, err := token.SignedString(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return signed
+}
+
+func TestCreateTokenPair(t *testing.T) {
+	svc := NewJwtService(secretConfig())
+	access, refresh, err := svc.CreateTokenPair(testUser, "session-1")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, tt := range []struct {
+		token, wantType string
+	}{
+		{access, TokenTypeAccess},
+		{refresh, TokenTypeRefresh},
+	} {
+		claims, err := svc.VerifyToken(tt.token)
+		if err != nil {
+			t.Fatalf("VerifyToken(%s) error = %v", tt.wantType, err)
+		}
+		if claims.Type != tt.wantType || claims.UserID != testUser.ID || claims.Subject != testUser.ID ||
+			claims.Role != testUser.Role || claims.Email != testUser.Email || claims.SessionID != "session-1" {
+			t.Errorf("VerifyToken(%s) = %+v", tt.wantType, claims)
+		}
+		if claims.ID == "" || claims.IssuedAtMs == 0 {
+			t.Errorf("VerifyToken(%s): jti %q, iat_ms %d, want both set", tt.wantType, claims.ID, claims.IssuedAtMs)
+		}
+	}
+
+	other, _, err := svc.CreateTokenPair(testUser, "session-1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	first, _ := svc.VerifyToken(access)
+	second, _ := svc.VerifyToken(other)
+	if first.ID == second.ID {
+		t.Errorf("two tokens share the jti %q", first.ID)
+	}
+}
+
+func TestVerifyTokenWithSecret(t *testing.T) {
+	cfg := secretConfig()
+	expired := cfg
+	expired.AccessTokenLifeTime = -time.Minute
+	expiredToken, err := NewJwtService(expired).CreateAccessToken(testUser, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name    string
+		token   string
+		wantErr error
+	}{
+		{"valid", signClaims(t, cfg, jwt.SigningMethodHS256, cfg.JwtSecretKey, nil), nil},
+		{"expired", expiredToken, jwt.ErrTokenExpired},
+		{"without expiry", signClaims(t, cfg, jwt.SigningMethodHS256, cfg.JwtSecretKey, func(c *modelUtils.JwtPayloadClaim) {
+			c.ExpiresAt = nil
+		}), jwt.ErrTokenRequiredClaimMissing},
+		{"wrong secret", signClaims(t, cfg, jwt.SigningMethodHS256, []byte("guessed-secret-guessed-secret-00"), nil), jwt.ErrTokenSignatureInvalid},
+		{"other HMAC alg", signClaims(t, cfg, jwt.SigningMethodHS512, cfg.JwtSecretKey, nil), jwt.ErrTokenSignatureInvalid},
+		{"alg none", signClaims(t, cfg, jwt.SigningMethodNone, jwt.UnsafeAllowNoneSignatureType, nil), jwt.ErrTokenSignatureInvalid},
+		{"other issuer", signClaims(t, cfg, jwt.SigningMethodHS256, cfg.JwtSecretKey, func(c *modelUtils.JwtPayloadClaim) {
+			c.Issuer = "someone-else"
+		}), jwt.ErrTokenInvalidIssuer},
+		{"other audience", signClaims(t, cfg, jwt.SigningMethodHS256, cfg.JwtSecretKey, func(c *modelUtils.JwtPayloadClaim) {
+			c.Audience = jwt.ClaimStrings{"another-api"}
+		}), jwt.ErrTokenInvalidAudience},
+		{"garbage", "not.a.token", jwt.ErrTokenMalformed},
+	}
+	svc := NewJwtService(cfg)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := svc.VerifyToken(tt.token)
+			if tt.wantErr == nil {
+				if err != nil {
+					t.Fatalf("VerifyToken() error = %v, want nil", err)
+				}
+				return
+			}
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("VerifyToken() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func ed25519Key(t *testing.T, kid string) config.SigningKey {
+	t.Helper()
+	pub, private, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return config.SigningKey{ID: kid, Method: jwt.SigningMethodEdDSA, Private: private, Public: pub}
+}
+
+func rsaKey(t *testing.T, kid string) config.SigningKey {
+	t.Helper()
+	private, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return config.SigningKey{ID: kid, Method: jwt.SigningMethodRS256, Private: private, Public: &private.PublicKey}
+}
+
+// publicOnly is key as loaded from a public key file for JWT_PREVIOUS_KEYS.
+func publicOnly(key config.SigningKey) config.SigningKey {
+	key.Private = nil
+	return key
+}
+
+func TestVerifyTokenWithSigningKeys(t *testing.T) {
+	old := ed25519Key(t, "2024-01")
+	current := rsaKey(t, "2025-01")
+	oldRSA := rsaKey(t, "2024-06")
+	stranger := rsaKey(t, "2025-01")
+
+	oldCfg := secretConfig()
+	oldCfg.SigningKey = &old
+	oldToken, err := NewJwtService(oldCfg).CreateAccessToken(testUser, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cfg := secretConfig()
+	cfg.SigningKey = &current
+	cfg.PreviousKeys = []config.SigningKey{publicOnly(old), publicOnly(oldRSA)}
+	cfg.PreviousKeysUntil = time.Now().Add(time.Hour)
+	oldRSAToken := signWithKid(t, cfg, jwt.SigningMethodRS256, oldRSA.ID, oldRSA.Private, nil)
+	currentToken, err := NewJwtService(cfg).CreateAccessToken(testUser, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	expired := cfg
+	expired.AccessTokenLifeTime = -time.Minute
+	expiredToken, err := NewJwtService(expired).CreateAccessToken(testUser, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name      string
+		token     string
+		graceOver bool
+		wantErr   error
+	}{
+		{name: "current key", token: currentToken},
+		{name: "previous key in its grace window", token: oldToken},
+		// EdDSA went out with the old key
+		{name: "previous key after its grace window", token: oldToken, graceOver: true, wantErr: jwt.ErrTokenSignatureInvalid},
+		{name: "previous key of the same alg in its grace window", token: oldRSAToken},
+		{name: "previous key of the same alg after its grace window", token: oldRSAToken, graceOver: true, wantErr: jwt.ErrTokenUnverifiable},
+		{name: "current key after the grace window", token: currentToken, graceOver: true},
+		{name: "expired", token: expiredToken, wantErr: jwt.ErrTokenExpired},
+		{name: "unknown kid", token: signWithKid(t, cfg, jwt.SigningMethodRS256, "2023-01", current.Private, nil), wantErr: jwt.ErrTokenUnverifiable},
+		{name: "no kid", token: signWithKid(t, cfg, jwt.SigningMethodRS256, "", current.Private, nil), wantErr: jwt.ErrTokenUnverifiable},
+		{name: "kid of another key", token: signWithKid(t, cfg, jwt.SigningMethodRS256, current.ID, stranger.Private, nil), wantErr: jwt.ErrTokenSignatureInvalid},
+		// both algs are accepted, but each kid only with its own
+		{name: "alg of another kid", token: signWithKid(t, cfg, jwt.SigningMethodRS256, old.ID, current.Private, nil), wantErr: jwt.ErrTokenUnverifiable},
+		// the shared secret no longer signs once there is a signing key
+		{name: "shared secret", token: signWithKid(t, cfg, jwt.SigningMethodHS256, current.ID, cfg.JwtSecretKey, nil), wantErr: jwt.ErrTokenSignatureInvalid},
+		{name: "alg none", token: signWithKid(t, cfg, jwt.SigningMethodNone, current.ID, jwt.UnsafeAllowNoneSignatureType, nil), wantErr: jwt.ErrTokenSignatureInvalid},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := cfg
+			if tt.graceOver {
+				c.PreviousKeysUntil = time.Now().Add(-time.Second)
+			}
+			claims, err := NewJwtService(c).VerifyToken(tt.token)
+			if tt.wantErr == nil {
+				if err != nil {
+					t.Fatalf("VerifyToken() error = %v, want nil", err)
+				}
+				if claims.UserID != testUser.ID {
+					t.Errorf("VerifyToken() user = %q, want %q", claims.UserID, testUser.ID)
+				}
+				return
+			}
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("VerifyToken() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestCreateTokenNamesSigningKey(t *testing.T) {
+	key := ed25519Key(t, "2025-01")
+	cfg := secretConfig()
+	cfg.SigningKey = &key
+	signed, err := NewJwtService(cfg).CreateAccessToken(testUser, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	token, _, err := jwt.NewParser().ParseUnverified(signed, &modelUtils.JwtPayloadClaim{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if token.Header["kid"] != key.ID || token.Method.Alg() != "EdDSA" {
+		t.Errorf("header = %v, want kid %q and alg EdDSA", token.Header, key.ID)
+	}
+}
+
+func TestJWKS(t *testing.T) {
+	old := ed25519Key(t, "2024-01")
+	current := rsaKey(t, "2025-01")
+
+	kids := func(set model.JSONWebKeySet) []string {
+		var ids []string
+		for _, k := range set.Keys {
+			ids = append(ids, k.Kid+"/"+k.Kty+"/"+k.Alg)
+		}
+		return ids
+	}
+
+	cfg := secretConfig()
+	if got := NewJwtService(cfg).JWKS(); len(got.Keys) != 0 {
+		t.Errorf("JWKS() with the shared secret = %v, want no keys", kids(got))
+	}
+
+	cfg.SigningKey = &current
+	cfg.PreviousKeys = []config.SigningKey{publicOnly(old)}
+	cfg.PreviousKeysUntil = time.Now().Add(time.Hour)
+	got := NewJwtService(cfg).JWKS()
+	if want := []string{"2025-01/RSA/RS256", "2024-01/OKP/EdDSA"}; !slices.Equal(kids(got), want) {
+		t.Errorf("JWKS() = %v, want %v", kids(got), want)
+	}
+	if k := got.Keys[0]; k.N == "" || k.E != "AQAB" || k.Use != "sig" {
+		t.Errorf("RSA key = %+v, want n, e=AQAB and use=sig", k)
+	}
+	if k := got.Keys[1]; k.Crv != "Ed25519" || k.X == "" {
+		t.Errorf("Ed25519 key = %+v, want crv Ed25519 and x", k)
+	}
+
+	cfg.PreviousKeysUntil = time.Now().Add(-time.Second)
+	if got, want := kids(NewJwtService(cfg).JWKS()), []string{"2025-01/RSA/RS256"}; !slices.Equal(got, want) {
+		t.Errorf("JWKS() after the grace window = %v, want %v", got, want)
+	}
+}
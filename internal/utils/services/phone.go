@@ -0,0 +1,48 @@
+package utils
+
+import (
+	"errors"
+	"strings"
+)
+
+var ErrInvalidPhoneNumber = errors.New("phone number must be a valid Indonesian number")
+
+// NormalizeIndonesianPhone converts an Indonesian phone number as people
+// type it (0812-3456-7890, 62 812 3456 7890, +62 (812) 34567890, ...) into
+// E.164, e.g. +6281234567890. Numbers of other countries are rejected.
+func NormalizeIndonesianPhone(phone string) (string, error) {
+	phone = strings.TrimSpace(phone)
+	var digits strings.Builder
+	for i, ch := range phone {
+		switch {
+		case ch >= '0' && ch <= '9':
+			digits.WriteRune(ch)
+		case ch == '+' && i == 0:
+		case ch == ' ', ch == '-', ch == '.', ch == '(', ch == ')':
+		default:
+			return "", ErrInvalidPhoneNumber
+		}
+	}
+
+	// national significant number, without the trunk prefix 0 or the
+	// country code 62
+	national := digits.String()
+	switch {
+	case strings.HasPrefix(phone, "+") || strings.HasPrefix(national, "62"):
+		if !strings.HasPrefix(national, "62") {
+			return "", ErrInvalidPhoneNumber
+		}
+		// people often keep the trunk prefix after the country code,
+		// as in +62 0812-3456-7890
+		national = strings.TrimPrefix(national[2:], "0")
+	case strings.HasPrefix(national, "0"):
+		national = national[1:]
+	}
+
+	// 8 to 12 digits, never starting with 0 (E.164 allows up to 15 in
+	// total with the country code)
+	if len(national) < 8 || len(national) > 12 || national[0] == '0' {
+		return "", ErrInvalidPhoneNumber
+	}
+	return "+62" + national, nil
+}
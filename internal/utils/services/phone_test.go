@@ -0,0 +1,49 @@
+package utils
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestNormalizeIndonesianPhone(t *testing.T) {
+	tests := []struct {
+		phone   string
+		want    string
+		wantErr error
+	}{
+		{"081234567890", "+6281234567890", nil},
+		{"0812-3456-7890", "+6281234567890", nil},
+		{"62 812 3456 7890", "+6281234567890", nil},
+		{"+62 (812) 34567890", "+6281234567890", nil},
+		{"+6281234567890", "+6281234567890", nil},
+		{"  0812.3456.7890  ", "+6281234567890", nil},
+		// the trunk prefix kept after the country code
+		{"+62 0812-3456-7890", "+6281234567890", nil},
+		{"+6208123456789", "+628123456789", nil},
+		{"62 0812 3456 7890", "+6281234567890", nil},
+		// landline, shortest and longest national numbers
+		{"021-555-0123", "+62215550123", nil},
+		{"0812345678", "+62812345678", nil},
+		{"0812345678901", "+62812345678901", nil},
+
+		{"", "", ErrInvalidPhoneNumber},
+		{"+62 00812 3456 7890", "", ErrInvalidPhoneNumber},
+		{"+1 415 555 0123", "", ErrInvalidPhoneNumber},
+		{"0812 3456 789a", "", ErrInvalidPhoneNumber},
+		{"0812+34567890", "", ErrInvalidPhoneNumber},
+		{"0812345", "", ErrInvalidPhoneNumber},
+		{"08123456789012", "", ErrInvalidPhoneNumber},
+		{"+62", "", ErrInvalidPhoneNumber},
+	}
+	for _, tt := range tests {
+		t.Run(tt.phone, func(t *testing.T) {
+			got, err := NormalizeIndonesianPhone(tt.phone)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("NormalizeIndonesianPhone(%q) error = %v, want %v", tt.phone, err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("NormalizeIndonesianPhone(%q) = %q, want %q", tt.phone, got, tt.want)
+			}
+		})
+	}
+}
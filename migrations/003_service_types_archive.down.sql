@@ -0,0 +1,6 @@
+-- 003_service_types_archive.down.sql
+
+DROP INDEX IF EXISTS idx_service_types_active;
+
+ALTER TABLE public.service_types
+  DROP COLUMN IF EXISTS archived_at;
@@ -0,0 +1,9 @@
+-- 003_service_types_archive.up.sql
+
+-- Archived service types are hidden from the catalog and can't be ordered,
+-- but stay referenced by existing order_services rows.
+ALTER TABLE public.service_types
+  ADD COLUMN IF NOT EXISTS archived_at timestamp without time zone;
+
+CREATE INDEX IF NOT EXISTS idx_service_types_active ON public.service_types (base_price)
+  WHERE archived_at IS NULL;
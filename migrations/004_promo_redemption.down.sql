@@ -0,0 +1,57 @@
+-- 004_promo_redemption.down.sql
+
+CREATE OR REPLACE FUNCTION create_order_with_services(
+  p_user_id UUID,
+  p_address_id INT,
+  p_total_price NUMERIC(10,2),
+  p_status order_status,
+  p_is_express BOOLEAN,
+  p_express_fee NUMERIC(10,2),
+  p_promo_code TEXT,
+  p_services JSONB -- Format: [{"service_type_id": 1, "quantity": 2, "price": 50000}, ...]
+) RETURNS INT AS $$
+DECLARE
+  new_order_id INT;
+  service_item JSONB;
+BEGIN
+  -- Mulai transaksi implisit
+  BEGIN
+    -- Insert order utama
+    INSERT INTO public.orders (
+      user_id, address_id, total_price, status, 
+      is_express, express_fee, promo_code
+    ) VALUES (
+      p_user_id, p_address_id, p_total_price, p_status,
+      p_is_express, p_express_fee, p_promo_code
+    ) RETURNING id INTO new_order_id;
+
+    -- Insert order services dari JSONB
+    FOR service_item IN SELECT * FROM jsonb_array_elements(p_services)
+    LOOP
+      INSERT INTO public.order_services (
+        order_id, service_type_id, quantity, price
+      ) VALUES (
+        new_order_id,
+        (service_item->>'service_type_id')::INT,
+        (service_item->>'quantity')::INT,
+        (service_item->>'price')::NUMERIC
+      );
+    END LOOP;
+
+    -- Update promo usage jika ada promo
+    IF p_promo_code IS NOT NULL THEN
+      UPDATE public.promos
+      SET used_count = used_count + 1
+      WHERE code = p_promo_code;
+    END IF;
+
+    -- Return order ID jika sukses
+    RETURN new_order_id;
+    
+  EXCEPTION
+    WHEN others THEN
+      -- Rollback otomatis jika ada error
+      RAISE EXCEPTION 'Order creation failed: %', SQLERRM;
+  END;
+END;
+$$ LANGUAGE plpgsql;
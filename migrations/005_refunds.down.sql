@@ -0,0 +1,5 @@
+-- 005_refunds.down.sql
+
+DROP INDEX IF EXISTS idx_refunds_payment;
+
+DROP TABLE IF EXISTS public.refunds;
@@ -0,0 +1,14 @@
+-- 005_refunds.up.sql
+
+-- Every refund issued against a payment, partial ones included. The payment
+-- only becomes 'refunded' once the refunds add up to its amount.
+CREATE TABLE IF NOT EXISTS public.refunds (
+  id SERIAL PRIMARY KEY,
+  payment_id INT NOT NULL REFERENCES public.payments(id) ON DELETE CASCADE,
+  amount NUMERIC(10,2) NOT NULL CHECK (amount > 0),
+  reason TEXT NOT NULL,
+  refunded_by UUID REFERENCES public.users(id) ON DELETE SET NULL,
+  created_at timestamp without time zone DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_refunds_payment ON public.refunds (payment_id);
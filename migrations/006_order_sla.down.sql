@@ -0,0 +1,6 @@
+-- 006_order_sla.down.sql
+
+DROP INDEX IF EXISTS idx_orders_promised_by;
+
+ALTER TABLE public.orders
+  DROP COLUMN IF EXISTS promised_by;
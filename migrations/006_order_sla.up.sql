@@ -0,0 +1,9 @@
+-- 006_order_sla.up.sql
+
+-- Deadline promised to the customer when the order is placed, derived from
+-- the longest estimated_duration_hours of its services (shorter for express).
+ALTER TABLE public.orders
+  ADD COLUMN IF NOT EXISTS promised_by timestamp without time zone;
+
+CREATE INDEX IF NOT EXISTS idx_orders_promised_by ON public.orders (promised_by)
+  WHERE status IN ('pending', 'processing', 'cleaning', 'ready_for_delivery');
@@ -0,0 +1,6 @@
+-- 007_complaint_notes.down.sql
+
+DROP INDEX IF EXISTS idx_complaints_status_created;
+DROP INDEX IF EXISTS idx_complaint_notes_complaint;
+
+DROP TABLE IF EXISTS public.complaint_notes;
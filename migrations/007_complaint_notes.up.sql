@@ -0,0 +1,13 @@
+-- 007_complaint_notes.up.sql
+
+-- Notes added by admins while working on a complaint, shown to the customer.
+CREATE TABLE IF NOT EXISTS public.complaint_notes (
+  id SERIAL PRIMARY KEY,
+  complaint_id INT NOT NULL REFERENCES public.complaints(id) ON DELETE CASCADE,
+  author_id UUID REFERENCES public.users(id) ON DELETE SET NULL,
+  note TEXT NOT NULL,
+  created_at timestamp without time zone DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_complaint_notes_complaint ON public.complaint_notes (complaint_id);
+CREATE INDEX IF NOT EXISTS idx_complaints_status_created ON public.complaints (status, created_at);
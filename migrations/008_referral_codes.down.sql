@@ -0,0 +1,9 @@
+-- 008_referral_codes.down.sql
+
+DROP INDEX IF EXISTS idx_referrals_referred;
+
+ALTER TABLE public.referrals
+  DROP COLUMN IF EXISTS completed_at,
+  DROP COLUMN IF EXISTS referred_id;
+
+DROP TABLE IF EXISTS public.referral_codes;
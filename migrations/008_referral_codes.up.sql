@@ -0,0 +1,15 @@
+-- 008_referral_codes.up.sql
+
+-- Invite code of every user, created the first time it's asked for.
+CREATE TABLE IF NOT EXISTS public.referral_codes (
+  user_id UUID PRIMARY KEY REFERENCES public.users(id) ON DELETE CASCADE,
+  code TEXT NOT NULL UNIQUE,
+  created_at timestamp without time zone DEFAULT NOW()
+);
+
+-- Account that signed up with the code; a user can be referred only once.
+ALTER TABLE public.referrals
+  ADD COLUMN IF NOT EXISTS referred_id UUID REFERENCES public.users(id) ON DELETE SET NULL,
+  ADD COLUMN IF NOT EXISTS completed_at timestamp without time zone;
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_referrals_referred ON public.referrals (referred_id);
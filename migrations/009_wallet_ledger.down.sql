@@ -0,0 +1,14 @@
+-- 009_wallet_ledger.down.sql
+
+ALTER TABLE public.payments
+  DROP COLUMN IF EXISTS wallet_amount;
+
+DROP TRIGGER IF EXISTS ledger_entries_balanced ON public.ledger_entries;
+DROP TRIGGER IF EXISTS ledger_entries_immutable ON public.ledger_entries;
+DROP TRIGGER IF EXISTS ledger_transactions_immutable ON public.ledger_transactions;
+DROP FUNCTION IF EXISTS ledger_check_balanced();
+DROP FUNCTION IF EXISTS ledger_reject_change();
+
+DROP TABLE IF EXISTS public.ledger_entries;
+DROP TABLE IF EXISTS public.ledger_transactions;
+DROP TABLE IF EXISTS public.ledger_accounts;
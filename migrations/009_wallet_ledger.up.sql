@@ -0,0 +1,84 @@
+-- 009_wallet_ledger.up.sql
+
+-- Double-entry credit ledger. Every customer wallet is an account, and so is
+-- every source or sink of credit (identified by code). A transaction moves
+-- credit between accounts through entries that add up to zero; balances are
+-- the sum of an account's entries and are never stored.
+CREATE TABLE IF NOT EXISTS public.ledger_accounts (
+  id SERIAL PRIMARY KEY,
+  user_id UUID UNIQUE REFERENCES public.users(id) ON DELETE SET NULL,
+  code TEXT UNIQUE,
+  created_at timestamp without time zone DEFAULT NOW()
+);
+
+INSERT INTO public.ledger_accounts (code) VALUES
+  ('referral_cashback'),
+  ('order_payments'),
+  ('refunds'),
+  ('adjustments')
+ON CONFLICT (code) DO NOTHING;
+
+-- order_id and created_by are plain references: the ledger outlives orders
+-- and users and its rows can't be updated by ON DELETE actions.
+CREATE TABLE IF NOT EXISTS public.ledger_transactions (
+  id SERIAL PRIMARY KEY,
+  kind TEXT NOT NULL CHECK (kind IN (
+    'referral_cashback', 'order_payment', 'order_payment_reversal', 'refund', 'adjustment'
+  )),
+  order_id INT,
+  description TEXT NOT NULL,
+  created_by UUID,
+  created_at timestamp without time zone DEFAULT NOW()
+);
+
+CREATE TABLE IF NOT EXISTS public.ledger_entries (
+  id SERIAL PRIMARY KEY,
+  transaction_id INT NOT NULL REFERENCES public.ledger_transactions(id),
+  account_id INT NOT NULL REFERENCES public.ledger_accounts(id),
+  amount NUMERIC(12,2) NOT NULL CHECK (amount <> 0),
+  created_at timestamp without time zone DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_ledger_entries_account ON public.ledger_entries (account_id, id);
+CREATE INDEX IF NOT EXISTS idx_ledger_entries_transaction ON public.ledger_entries (transaction_id);
+CREATE INDEX IF NOT EXISTS idx_ledger_transactions_order ON public.ledger_transactions (order_id);
+
+-- Ledger rows are append-only; mistakes are corrected with new transactions.
+CREATE OR REPLACE FUNCTION ledger_reject_change()
+RETURNS TRIGGER AS $$
+BEGIN
+  RAISE EXCEPTION '% is append-only', TG_TABLE_NAME;
+END;
+$$ LANGUAGE plpgsql;
+
+DROP TRIGGER IF EXISTS ledger_transactions_immutable ON public.ledger_transactions;
+CREATE TRIGGER ledger_transactions_immutable
+BEFORE UPDATE OR DELETE ON public.ledger_transactions
+FOR EACH ROW EXECUTE FUNCTION ledger_reject_change();
+
+DROP TRIGGER IF EXISTS ledger_entries_immutable ON public.ledger_entries;
+CREATE TRIGGER ledger_entries_immutable
+BEFORE UPDATE OR DELETE ON public.ledger_entries
+FOR EACH ROW EXECUTE FUNCTION ledger_reject_change();
+
+-- Checked at commit, once all entries of a transaction are in.
+CREATE OR REPLACE FUNCTION ledger_check_balanced()
+RETURNS TRIGGER AS $$
+BEGIN
+  IF (SELECT SUM(amount) FROM public.ledger_entries WHERE transaction_id = NEW.transaction_id) <> 0 THEN
+    RAISE EXCEPTION 'ledger transaction % is not balanced', NEW.transaction_id;
+  END IF;
+  RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+DROP TRIGGER IF EXISTS ledger_entries_balanced ON public.ledger_entries;
+CREATE CONSTRAINT TRIGGER ledger_entries_balanced
+AFTER INSERT ON public.ledger_entries
+DEFERRABLE INITIALLY DEFERRED
+FOR EACH ROW EXECUTE FUNCTION ledger_check_balanced();
+
+-- Part of a payment covered by wallet credit; amount is what the payment
+-- method is charged.
+ALTER TABLE public.payments
+  ADD COLUMN IF NOT EXISTS wallet_amount NUMERIC(10,2) NOT NULL DEFAULT 0;
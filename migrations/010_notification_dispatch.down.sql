@@ -0,0 +1,12 @@
+-- 010_notification_dispatch.down.sql
+
+DROP TRIGGER IF EXISTS order_status_history_notify ON public.order_status_history;
+DROP FUNCTION IF EXISTS enqueue_order_status_notification();
+
+DROP INDEX IF EXISTS idx_notifications_pending;
+
+ALTER TABLE public.notifications
+  DROP COLUMN IF EXISTS created_at,
+  DROP COLUMN IF EXISTS last_error,
+  DROP COLUMN IF EXISTS next_attempt_at,
+  DROP COLUMN IF EXISTS attempts;
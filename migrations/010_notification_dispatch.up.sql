@@ -0,0 +1,52 @@
+-- 010_notification_dispatch.up.sql
+
+-- Delivery bookkeeping for the notification dispatcher. Pending rows are
+-- picked up once next_attempt_at has passed; attempts counts the tries.
+ALTER TABLE public.notifications
+  ADD COLUMN IF NOT EXISTS attempts INT NOT NULL DEFAULT 0,
+  ADD COLUMN IF NOT EXISTS next_attempt_at timestamp without time zone NOT NULL DEFAULT NOW(),
+  ADD COLUMN IF NOT EXISTS last_error TEXT,
+  ADD COLUMN IF NOT EXISTS created_at timestamp without time zone DEFAULT NOW();
+
+CREATE INDEX IF NOT EXISTS idx_notifications_pending
+  ON public.notifications (next_attempt_at)
+  WHERE status = 'pending';
+
+-- Every order status change notifies the customer in-app and by email, and
+-- on WhatsApp when they have a phone number.
+CREATE OR REPLACE FUNCTION enqueue_order_status_notification()
+RETURNS TRIGGER AS $$
+DECLARE
+  v_user_id UUID;
+  v_phone TEXT;
+  v_message TEXT;
+BEGIN
+  SELECT o.user_id, u.phone_number INTO v_user_id, v_phone
+  FROM public.orders o
+  JOIN public.users u ON u.id = o.user_id
+  WHERE o.id = NEW.order_id;
+
+  IF NOT FOUND THEN
+    RETURN NULL;
+  END IF;
+
+  v_message := format('Your order #%s is now %s.', NEW.order_id, replace(NEW.status::text, '_', ' '));
+
+  INSERT INTO public.notifications (user_id, order_id, message, channel)
+  VALUES
+    (v_user_id, NEW.order_id, v_message, 'in_app'),
+    (v_user_id, NEW.order_id, v_message, 'email');
+
+  IF COALESCE(v_phone, '') <> '' THEN
+    INSERT INTO public.notifications (user_id, order_id, message, channel)
+    VALUES (v_user_id, NEW.order_id, v_message, 'whatsapp');
+  END IF;
+
+  RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+DROP TRIGGER IF EXISTS order_status_history_notify ON public.order_status_history;
+CREATE TRIGGER order_status_history_notify
+AFTER INSERT ON public.order_status_history
+FOR EACH ROW EXECUTE FUNCTION enqueue_order_status_notification();
@@ -0,0 +1,6 @@
+-- 011_notification_read_at.down.sql
+
+DROP INDEX IF EXISTS idx_notifications_inbox;
+
+ALTER TABLE public.notifications
+  DROP COLUMN IF EXISTS read_at;
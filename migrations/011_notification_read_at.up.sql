@@ -0,0 +1,10 @@
+-- 011_notification_read_at.up.sql
+
+-- When the customer read an in-app notification; NULL while unread.
+ALTER TABLE public.notifications
+  ADD COLUMN IF NOT EXISTS read_at timestamp without time zone;
+
+-- In-app inbox, newest first, and its unread count.
+CREATE INDEX IF NOT EXISTS idx_notifications_inbox
+  ON public.notifications (user_id, id)
+  WHERE channel = 'in_app' AND status = 'sent';
@@ -0,0 +1,11 @@
+-- 012_blog_posts.down.sql
+
+DROP INDEX IF EXISTS idx_blog_posts_slug;
+
+ALTER TABLE public.blog_posts
+  ALTER COLUMN published_at SET DEFAULT NOW(),
+  DROP COLUMN IF EXISTS updated_at,
+  DROP COLUMN IF EXISTS created_at,
+  DROP COLUMN IF EXISTS content_html,
+  DROP COLUMN IF EXISTS excerpt,
+  DROP COLUMN IF EXISTS slug;
@@ -0,0 +1,20 @@
+-- 012_blog_posts.up.sql
+
+-- Posts are addressed by slug. content holds the Markdown source and
+-- content_html its sanitized rendering, made when the post is saved.
+-- A post is public once is_published is set and published_at has passed,
+-- so a future published_at schedules it.
+ALTER TABLE public.blog_posts
+  ADD COLUMN IF NOT EXISTS slug TEXT,
+  ADD COLUMN IF NOT EXISTS excerpt TEXT,
+  ADD COLUMN IF NOT EXISTS content_html TEXT NOT NULL DEFAULT '',
+  ADD COLUMN IF NOT EXISTS created_at timestamp without time zone DEFAULT NOW(),
+  ADD COLUMN IF NOT EXISTS updated_at timestamp without time zone DEFAULT NOW();
+
+UPDATE public.blog_posts SET slug = 'post-' || id WHERE slug IS NULL;
+
+ALTER TABLE public.blog_posts
+  ALTER COLUMN slug SET NOT NULL,
+  ALTER COLUMN published_at DROP DEFAULT;
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_blog_posts_slug ON public.blog_posts (slug);
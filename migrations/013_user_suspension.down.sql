@@ -0,0 +1,3 @@
+-- 013_user_suspension.down.sql
+
+ALTER TABLE auth.users DROP COLUMN IF EXISTS suspended_at;
@@ -0,0 +1,6 @@
+-- 013_user_suspension.up.sql
+
+-- When an admin suspended the account; NULL while it is active. Suspended
+-- users can't log in and their tokens are rejected.
+ALTER TABLE auth.users
+  ADD COLUMN IF NOT EXISTS suspended_at timestamp without time zone;
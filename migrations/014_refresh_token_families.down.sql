@@ -0,0 +1,9 @@
+-- 014_refresh_token_families.down.sql
+
+DROP INDEX IF EXISTS auth.idx_refresh_token_family;
+DROP INDEX IF EXISTS auth.idx_refresh_token_hash;
+
+ALTER TABLE auth.refresh_tokens
+  DROP COLUMN IF EXISTS replaced_by,
+  DROP COLUMN IF EXISTS rotated_at,
+  DROP COLUMN IF EXISTS family_id;
@@ -0,0 +1,13 @@
+-- 014_refresh_token_families.up.sql
+
+-- Every login starts a token family; each refresh rotates the presented
+-- token into a new one of the same family, recording when it was rotated
+-- and by which token. A rotated token that is presented again has been
+-- copied, so the whole family gets revoked.
+ALTER TABLE auth.refresh_tokens
+  ADD COLUMN IF NOT EXISTS family_id UUID NOT NULL DEFAULT uuid_generate_v4(),
+  ADD COLUMN IF NOT EXISTS rotated_at timestamp without time zone,
+  ADD COLUMN IF NOT EXISTS replaced_by UUID REFERENCES auth.refresh_tokens(id) ON DELETE SET NULL;
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_refresh_token_hash ON auth.refresh_tokens (token_hash);
+CREATE INDEX IF NOT EXISTS idx_refresh_token_family ON auth.refresh_tokens (family_id);
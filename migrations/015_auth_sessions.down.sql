@@ -0,0 +1,3 @@
+-- 015_auth_sessions.down.sql
+
+DROP TABLE IF EXISTS auth.sessions;
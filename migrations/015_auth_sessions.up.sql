@@ -0,0 +1,17 @@
+-- 015_auth_sessions.up.sql
+
+-- One row per refresh token family, that is per login on a device, sharing
+-- the family's ID. The session is active while its family still holds a
+-- usable refresh token; last_used_at, user_agent and ip_address follow the
+-- latest refresh.
+CREATE TABLE IF NOT EXISTS auth.sessions (
+  id UUID PRIMARY KEY,
+  user_id UUID NOT NULL REFERENCES auth.users(id) ON DELETE CASCADE,
+  device_name TEXT,
+  user_agent TEXT,
+  ip_address TEXT,
+  created_at timestamp without time zone DEFAULT NOW(),
+  last_used_at timestamp without time zone DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_sessions_user ON auth.sessions (user_id, last_used_at DESC);
@@ -0,0 +1,5 @@
+-- 016_blog_render_on_read.down.sql
+
+-- Posts come back without HTML; saving them again renders it.
+ALTER TABLE public.blog_posts
+  ADD COLUMN IF NOT EXISTS content_html TEXT NOT NULL DEFAULT '';
@@ -0,0 +1,5 @@
+-- 016_blog_render_on_read.up.sql
+
+-- Blog posts are rendered from their Markdown source whenever they are
+-- read, so sanitizer fixes apply to every post without re-saving it.
+ALTER TABLE public.blog_posts DROP COLUMN IF EXISTS content_html;
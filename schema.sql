@@ -11,7 +11,8 @@ CREATE TABLE IF NOT EXISTS auth.users (
   created_at TIMESTAMPTZ DEFAULT NOW(),
   updated_at TIMESTAMPTZ DEFAULT NOW(),
   confirmed_at TIMESTAMPTZ,
-  last_sign_in_at TIMESTAMPTZ
+  last_sign_in_at TIMESTAMPTZ,
+  suspended_at TIMESTAMPTZ
 );
 
 -------------------------------
@@ -67,7 +68,8 @@ CREATE TABLE public.service_types (
   base_price NUMERIC(10,2) NOT NULL,
   estimated_duration_hours INT,
   is_eco_friendly BOOLEAN DEFAULT false,
-  created_at TIMESTAMPTZ DEFAULT NOW()
+  created_at TIMESTAMPTZ DEFAULT NOW(),
+  archived_at TIMESTAMPTZ
 );
 
 -- Orders
@@ -81,7 +83,8 @@ CREATE TABLE public.orders (
   express_fee NUMERIC(10,2) DEFAULT 0,
   promo_code TEXT,
   created_at TIMESTAMPTZ DEFAULT NOW(),
-  completed_at TIMESTAMPTZ
+  completed_at TIMESTAMPTZ,
+  promised_by TIMESTAMPTZ
 );
 
 -- Order Services
@@ -101,7 +104,18 @@ CREATE TABLE public.payments (
   amount NUMERIC(10,2) NOT NULL,
   transaction_id TEXT,
   status payment_status DEFAULT 'pending',
-  paid_at TIMESTAMPTZ
+  paid_at TIMESTAMPTZ,
+  wallet_amount NUMERIC(10,2) NOT NULL DEFAULT 0
+);
+
+-- Refunds (partial refunds included)
+CREATE TABLE public.refunds (
+  id SERIAL PRIMARY KEY,
+  payment_id INT NOT NULL REFERENCES public.payments(id) ON DELETE CASCADE,
+  amount NUMERIC(10,2) NOT NULL CHECK (amount > 0),
+  reason TEXT NOT NULL,
+  refunded_by UUID REFERENCES public.users(id) ON DELETE SET NULL,
+  created_at TIMESTAMPTZ DEFAULT NOW()
 );
 
 -- Promos
@@ -133,7 +147,12 @@ CREATE TABLE public.notifications (
   message TEXT NOT NULL,
   channel notification_channel NOT NULL,
   status TEXT DEFAULT 'pending',
-  sent_at TIMESTAMPTZ
+  sent_at TIMESTAMPTZ,
+  attempts INT NOT NULL DEFAULT 0,
+  next_attempt_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+  last_error TEXT,
+  created_at TIMESTAMPTZ DEFAULT NOW(),
+  read_at TIMESTAMPTZ
 );
 
 -- Photo Evidences
@@ -161,8 +180,12 @@ CREATE TABLE public.blog_posts (
   title TEXT NOT NULL,
   content TEXT NOT NULL,
   author_id UUID REFERENCES public.users(id) ON DELETE SET NULL,
-  published_at TIMESTAMPTZ DEFAULT NOW(),
-  is_published BOOLEAN DEFAULT false
+  published_at TIMESTAMPTZ,
+  is_published BOOLEAN DEFAULT false,
+  slug TEXT NOT NULL,
+  excerpt TEXT,
+  created_at TIMESTAMPTZ DEFAULT NOW(),
+  updated_at TIMESTAMPTZ DEFAULT NOW()
 );
 
 -- Complaints
@@ -176,6 +199,15 @@ CREATE TABLE public.complaints (
   resolved_at TIMESTAMPTZ
 );
 
+-- Complaint Notes
+CREATE TABLE public.complaint_notes (
+  id SERIAL PRIMARY KEY,
+  complaint_id INT NOT NULL REFERENCES public.complaints(id) ON DELETE CASCADE,
+  author_id UUID REFERENCES public.users(id) ON DELETE SET NULL,
+  note TEXT NOT NULL,
+  created_at TIMESTAMPTZ DEFAULT NOW()
+);
+
 -- Referrals
 CREATE TABLE public.referrals (
   id SERIAL PRIMARY KEY,
@@ -183,6 +215,48 @@ CREATE TABLE public.referrals (
   referred_email TEXT NOT NULL,
   is_completed BOOLEAN DEFAULT false,
   cashback_amount NUMERIC(10,2) DEFAULT 0,
+  created_at TIMESTAMPTZ DEFAULT NOW(),
+  referred_id UUID REFERENCES public.users(id) ON DELETE SET NULL,
+  completed_at TIMESTAMPTZ
+);
+
+-- Referral Codes
+CREATE TABLE public.referral_codes (
+  user_id UUID PRIMARY KEY REFERENCES public.users(id) ON DELETE CASCADE,
+  code TEXT NOT NULL UNIQUE,
+  created_at TIMESTAMPTZ DEFAULT NOW()
+);
+
+-- Wallet Ledger (double-entry, append-only)
+CREATE TABLE public.ledger_accounts (
+  id SERIAL PRIMARY KEY,
+  user_id UUID UNIQUE REFERENCES public.users(id) ON DELETE SET NULL,
+  code TEXT UNIQUE,
+  created_at TIMESTAMPTZ DEFAULT NOW()
+);
+
+INSERT INTO public.ledger_accounts (code) VALUES
+  ('referral_cashback'),
+  ('order_payments'),
+  ('refunds'),
+  ('adjustments');
+
+CREATE TABLE public.ledger_transactions (
+  id SERIAL PRIMARY KEY,
+  kind TEXT NOT NULL CHECK (kind IN (
+    'referral_cashback', 'order_payment', 'order_payment_reversal', 'refund', 'adjustment'
+  )),
+  order_id INT,
+  description TEXT NOT NULL,
+  created_by UUID,
+  created_at TIMESTAMPTZ DEFAULT NOW()
+);
+
+CREATE TABLE public.ledger_entries (
+  id SERIAL PRIMARY KEY,
+  transaction_id INT NOT NULL REFERENCES public.ledger_transactions(id),
+  account_id INT NOT NULL REFERENCES public.ledger_accounts(id),
+  amount NUMERIC(12,2) NOT NULL CHECK (amount <> 0),
   created_at TIMESTAMPTZ DEFAULT NOW()
 );
 
@@ -195,7 +269,20 @@ CREATE TABLE auth.refresh_tokens (
   token_hash TEXT NOT NULL,
   expires_at TIMESTAMPTZ NOT NULL,
   created_at TIMESTAMPTZ DEFAULT NOW(),
-  revoked BOOLEAN DEFAULT false
+  revoked BOOLEAN DEFAULT false,
+  family_id UUID NOT NULL DEFAULT uuid_generate_v4(),
+  rotated_at TIMESTAMPTZ,
+  replaced_by UUID REFERENCES auth.refresh_tokens(id) ON DELETE SET NULL
+);
+
+CREATE TABLE auth.sessions (
+  id UUID PRIMARY KEY,
+  user_id UUID NOT NULL REFERENCES auth.users(id) ON DELETE CASCADE,
+  device_name TEXT,
+  user_agent TEXT,
+  ip_address TEXT,
+  created_at TIMESTAMPTZ DEFAULT NOW(),
+  last_used_at TIMESTAMPTZ DEFAULT NOW()
 );
 
 CREATE TABLE auth.audit_log (
@@ -229,14 +316,23 @@ FOR EACH ROW EXECUTE FUNCTION delete_user_cascade();
 -- Users
 CREATE INDEX idx_users_email ON public.users (email);
 
+-- Service Types
+CREATE INDEX idx_service_types_active ON public.service_types (base_price)
+  WHERE archived_at IS NULL;
+
 -- Orders
 CREATE INDEX idx_orders_user_status ON public.orders (user_id, status);
 CREATE INDEX idx_orders_created ON public.orders (created_at);
+CREATE INDEX idx_orders_promised_by ON public.orders (promised_by)
+  WHERE status IN ('pending', 'processing', 'cleaning', 'ready_for_delivery');
 
 -- Payments
 CREATE INDEX idx_payments_status ON public.payments (status);
 CREATE INDEX idx_payments_transaction ON public.payments (transaction_id);
 
+-- Refunds
+CREATE INDEX idx_refunds_payment ON public.refunds (payment_id);
+
 -- Order Services
 CREATE INDEX idx_order_services_order ON public.order_services (order_id);
 
@@ -249,6 +345,8 @@ CREATE INDEX idx_reviews_order ON public.reviews (order_id);
 
 -- Notifications
 CREATE INDEX idx_notifications_user ON public.notifications (user_id);
+CREATE INDEX idx_notifications_pending ON public.notifications (next_attempt_at) WHERE status = 'pending';
+CREATE INDEX idx_notifications_inbox ON public.notifications (user_id, id) WHERE channel = 'in_app' AND status = 'sent';
 
 -- Photo Evidences
 CREATE INDEX idx_photo_evidences_order ON public.photo_evidences (order_id);
@@ -259,18 +357,30 @@ CREATE INDEX idx_order_status_history_order ON public.order_status_history (orde
 -- Blog Posts
 CREATE INDEX idx_blog_posts_author ON public.blog_posts (author_id);
 CREATE INDEX idx_blog_posts_published ON public.blog_posts (published_at);
+CREATE UNIQUE INDEX idx_blog_posts_slug ON public.blog_posts (slug);
 
 -- Complaints
 CREATE INDEX idx_complaints_user ON public.complaints (user_id);
 CREATE INDEX idx_complaints_order ON public.complaints (order_id);
+CREATE INDEX idx_complaints_status_created ON public.complaints (status, created_at);
+CREATE INDEX idx_complaint_notes_complaint ON public.complaint_notes (complaint_id);
 
 -- Referrals
 CREATE INDEX idx_referrals_referrer ON public.referrals (referrer_id);
 CREATE INDEX idx_referrals_email ON public.referrals (referred_email);
+CREATE UNIQUE INDEX idx_referrals_referred ON public.referrals (referred_id);
 
 -- Refresh Tokens
 CREATE INDEX idx_refresh_token_user ON auth.refresh_tokens (user_id);
 CREATE INDEX idx_refresh_token_expiry ON auth.refresh_tokens (expires_at);
+CREATE UNIQUE INDEX idx_refresh_token_hash ON auth.refresh_tokens (token_hash);
+CREATE INDEX idx_refresh_token_family ON auth.refresh_tokens (family_id);
+CREATE INDEX idx_sessions_user ON auth.sessions (user_id, last_used_at DESC);
+
+-- Wallet Ledger
+CREATE INDEX idx_ledger_entries_account ON public.ledger_entries (account_id, id);
+CREATE INDEX idx_ledger_entries_transaction ON public.ledger_entries (transaction_id);
+CREATE INDEX idx_ledger_transactions_order ON public.ledger_transactions (order_id);
 
 -------------------------------
 -- 7. Business Transaction Functions
@@ -314,11 +424,20 @@ BEGIN
       );
     END LOOP;
 
-    -- Update promo usage jika ada promo
+    -- Redeem promo: the row lock taken by UPDATE serialises concurrent
+    -- orders, and the conditions are re-checked against the latest row, so
+    -- used_count can never pass max_usage.
     IF p_promo_code IS NOT NULL THEN
       UPDATE public.promos
-      SET used_count = used_count + 1
-      WHERE code = p_promo_code;
+      SET used_count = COALESCE(used_count, 0) + 1
+      WHERE code = p_promo_code
+        AND (valid_until IS NULL OR valid_until > NOW())
+        AND (max_usage IS NULL OR COALESCE(used_count, 0) < max_usage);
+
+      IF NOT FOUND THEN
+        RAISE EXCEPTION 'promo % is not redeemable', p_promo_code
+          USING ERRCODE = 'PR001';
+      END IF;
     END IF;
 
     -- Return order ID jika sukses
@@ -327,7 +446,8 @@ BEGIN
   EXCEPTION
     WHEN others THEN
       -- Rollback otomatis jika ada error
-      RAISE EXCEPTION 'Order creation failed: %', SQLERRM;
+      RAISE EXCEPTION 'Order creation failed: %', SQLERRM
+        USING ERRCODE = SQLSTATE;
   END;
 END;
 $$ LANGUAGE plpgsql;
@@ -409,6 +529,76 @@ BEGIN
 END;
 $$ LANGUAGE plpgsql;
 
+-- Ledger rows are append-only
+CREATE OR REPLACE FUNCTION ledger_reject_change()
+RETURNS TRIGGER AS $$
+BEGIN
+  RAISE EXCEPTION '% is append-only', TG_TABLE_NAME;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER ledger_transactions_immutable
+BEFORE UPDATE OR DELETE ON public.ledger_transactions
+FOR EACH ROW EXECUTE FUNCTION ledger_reject_change();
+
+CREATE TRIGGER ledger_entries_immutable
+BEFORE UPDATE OR DELETE ON public.ledger_entries
+FOR EACH ROW EXECUTE FUNCTION ledger_reject_change();
+
+-- Entries of a ledger transaction must add up to zero at commit
+CREATE OR REPLACE FUNCTION ledger_check_balanced()
+RETURNS TRIGGER AS $$
+BEGIN
+  IF (SELECT SUM(amount) FROM public.ledger_entries WHERE transaction_id = NEW.transaction_id) <> 0 THEN
+    RAISE EXCEPTION 'ledger transaction % is not balanced', NEW.transaction_id;
+  END IF;
+  RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE CONSTRAINT TRIGGER ledger_entries_balanced
+AFTER INSERT ON public.ledger_entries
+DEFERRABLE INITIALLY DEFERRED
+FOR EACH ROW EXECUTE FUNCTION ledger_check_balanced();
+
+-- Every order status change notifies the customer in-app and by email, and
+-- on WhatsApp when they have a phone number
+CREATE OR REPLACE FUNCTION enqueue_order_status_notification()
+RETURNS TRIGGER AS $$
+DECLARE
+  v_user_id UUID;
+  v_phone TEXT;
+  v_message TEXT;
+BEGIN
+  SELECT o.user_id, u.phone_number INTO v_user_id, v_phone
+  FROM public.orders o
+  JOIN public.users u ON u.id = o.user_id
+  WHERE o.id = NEW.order_id;
+
+  IF NOT FOUND THEN
+    RETURN NULL;
+  END IF;
+
+  v_message := format('Your order #%s is now %s.', NEW.order_id, replace(NEW.status::text, '_', ' '));
+
+  INSERT INTO public.notifications (user_id, order_id, message, channel)
+  VALUES
+    (v_user_id, NEW.order_id, v_message, 'in_app'),
+    (v_user_id, NEW.order_id, v_message, 'email');
+
+  IF COALESCE(v_phone, '') <> '' THEN
+    INSERT INTO public.notifications (user_id, order_id, message, channel)
+    VALUES (v_user_id, NEW.order_id, v_message, 'whatsapp');
+  END IF;
+
+  RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER order_status_history_notify
+AFTER INSERT ON public.order_status_history
+FOR EACH ROW EXECUTE FUNCTION enqueue_order_status_notification();
+
 -------------------------------
 -- 8. Security & Realtime
 -------------------------------